@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"sync"
+)
+
+type contextKey struct{}
+
+// Trail holds the details of the request an audit event is recorded for,
+// and collects the events of failed actions so they can be saved after the request's transaction is rolled back.
+type Trail struct {
+	// IPAddress is the ip address of the client that made the request.
+	IPAddress string
+
+	// UserAgent is the user agent of the client that made the request.
+	UserAgent string
+
+	mutex    sync.Mutex
+	failures []*models.AuditEvent
+}
+
+// CreateTrail creates a new trail for a request with the given ip address and user agent.
+// Values longer than the audit event's max lengths are truncated.
+func CreateTrail(ipAddress string, userAgent string) *Trail {
+	return &Trail{
+		IPAddress: common.TruncateString(ipAddress, models.AuditEventIPAddressMaxLength),
+		UserAgent: common.TruncateString(userAgent, models.AuditEventUserAgentMaxLength),
+	}
+}
+
+// NewEvent creates a new audit event with the trail's request details.
+func (t *Trail) NewEvent(action string, outcome string) *models.AuditEvent {
+	event := models.CreateNewAuditEvent(action, outcome)
+	event.IPAddress = t.IPAddress
+	event.UserAgent = t.UserAgent
+
+	return event
+}
+
+// AddFailure adds the event of a failed action to the trail.
+// Failures are saved separately since the transaction of a failed request is rolled back.
+func (t *Trail) AddFailure(event *models.AuditEvent) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.failures = append(t.failures, event)
+}
+
+// Failures returns the events of failed actions added to the trail.
+func (t *Trail) Failures() []*models.AuditEvent {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return append([]*models.AuditEvent{}, t.failures...)
+}
+
+// NewContext returns a child of the context that carries the trail.
+func NewContext(ctx context.Context, t *Trail) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the trail carried by the context.
+// If the context has no trail, such as outside of a request, an empty trail is returned.
+func FromContext(ctx context.Context) *Trail {
+	t, ok := ctx.Value(contextKey{}).(*Trail)
+	if !ok {
+		return &Trail{}
+	}
+
+	return t
+}
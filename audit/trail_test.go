@@ -0,0 +1,79 @@
+package audit_test
+
+import (
+	"authserver/audit"
+	"authserver/models"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TrailTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TrailTestSuite) TestCreateTrail_TruncatesLongValues() {
+	//arrange
+	userAgent := strings.Repeat("a", models.AuditEventUserAgentMaxLength+10)
+
+	//act
+	trail := audit.CreateTrail("127.0.0.1", userAgent)
+
+	//assert
+	suite.Equal("127.0.0.1", trail.IPAddress)
+	suite.Len(trail.UserAgent, models.AuditEventUserAgentMaxLength)
+}
+
+func (suite *TrailTestSuite) TestNewEvent_CreatesEventWithRequestDetails() {
+	//arrange
+	trail := audit.CreateTrail("127.0.0.1", "user agent")
+
+	//act
+	event := trail.NewEvent(models.AuditActionLogin, models.AuditOutcomeSuccess)
+
+	//assert
+	suite.Equal(models.ValidateAuditEventValid, event.Validate())
+	suite.Equal(models.AuditActionLogin, event.Action)
+	suite.Equal(models.AuditOutcomeSuccess, event.Outcome)
+	suite.Equal("127.0.0.1", event.IPAddress)
+	suite.Equal("user agent", event.UserAgent)
+}
+
+func (suite *TrailTestSuite) TestAddFailure_AddsEventToFailures() {
+	//arrange
+	trail := audit.CreateTrail("", "")
+	event := trail.NewEvent(models.AuditActionLogin, models.AuditOutcomeFailure)
+
+	//act
+	trail.AddFailure(event)
+
+	//assert
+	suite.Equal([]*models.AuditEvent{event}, trail.Failures())
+}
+
+func (suite *TrailTestSuite) TestFromContext_WithTrail_ReturnsTrail() {
+	//arrange
+	trail := audit.CreateTrail("", "")
+	ctx := audit.NewContext(context.Background(), trail)
+
+	//act
+	result := audit.FromContext(ctx)
+
+	//assert
+	suite.Same(trail, result)
+}
+
+func (suite *TrailTestSuite) TestFromContext_WithoutTrail_ReturnsEmptyTrail() {
+	//act
+	result := audit.FromContext(context.Background())
+
+	//assert
+	suite.Require().NotNil(result)
+	suite.Empty(result.Failures())
+}
+
+func TestTrailTestSuite(t *testing.T) {
+	suite.Run(t, &TrailTestSuite{})
+}
@@ -1,10 +1,33 @@
 package common
 
-import (
-	"errors"
-)
+import "strings"
 
 // ChainError will combine the error message and the message together in an easy to read manner.
+// The chained error wraps the original error, so it can still be inspected with errors.Is and errors.As.
 func ChainError(message string, err error) error {
-	return errors.New(message + "\n\t" + err.Error())
+	return chainedError{
+		message: message,
+		err:     err,
+	}
+}
+
+type chainedError struct {
+	message string
+	err     error
+}
+
+func (e chainedError) Error() string {
+	return e.message + "\n\t" + e.err.Error()
+}
+
+func (e chainedError) Unwrap() error {
+	return e.err
+}
+
+// TruncateString shortens the string to at most length bytes, dropping any invalid utf8 so it can be stored safely.
+func TruncateString(str string, length int) string {
+	if len(str) > length {
+		str = str[:length]
+	}
+	return strings.ToValidUTF8(str, "")
 }
@@ -1,69 +1,203 @@
 package requesterror
 
-import "errors"
+import "net/http"
 
+// Error codes identify the kind of a request error, so clients can handle errors without parsing their messages.
 const (
-	ErrorTypeNone     = iota
-	ErrorTypeInternal = iota
-	ErrorTypeClient   = iota
+	CodeInternal             = "internal_error"
+	CodeInvalidRequest       = "invalid_request"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeConflict             = "conflict"
+	CodeRateLimited          = "rate_limited"
+	CodePayloadTooLarge      = "payload_too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
 )
 
-// RequestError is an error with an added type field to determine how it should be handled
+// OAuthErrorInvalidClient is the oauth error name for requests where the client failed to authenticate.
+const OAuthErrorInvalidClient = "invalid_client"
+
+// Sentinel errors for each code, to be used as targets of errors.Is.
+var (
+	ErrInternal             = &RequestError{Code: CodeInternal}
+	ErrInvalidRequest       = &RequestError{Code: CodeInvalidRequest}
+	ErrUnauthorized         = &RequestError{Code: CodeUnauthorized}
+	ErrForbidden            = &RequestError{Code: CodeForbidden}
+	ErrNotFound             = &RequestError{Code: CodeNotFound}
+	ErrConflict             = &RequestError{Code: CodeConflict}
+	ErrRateLimited          = &RequestError{Code: CodeRateLimited}
+	ErrPayloadTooLarge      = &RequestError{Code: CodePayloadTooLarge}
+	ErrUnsupportedMediaType = &RequestError{Code: CodeUnsupportedMediaType}
+)
+
+var statuses = map[string]int{
+	CodeInternal:             http.StatusInternalServerError,
+	CodeInvalidRequest:       http.StatusBadRequest,
+	CodeUnauthorized:         http.StatusUnauthorized,
+	CodeForbidden:            http.StatusForbidden,
+	CodeNotFound:             http.StatusNotFound,
+	CodeConflict:             http.StatusConflict,
+	CodeRateLimited:          http.StatusTooManyRequests,
+	CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+}
+
+// FieldError describes why a single field of the request is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// RequestError is an error that determines how the request that caused it should be responded to.
 type RequestError struct {
-	error
-	Type int
+	// Code is the machine readable kind of the error. One of the Code constants.
+	Code string
+
+	// Message is the human readable message returned to the client.
+	Message string
+
+	// Fields are the details of which fields of the request are invalid, if any.
+	Fields []FieldError
+
+	// OAuthError is the error name defined by the oauth spec.
+	// If set, the error is returned to the client as an oauth error response.
+	OAuthError string
+
+	// Scope is the scope required to access the resource, if the error is caused by the request having insufficient scope.
+	Scope string
+
+	// Err is the underlying cause of the error, if any. It is never returned to the client.
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	if e.Err != nil {
+		return e.Message + "\n\t" + e.Err.Error()
+	}
+	return e.Message
 }
 
-// NoError returns a RequestError with type ErrorTypeNone
-func NoError() RequestError {
-	return RequestError{
-		error: nil,
-		Type:  ErrorTypeNone,
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns whether the target is a request error with the same code, so errors.Is can be used with the sentinel errors.
+func (e *RequestError) Is(target error) bool {
+	t, ok := target.(*RequestError)
+	return ok && t.Code == e.Code
+}
+
+// Status returns the http status code the error should be responded to with.
+func (e *RequestError) Status() int {
+	status, ok := statuses[e.Code]
+	if !ok {
+		return http.StatusInternalServerError
 	}
+	return status
 }
 
-// InternalError returns a RequestError with type ErrorTypeInternal and an internal error message
-func InternalError() RequestError {
-	return RequestError{
-		error: errors.New("an internal error occurred"),
-		Type:  ErrorTypeInternal,
+// InternalError returns a RequestError with code CodeInternal and an internal error message
+func InternalError() error {
+	return &RequestError{
+		Code:    CodeInternal,
+		Message: "an internal error occurred",
 	}
 }
 
-// ClientError returns a RequestError with type ErrorTypeClient and the provided message
-func ClientError(message string) RequestError {
-	return RequestError{
-		error: errors.New(message),
-		Type:  ErrorTypeClient,
+// InvalidRequestError returns a RequestError with code CodeInvalidRequest, the provided message and the details of any invalid fields
+func InvalidRequestError(message string, fields ...FieldError) error {
+	return &RequestError{
+		Code:    CodeInvalidRequest,
+		Message: message,
+		Fields:  fields,
 	}
 }
 
-// OAuthRequestError is a RequestError with an added error name field to determine the oauth error name
-type OAuthRequestError struct {
-	RequestError
-	ErrorName string
+// InvalidFieldError returns a RequestError with code CodeInvalidRequest and the provided message, describing the provided field as invalid
+func InvalidFieldError(field string, message string) error {
+	return InvalidRequestError(message, FieldError{
+		Field:   field,
+		Message: message,
+	})
 }
 
-// OAuthNoError returns an OAuthRequestError with type ErrorTypeNone
-func OAuthNoError() OAuthRequestError {
-	return OAuthRequestError{
-		RequestError: NoError(),
-		ErrorName:    "",
+// UnauthorizedError returns a RequestError with code CodeUnauthorized and the provided message
+func UnauthorizedError(message string) error {
+	return &RequestError{
+		Code:    CodeUnauthorized,
+		Message: message,
 	}
 }
 
-// OAuthInternalError returns an OAuthRequestError with type ErrorTypeInternal and an internal error message
-func OAuthInternalError() OAuthRequestError {
-	return OAuthRequestError{
-		RequestError: InternalError(),
-		ErrorName:    "",
+// ForbiddenError returns a RequestError with code CodeForbidden and the provided message
+func ForbiddenError(message string) error {
+	return &RequestError{
+		Code:    CodeForbidden,
+		Message: message,
 	}
 }
 
-// ClientError returns a RequestError with type ErrorTypeClient and the provided error name and message
-func OAuthClientError(errorName string, message string) OAuthRequestError {
-	return OAuthRequestError{
-		RequestError: ClientError(message),
-		ErrorName:    errorName,
+// InsufficientScopeError returns a RequestError with code CodeForbidden and the provided message, requiring the provided scope
+func InsufficientScopeError(scope string, message string) error {
+	return &RequestError{
+		Code:    CodeForbidden,
+		Message: message,
+		Scope:   scope,
+	}
+}
+
+// NotFoundError returns a RequestError with code CodeNotFound and the provided message
+func NotFoundError(message string) error {
+	return &RequestError{
+		Code:    CodeNotFound,
+		Message: message,
+	}
+}
+
+// ConflictError returns a RequestError with code CodeConflict and the provided message
+func ConflictError(message string) error {
+	return &RequestError{
+		Code:    CodeConflict,
+		Message: message,
+	}
+}
+
+// RateLimitedError returns a RequestError with code CodeRateLimited and the provided message
+func RateLimitedError(message string) error {
+	return &RequestError{
+		Code:    CodeRateLimited,
+		Message: message,
+	}
+}
+
+// PayloadTooLargeError returns a RequestError with code CodePayloadTooLarge and the provided message
+func PayloadTooLargeError(message string) error {
+	return &RequestError{
+		Code:    CodePayloadTooLarge,
+		Message: message,
+	}
+}
+
+// UnsupportedMediaTypeError returns a RequestError with code CodeUnsupportedMediaType and the provided message
+func UnsupportedMediaTypeError(message string) error {
+	return &RequestError{
+		Code:    CodeUnsupportedMediaType,
+		Message: message,
+	}
+}
+
+// OAuthClientError returns a RequestError with the provided oauth error name and message.
+// Its code is CodeUnauthorized if the client failed to authenticate, otherwise it is CodeInvalidRequest.
+func OAuthClientError(errorName string, message string) error {
+	code := CodeInvalidRequest
+	if errorName == OAuthErrorInvalidClient {
+		code = CodeUnauthorized
+	}
+
+	return &RequestError{
+		Code:       code,
+		Message:    message,
+		OAuthError: errorName,
 	}
 }
@@ -0,0 +1,84 @@
+package requesterror_test
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestErrorTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RequestErrorTestSuite) TestIs_WithChainedErrorOfSameCode_ReturnsTrue() {
+	//arrange
+	err := common.ChainError("error deleting user", requesterror.NotFoundError("user not found"))
+
+	//act
+	result := errors.Is(err, requesterror.ErrNotFound)
+
+	//assert
+	suite.True(result)
+	suite.False(errors.Is(err, requesterror.ErrConflict))
+}
+
+func (suite *RequestErrorTestSuite) TestAs_WithChainedError_ReturnsRequestError() {
+	//arrange
+	err := common.ChainError("error creating user", requesterror.InvalidFieldError("username", "username cannot be empty"))
+
+	//act
+	var rerr *requesterror.RequestError
+	ok := errors.As(err, &rerr)
+
+	//assert
+	suite.Require().True(ok)
+	suite.Equal(requesterror.CodeInvalidRequest, rerr.Code)
+	suite.Equal([]requesterror.FieldError{{Field: "username", Message: "username cannot be empty"}}, rerr.Fields)
+}
+
+func (suite *RequestErrorTestSuite) TestUnwrap_ReturnsCause() {
+	//arrange
+	cause := errors.New("cause")
+	err := &requesterror.RequestError{Code: requesterror.CodeInternal, Message: "message", Err: cause}
+
+	//act
+	result := errors.Is(err, cause)
+
+	//assert
+	suite.True(result)
+	common.AssertError(&suite.Suite, err, "message", "cause")
+}
+
+func (suite *RequestErrorTestSuite) TestStatus_ReturnsStatusOfCode() {
+	statuses := map[string]int{
+		requesterror.CodeInternal:             http.StatusInternalServerError,
+		requesterror.CodeInvalidRequest:       http.StatusBadRequest,
+		requesterror.CodeUnauthorized:         http.StatusUnauthorized,
+		requesterror.CodeForbidden:            http.StatusForbidden,
+		requesterror.CodeNotFound:             http.StatusNotFound,
+		requesterror.CodeConflict:             http.StatusConflict,
+		requesterror.CodeRateLimited:          http.StatusTooManyRequests,
+		requesterror.CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+		requesterror.CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+		"unknown":                             http.StatusInternalServerError,
+	}
+
+	for code, expected := range statuses {
+		//arrange
+		err := &requesterror.RequestError{Code: code}
+
+		//act
+		status := err.Status()
+
+		//assert
+		suite.Equal(expected, status, code)
+	}
+}
+
+func TestRequestErrorTestSuite(t *testing.T) {
+	suite.Run(t, &RequestErrorTestSuite{})
+}
@@ -1,6 +1,9 @@
 package common
 
-import "net/http"
+import (
+	requesterror "authserver/common/request_error"
+	"net/http"
+)
 
 // BasicResponse represents a response with a simple true/false success field
 type BasicResponse struct {
@@ -13,25 +16,55 @@ func NewSuccessResponse() (int, BasicResponse) {
 	}
 }
 
-// ErrorResponse represents a response with a true/false success field and an error message
+// ErrorResponse represents a response with a true/false success field, a machine readable error code, an error message,
+// and the details of any invalid fields
 type ErrorResponse struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error"`
+	Success bool                      `json:"success"`
+	Code    string                    `json:"code"`
+	Error   string                    `json:"error"`
+	Details []requesterror.FieldError `json:"details,omitempty"`
+	Scope   string                    `json:"scope,omitempty"`
 }
 
-func NewErrorResponse(err string) ErrorResponse {
-	return ErrorResponse{
+func NewInternalServerErrorResponse() (int, ErrorResponse) {
+	return http.StatusInternalServerError, ErrorResponse{
 		Success: false,
-		Error:   err,
+		Code:    requesterror.CodeInternal,
+		Error:   "an internal error occurred",
 	}
 }
 
-func NewBadRequestResponse(err string) (int, ErrorResponse) {
-	return http.StatusBadRequest, NewErrorResponse(err)
+// ProblemDetailsContentType is the content type of problem details responses
+const ProblemDetailsContentType = "application/problem+json"
+
+// ProblemDetailsTypePrefix is the prefix of the problem type uris. The type of a problem is the prefix followed by its error code.
+const ProblemDetailsTypePrefix = "urn:authserver:problem:"
+
+// ProblemDetails represents an error response defined by RFC 7807, with the error code, the details of any invalid fields,
+// and the request id as extension members
+type ProblemDetails struct {
+	Type      string                    `json:"type"`
+	Title     string                    `json:"title"`
+	Status    int                       `json:"status"`
+	Detail    string                    `json:"detail,omitempty"`
+	Instance  string                    `json:"instance,omitempty"`
+	Code      string                    `json:"code"`
+	Errors    []requesterror.FieldError `json:"errors,omitempty"`
+	RequestID string                    `json:"request_id,omitempty"`
 }
 
-func NewInternalServerErrorResponse() (int, ErrorResponse) {
-	return http.StatusInternalServerError, NewErrorResponse("an internal error occurred")
+// NewProblemDetails creates the problem details equivalent to the error response
+func NewProblemDetails(status int, res ErrorResponse, instance string, requestID string) ProblemDetails {
+	return ProblemDetails{
+		Type:      ProblemDetailsTypePrefix + res.Code,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    res.Error,
+		Instance:  instance,
+		Code:      res.Code,
+		Errors:    res.Details,
+		RequestID: requestID,
+	}
 }
 
 // DataResponse represents a response with a true/false success field and generic data
@@ -53,13 +86,6 @@ type OAuthErrorResponse struct {
 	ErrorDescription string `json:"error_description"`
 }
 
-func NewOAuthErrorResponse(name string, description string) (int, OAuthErrorResponse) {
-	return http.StatusBadRequest, OAuthErrorResponse{
-		Error:            name,
-		ErrorDescription: description,
-	}
-}
-
 // AccessTokenResponse represents an access token response defined by the oauth spec
 type AccessTokenResponse struct {
 	AccessToken string `json:"access_token"`
@@ -2,6 +2,7 @@ package config
 
 import (
 	"authserver/common"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"path"
@@ -16,17 +17,145 @@ import (
 type Config struct {
 	RootDir                string                 `yaml:"root_dir"`
 	AppID                  string                 `yaml:"app_id"`
+	ServerConfig           ServerConfig           `yaml:"server"`
+	LoggingConfig          LoggingConfig          `yaml:"logging"`
 	DatabaseConfig         DatabaseConfig         `yaml:"database"`
 	PasswordCriteriaConfig PasswordCriteriaConfig `yaml:"password_criteria"`
+	WebhookConfig          WebhookConfig          `yaml:"webhooks"`
+	TokenCacheConfig       TokenCacheConfig       `yaml:"token_cache"`
+	RateLimitConfig        RateLimitConfig        `yaml:"rate_limit"`
+	CORSConfig             CORSConfig             `yaml:"cors"`
 }
 
+// ServerConfig is a struct with fields needed for configuring the server.
+type ServerConfig struct {
+	// Addr is the tcp address the server should listen on.
+	Addr string `yaml:"addr"`
+
+	// ReadTimeout is the max time in milliseconds the server should spend reading a request, including its body.
+	ReadTimeout int `yaml:"read_timeout"`
+
+	// WriteTimeout is the max time in milliseconds the server should spend writing a response.
+	WriteTimeout int `yaml:"write_timeout"`
+
+	// IdleTimeout is the max time in milliseconds the server should wait for the next request on a keep-alive connection.
+	IdleTimeout int `yaml:"idle_timeout"`
+
+	// MaxHeaderBytes is the max number of bytes the server will read parsing a request's headers.
+	MaxHeaderBytes int `yaml:"max_header_bytes"`
+
+	// ShutdownDelay is the time in milliseconds the server keeps serving requests after reporting as not ready when shutting down,
+	// so load balancers have time to stop routing new requests to it.
+	ShutdownDelay int `yaml:"shutdown_delay"`
+
+	// ShutdownTimeout is the max time in milliseconds the server should wait for in-flight requests to finish when shutting down.
+	ShutdownTimeout int `yaml:"shutdown_timeout"`
+
+	// MaxBodyBytes is the max number of bytes the server will read from a request's body. Bodies are unlimited if zero.
+	MaxBodyBytes int64 `yaml:"max_body_bytes"`
+
+	// RouteMaxBodyBytes overrides MaxBodyBytes for the routes it is keyed by, such as "POST /user".
+	RouteMaxBodyBytes map[string]int64 `yaml:"route_max_body_bytes"`
+
+	// AllowAccessTokenFormParameter determines if clients may send their access token in the access_token form parameter of the request body.
+	AllowAccessTokenFormParameter bool `yaml:"allow_access_token_form_parameter"`
+
+	// TLS is the config for serving over tls. Tls is disabled if no cert file is provided.
+	TLS TLSConfig `yaml:"tls"`
+}
+
+// TLSConfig is a struct with fields needed for configuring tls.
+type TLSConfig struct {
+	// CertFile is the path to the server's certificate file. Changes to the file are reloaded automatically.
+	CertFile string `yaml:"cert_file"`
+
+	// KeyFile is the path to the server's private key file. Changes to the file are reloaded automatically.
+	KeyFile string `yaml:"key_file"`
+
+	// ClientCAFile is the path to the ca certificates used to verify client certificates.
+	// If provided, mutual tls is enabled and clients may authenticate using their certificates.
+	ClientCAFile string `yaml:"client_ca_file"`
+}
+
+// LoggingConfig is a struct with fields needed for configuring logging.
+type LoggingConfig struct {
+	// Level is the minimum level of entries that should be logged. One of debug, info, warn or error.
+	Level string `yaml:"level"`
+
+	// Format is the format entries should be written in. One of json or text.
+	Format string `yaml:"format"`
+}
+
+// Database adapters.
+const (
+	DatabaseAdapterSQL      = "sql"
+	DatabaseAdapterInMemory = "inmemory"
+)
+
+// Database drivers used by the sql adapter.
+const (
+	DatabaseDriverPostgres = "postgres"
+	DatabaseDriverSQLite   = "sqlite"
+	DatabaseDriverMySQL    = "mysql"
+)
+
+// Transaction isolation levels used by the sql adapter.
+const (
+	IsolationLevelReadCommitted  = "read_committed"
+	IsolationLevelRepeatableRead = "repeatable_read"
+	IsolationLevelSerializable   = "serializable"
+)
+
 // DatabaseConfig is a struct with fields needed for configuring database operations.
 type DatabaseConfig struct {
+	// Adapter is the database implementation to use. One of sql or inmemory, defaulting to sql if empty.
+	// The inmemory adapter needs no external services but loses its data when the application stops.
+	Adapter string `yaml:"adapter"`
+
+	// Driver is the sql driver the sql adapter uses. One of postgres, sqlite or mysql, defaulting to postgres if empty.
+	// Sqlite connection strings are file names, such as "file:authserver.db?_busy_timeout=5000".
+	// Mysql connection strings are data source names, such as "user:password@tcp(localhost:3306)/authserver".
+	Driver string `yaml:"driver"`
+
 	// ConnectionStrings is a string map that maps db keys to the connection string of the database.
 	ConnectionStrings map[string]string `yaml:"connection_strings"`
 
+	// ReplicaConnectionStrings maps db keys to the connection strings of the database's read replicas. Only used by the sql adapter.
+	// Read only queries made outside of a transaction, such as authenticating access tokens, are spread across the replicas,
+	// and are retried on the primary if a replica fails.
+	ReplicaConnectionStrings map[string][]string `yaml:"replica_connection_strings"`
+
+	// PinToPrimaryAfterWrite routes a request's reads to the primary once the request has written,
+	// so it always reads its own writes even if the replicas are behind.
+	PinToPrimaryAfterWrite bool `yaml:"pin_to_primary_after_write"`
+
 	// Timeout is the default timeout all database requests should use.
+	// It is applied on top of the request's context, so requests are also canceled when their client disconnects.
 	Timeout int `yaml:"timeout"`
+
+	// MaxOpenConns is the maximum number of open connections to the database. Zero means unlimited.
+	MaxOpenConns int `yaml:"max_open_conns"`
+
+	// MaxIdleConns is the maximum number of idle connections kept in the pool. Zero uses the database/sql default of 2.
+	MaxIdleConns int `yaml:"max_idle_conns"`
+
+	// ConnMaxLifetime is the maximum time in milliseconds a connection may be reused for. Zero means connections are reused forever.
+	ConnMaxLifetime int `yaml:"conn_max_lifetime"`
+
+	// IsolationLevel is the isolation level of the sql adapter's transactions. One of read_committed, repeatable_read or serializable,
+	// defaulting to the database's own default if empty. Sqlite transactions are always serializable.
+	IsolationLevel string `yaml:"isolation_level"`
+
+	// TransactionRetries is the max number of times a request is retried when its transaction fails because of contention
+	// with other transactions, such as a serialization failure or deadlock. Zero disables retries.
+	TransactionRetries int `yaml:"transaction_retries"`
+
+	// TransactionRetryBaseDelay is the time in milliseconds to wait before the first retry. The delay doubles with each retry,
+	// and a random delay up to it is used so competing requests don't retry in lockstep.
+	TransactionRetryBaseDelay int `yaml:"transaction_retry_base_delay"`
+
+	// TransactionRetryMaxDelay is the max time in milliseconds to wait before a retry.
+	TransactionRetryMaxDelay int `yaml:"transaction_retry_max_delay"`
 }
 
 // PasswordCriteriaConfig is a struct for encapsulating criteria requirements for a password
@@ -47,6 +176,137 @@ type PasswordCriteriaConfig struct {
 	RequireSymbol bool `yaml:"require_symbol"`
 }
 
+// WebhookConfig is a struct with fields needed for configuring webhook delivery.
+type WebhookConfig struct {
+	// Endpoints are the webhooks that outbox events are delivered to.
+	Endpoints []WebhookEndpointConfig `yaml:"endpoints"`
+
+	// PollInterval is the time in milliseconds the dispatcher waits between checks for pending events.
+	PollInterval int `yaml:"poll_interval"`
+
+	// BatchSize is the max number of events the dispatcher delivers per poll.
+	BatchSize int `yaml:"batch_size"`
+
+	// Timeout is the max time in milliseconds to wait for a webhook to respond.
+	Timeout int `yaml:"timeout"`
+
+	// MaxAttempts is the number of delivery attempts made before an event is dead lettered.
+	MaxAttempts int `yaml:"max_attempts"`
+
+	// RetryBaseDelay is the time in milliseconds to wait before retrying a failed delivery. The delay doubles with each attempt.
+	RetryBaseDelay int `yaml:"retry_base_delay"`
+
+	// RetryMaxDelay is the max time in milliseconds to wait before retrying a failed delivery.
+	RetryMaxDelay int `yaml:"retry_max_delay"`
+}
+
+// WebhookEndpointConfig is a struct with fields needed for configuring a webhook endpoint.
+type WebhookEndpointConfig struct {
+	// URL is the url events are posted to.
+	URL string `yaml:"url"`
+
+	// Secret is the key used to sign the events posted to the url.
+	Secret string `yaml:"secret"`
+
+	// Events are the types of the events delivered to the endpoint. All events are delivered if empty.
+	Events []string `yaml:"events"`
+}
+
+// Token cache backplanes.
+const (
+	TokenCacheBackplaneLocal    = "local"
+	TokenCacheBackplanePostgres = "postgres"
+)
+
+// TokenCacheConfig is a struct with fields needed for configuring the access token cache.
+type TokenCacheConfig struct {
+	// Size is the max number of access tokens kept in the cache. Zero disables the cache.
+	Size int `yaml:"size"`
+
+	// TTL is the max time in milliseconds a token is cached for before it is fetched from the database again.
+	TTL int `yaml:"ttl"`
+
+	// Backplane is how revoked tokens are invalidated across application replicas. One of local or postgres, defaulting to local if empty.
+	// The local backplane only invalidates the tokens cached by this replica, so revoked tokens may be used with other replicas until they expire.
+	// The postgres backplane uses LISTEN/NOTIFY on the database, so it requires the postgres driver.
+	Backplane string `yaml:"backplane"`
+}
+
+// Rate limit stores.
+const (
+	RateLimitStoreMemory   = "memory"
+	RateLimitStoreDatabase = "database"
+)
+
+// RateLimitConfig is a struct with fields needed for configuring the rate limits of the authentication routes.
+type RateLimitConfig struct {
+	// Store is where the rate limit buckets are kept. One of memory or database, defaulting to memory if empty.
+	// The memory store only limits the requests made to this replica, while the database store shares the limits between every replica.
+	Store string `yaml:"store"`
+
+	// Token are the rate limits of the token route.
+	Token RateLimitRulesConfig `yaml:"token"`
+
+	// CreateUser are the rate limits of the create user route.
+	CreateUser RateLimitRulesConfig `yaml:"create_user"`
+}
+
+// RateLimitRulesConfig is a struct with fields needed for configuring the rate limits of a route.
+// Each limit applies to the requests identified by a key, such as those from the same ip address.
+type RateLimitRulesConfig struct {
+	// IP is the limit of the requests from each ip address.
+	IP RateLimitRuleConfig `yaml:"ip"`
+
+	// ClientID is the limit of the requests made by each client.
+	ClientID RateLimitRuleConfig `yaml:"client_id"`
+
+	// Username is the limit of the requests for each username.
+	Username RateLimitRuleConfig `yaml:"username"`
+}
+
+// RateLimitRuleConfig is a struct with fields needed for configuring a rate limit.
+type RateLimitRuleConfig struct {
+	// Requests is the number of requests allowed per period, which may all be made at once. Zero disables the limit.
+	Requests int `yaml:"requests"`
+
+	// Period is the time in milliseconds the requests are allowed over.
+	Period int `yaml:"period"`
+}
+
+// CORSConfig is a struct with fields needed for configuring which cross origin requests browsers are allowed to make.
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed to make requests on behalf of any client, such as "https://example.com". "*" allows every origin.
+	AllowedOrigins []string `yaml:"allowed_origins"`
+
+	// Clients are the origins registered to each client. Requests that identify their client must come from one of its origins or an allowed origin.
+	Clients []CORSClientConfig `yaml:"clients"`
+
+	// AllowedMethods are the methods allowed in cross origin requests. Every method of the route is allowed if empty.
+	AllowedMethods []string `yaml:"allowed_methods"`
+
+	// AllowedHeaders are the request headers allowed in cross origin requests, such as Authorization.
+	AllowedHeaders []string `yaml:"allowed_headers"`
+
+	// ExposedHeaders are the response headers browsers expose to cross origin requests, such as Retry-After.
+	ExposedHeaders []string `yaml:"exposed_headers"`
+
+	// AllowCredentials determines if browsers may include credentials, such as cookies, in cross origin requests.
+	// Cannot be true if AllowedOrigins contains "*".
+	AllowCredentials bool `yaml:"allow_credentials"`
+
+	// MaxAge is the time in milliseconds browsers may cache preflight responses. Browsers use their default if zero.
+	MaxAge int `yaml:"max_age"`
+}
+
+// CORSClientConfig is a struct with fields needed for configuring the origins registered to a client.
+type CORSClientConfig struct {
+	// ClientID is the id of the client.
+	ClientID string `yaml:"client_id"`
+
+	// Origins are the origins the client's requests may come from.
+	Origins []string `yaml:"origins"`
+}
+
 //InitConfig sets the default config values and binds environment variables. Should be called at the start of the application.
 func InitConfig(dir string) error {
 	//set defaults
@@ -70,11 +330,44 @@ func InitConfig(dir string) error {
 		return common.ChainError("error parsing config file", err)
 	}
 
+	//fail if settings conflict
+	err = cfg.validate()
+	if err != nil {
+		return common.ChainError("invalid config", err)
+	}
+
 	//set the config
 	viper.Set("root_dir", cfg.RootDir)
 	viper.Set("app_id", cfg.AppID)
+	viper.Set("server", cfg.ServerConfig)
+	viper.Set("logging", cfg.LoggingConfig)
 	viper.Set("password_criteria", cfg.PasswordCriteriaConfig)
 	viper.Set("database", cfg.DatabaseConfig)
+	viper.Set("webhooks", cfg.WebhookConfig)
+	viper.Set("token_cache", cfg.TokenCacheConfig)
+	viper.Set("rate_limit", cfg.RateLimitConfig)
+	viper.Set("cors", cfg.CORSConfig)
+
+	return nil
+}
+
+// validate checks the config's settings are compatible with each other. Returns an error describing the first conflict found.
+func (cfg Config) validate() error {
+	//the postgres token cache backplane uses LISTEN/NOTIFY on the database
+	db := cfg.DatabaseConfig
+	if cfg.TokenCacheConfig.Backplane == TokenCacheBackplanePostgres &&
+		(db.Adapter == DatabaseAdapterInMemory || (db.Driver != "" && db.Driver != DatabaseDriverPostgres)) {
+		return errors.New("the postgres token cache backplane requires the sql adapter with the postgres driver")
+	}
+
+	//the cors middleware reflects the request's origin, so a wildcard with credentials would let any site make requests as the user
+	if cfg.CORSConfig.AllowCredentials {
+		for _, origin := range cfg.CORSConfig.AllowedOrigins {
+			if origin == "*" {
+				return errors.New("the cors allowed origins cannot contain \"*\" when credentials are allowed")
+			}
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,112 @@
+package config_test
+
+import (
+	"authserver/common"
+	"authserver/config"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+)
+
+type ConfigTestSuite struct {
+	suite.Suite
+	Dir string
+}
+
+func (suite *ConfigTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "config")
+	suite.Require().NoError(err)
+
+	suite.Dir = dir
+	viper.Set("env", "test")
+}
+
+func (suite *ConfigTestSuite) TearDownTest() {
+	os.RemoveAll(suite.Dir)
+}
+
+func (suite *ConfigTestSuite) writeConfig(cfg config.Config) {
+	data, err := yaml.Marshal(cfg)
+	suite.Require().NoError(err)
+
+	err = ioutil.WriteFile(path.Join(suite.Dir, "config.test.yml"), data, 0644)
+	suite.Require().NoError(err)
+}
+
+func (suite *ConfigTestSuite) TestInitConfig_WithPostgresTokenCacheBackplaneAndOtherDatabase_ReturnsError() {
+	databases := map[string]config.DatabaseConfig{
+		"InMemory": {Adapter: config.DatabaseAdapterInMemory},
+		"SQLite":   {Adapter: config.DatabaseAdapterSQL, Driver: config.DatabaseDriverSQLite},
+		"MySQL":    {Adapter: config.DatabaseAdapterSQL, Driver: config.DatabaseDriverMySQL},
+	}
+
+	for name, db := range databases {
+		suite.Run(name, func() {
+			//arrange
+			suite.writeConfig(config.Config{
+				DatabaseConfig:   db,
+				TokenCacheConfig: config.TokenCacheConfig{Backplane: config.TokenCacheBackplanePostgres},
+			})
+
+			//act
+			err := config.InitConfig(suite.Dir)
+
+			//assert
+			common.AssertError(&suite.Suite, err, "postgres token cache backplane requires")
+		})
+	}
+}
+
+func (suite *ConfigTestSuite) TestInitConfig_WithPostgresTokenCacheBackplaneAndPostgresDatabase_ReturnsNoError() {
+	//arrange
+	suite.writeConfig(config.Config{
+		DatabaseConfig:   config.DatabaseConfig{Adapter: config.DatabaseAdapterSQL, Driver: config.DatabaseDriverPostgres},
+		TokenCacheConfig: config.TokenCacheConfig{Backplane: config.TokenCacheBackplanePostgres},
+	})
+
+	//act
+	err := config.InitConfig(suite.Dir)
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *ConfigTestSuite) TestInitConfig_WithWildcardAllowedOriginAndAllowCredentials_ReturnsError() {
+	//arrange
+	suite.writeConfig(config.Config{
+		CORSConfig: config.CORSConfig{
+			AllowedOrigins:   []string{"https://example.com", "*"},
+			AllowCredentials: true,
+		},
+	})
+
+	//act
+	err := config.InitConfig(suite.Dir)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "cors allowed origins cannot contain")
+}
+
+func (suite *ConfigTestSuite) TestInitConfig_WithWildcardAllowedOriginWithoutAllowCredentials_ReturnsNoError() {
+	//arrange
+	suite.writeConfig(config.Config{
+		CORSConfig: config.CORSConfig{
+			AllowedOrigins: []string{"*"},
+		},
+	})
+
+	//act
+	err := config.InitConfig(suite.Dir)
+
+	//assert
+	suite.NoError(err)
+}
+
+func TestConfigTestSuite(t *testing.T) {
+	suite.Run(t, &ConfigTestSuite{})
+}
@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"context"
+
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	passwordhelpers "authserver/controllers/password_helpers"
+	"authserver/logger"
+	"authserver/models"
+
+	"github.com/google/uuid"
+)
+
+// AdminUserControl handles requests to "/admin/users" endpoints
+type AdminUserControl struct {
+	PasswordHasher            passwordhelpers.PasswordHasher
+	PasswordCriteriaValidator passwordhelpers.PasswordCriteriaValidator
+}
+
+// GetUsers gets a page of the users that match the filter, along with the total number of matching users
+func (c AdminUserControl) GetUsers(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, filter models.UserFilter) ([]*models.User, int, error) {
+	//get the users
+	users, err := CRUD.GetUsers(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error getting users", err))
+		return nil, 0, requesterror.InternalError()
+	}
+
+	//count all the matching users so the admin can page through them
+	total, err := CRUD.CountUsers(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error counting users", err))
+		return nil, 0, requesterror.InternalError()
+	}
+
+	return users, total, nil
+}
+
+// GetUserByID gets the user with the given id
+func (c AdminUserControl) GetUserByID(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID) (*models.User, error) {
+	user, err := CRUD.GetUserByID(ctx, ID)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error getting user by id", err))
+		return nil, requesterror.InternalError()
+	}
+	if user == nil {
+		return nil, requesterror.NotFoundError("user not found")
+	}
+
+	return user, nil
+}
+
+// SetUserDisabled disables or enables the user with the given id. Disabled users cannot login, and their tokens are revoked
+func (c AdminUserControl) SetUserDisabled(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID, disabled bool) (*models.User, error) {
+	//admins cannot lock themselves out
+	if disabled && ID == admin.ID {
+		return nil, requesterror.ForbiddenError("admins cannot disable their own account")
+	}
+
+	//get the user
+	user, rerr := c.GetUserByID(ctx, CRUD, admin, ID)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	if user.IsDisabled == disabled {
+		return user, nil
+	}
+
+	//update the user
+	user.IsDisabled = disabled
+	err := CRUD.UpdateUser(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error updating user", err))
+		return nil, requesterror.InternalError()
+	}
+
+	action := models.AuditActionUserEnable
+	eventType := models.OutboxEventTypeUserEnabled
+	if disabled {
+		action = models.AuditActionUserDisable
+		eventType = models.OutboxEventTypeUserDisabled
+
+		//revoke the user's tokens
+		rerr = revokeAllUserTokens(ctx, CRUD, user)
+		if rerr != nil {
+			return nil, rerr
+		}
+	}
+
+	//record the change
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, action, models.AuditOutcomeSuccess, admin.ID, user.ID, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return nil, requesterror.InternalError()
+	}
+
+	//notify webhooks of the change
+	err = saveUserOutboxEvent(ctx, CRUD, eventType, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, requesterror.InternalError()
+	}
+
+	return user, nil
+}
+
+// ResetUserPassword sets a new password for the user with the given id, forcing them to login again
+func (c AdminUserControl) ResetUserPassword(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID, password string) error {
+	//validate password meets criteria
+	verr := c.PasswordCriteriaValidator.ValidatePasswordCriteria(password)
+	if verr.Status != passwordhelpers.ValidatePasswordCriteriaValid {
+		logger.FromContext(ctx).With("error", verr.Error()).Info("password does not meet criteria")
+		return requesterror.InvalidFieldError("password", "password does not meet minimum criteria")
+	}
+
+	//get the user
+	user, rerr := c.GetUserByID(ctx, CRUD, admin, ID)
+	if rerr != nil {
+		return rerr
+	}
+
+	//hash the password
+	hash, err := c.PasswordHasher.HashPassword(password)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error generating password hash", err))
+		return requesterror.InternalError()
+	}
+
+	//update the user
+	user.PasswordHash = hash
+	err = CRUD.UpdateUser(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error updating user", err))
+		return requesterror.InternalError()
+	}
+
+	//revoke the user's tokens
+	rerr = revokeAllUserTokens(ctx, CRUD, user)
+	if rerr != nil {
+		return rerr
+	}
+
+	//record the password reset
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionPasswordReset, models.AuditOutcomeSuccess, admin.ID, user.ID, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return requesterror.InternalError()
+	}
+
+	//notify webhooks of the password change
+	err = saveUserOutboxEvent(ctx, CRUD, models.OutboxEventTypeUserPasswordChanged, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return requesterror.InternalError()
+	}
+
+	return nil
+}
+
+// DeleteUserByID deletes the user with the given id
+func (c AdminUserControl) DeleteUserByID(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID) error {
+	//admins delete their own account through "/user"
+	if ID == admin.ID {
+		return requesterror.ForbiddenError("admins cannot delete their own account")
+	}
+
+	//get the user
+	user, rerr := c.GetUserByID(ctx, CRUD, admin, ID)
+	if rerr != nil {
+		return rerr
+	}
+
+	//delete the user
+	err := CRUD.DeleteUser(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error deleting user", err))
+		return requesterror.InternalError()
+	}
+
+	//record the user deletion
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionUserDelete, models.AuditOutcomeSuccess, admin.ID, user.ID, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return requesterror.InternalError()
+	}
+
+	//notify webhooks of the user deletion
+	err = saveUserOutboxEvent(ctx, CRUD, models.OutboxEventTypeUserDeleted, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return requesterror.InternalError()
+	}
+
+	return nil
+}
+
+// revokeAllUserTokens deletes all of the user's tokens
+func revokeAllUserTokens(ctx context.Context, CRUD models.AccessTokenCRUD, user *models.User) error {
+	err := CRUD.DeleteAllUserTokens(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error deleting all user tokens", err))
+		return requesterror.InternalError()
+	}
+
+	return nil
+}
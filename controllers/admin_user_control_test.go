@@ -0,0 +1,319 @@
+package controllers_test
+
+import (
+	requesterror "authserver/common/request_error"
+	"authserver/controllers"
+	passwordhelpers "authserver/controllers/password_helpers"
+	passwordhelpermocks "authserver/controllers/password_helpers/mocks"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminUserControlTestSuite struct {
+	suite.Suite
+	CRUDMock                      databasemocks.CRUDOperations
+	PasswordHasherMock            passwordhelpermocks.PasswordHasher
+	PasswordCriteriaValidatorMock passwordhelpermocks.PasswordCriteriaValidator
+	AdminUserControl              controllers.AdminUserControl
+	Admin                         *models.User
+}
+
+func (suite *AdminUserControlTestSuite) SetupTest() {
+	suite.CRUDMock = databasemocks.CRUDOperations{}
+	suite.PasswordHasherMock = passwordhelpermocks.PasswordHasher{}
+	suite.PasswordCriteriaValidatorMock = passwordhelpermocks.PasswordCriteriaValidator{}
+	suite.AdminUserControl = controllers.AdminUserControl{
+		PasswordHasher:            &suite.PasswordHasherMock,
+		PasswordCriteriaValidator: &suite.PasswordCriteriaValidatorMock,
+	}
+	suite.Admin = &models.User{ID: uuid.New(), Username: "admin", IsAdmin: true}
+}
+
+func (suite *AdminUserControlTestSuite) TestGetUsers_WithErrorGettingUsers_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUsers", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	users, total, rerr := suite.AdminUserControl.GetUsers(context.Background(), &suite.CRUDMock, suite.Admin, models.UserFilter{})
+
+	//assert
+	suite.Nil(users)
+	suite.Zero(total)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestGetUsers_WithErrorCountingUsers_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUsers", mock.Anything, mock.Anything).Return([]*models.User{}, nil)
+	suite.CRUDMock.On("CountUsers", mock.Anything, mock.Anything).Return(0, errors.New(""))
+
+	//act
+	users, total, rerr := suite.AdminUserControl.GetUsers(context.Background(), &suite.CRUDMock, suite.Admin, models.UserFilter{})
+
+	//assert
+	suite.Nil(users)
+	suite.Zero(total)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestGetUsers_WithValidRequest_ReturnsUsersAndTotal() {
+	//arrange
+	filter := models.UserFilter{Username: "user", Limit: 1}
+	expectedUsers := []*models.User{models.CreateNewUser("username", nil)}
+
+	suite.CRUDMock.On("GetUsers", mock.Anything, mock.Anything).Return(expectedUsers, nil)
+	suite.CRUDMock.On("CountUsers", mock.Anything, mock.Anything).Return(3, nil)
+
+	//act
+	users, total, rerr := suite.AdminUserControl.GetUsers(context.Background(), &suite.CRUDMock, suite.Admin, filter)
+
+	//assert
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUsers", mock.Anything, filter)
+	suite.CRUDMock.AssertCalled(suite.T(), "CountUsers", mock.Anything, filter)
+
+	suite.Equal(expectedUsers, users)
+	suite.Equal(3, total)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestGetUserByID_WithErrorGettingUserByID_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, rerr := suite.AdminUserControl.GetUserByID(context.Background(), &suite.CRUDMock, suite.Admin, uuid.New())
+
+	//assert
+	suite.Nil(user)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestGetUserByID_WhereUserNotFound_ReturnsNotFoundError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	user, rerr := suite.AdminUserControl.GetUserByID(context.Background(), &suite.CRUDMock, suite.Admin, uuid.New())
+
+	//assert
+	suite.Nil(user)
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeNotFound, "user not found")
+}
+
+func (suite *AdminUserControlTestSuite) TestSetUserDisabled_ForOwnAccount_ReturnsForbiddenError() {
+	//act
+	user, rerr := suite.AdminUserControl.SetUserDisabled(context.Background(), &suite.CRUDMock, suite.Admin, suite.Admin.ID, true)
+
+	//assert
+	suite.Nil(user)
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeForbidden, "cannot disable their own account")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminUserControlTestSuite) TestSetUserDisabled_WithNoChange_DoesNotUpdateUser() {
+	//arrange
+	existing := models.CreateNewUser("username", nil)
+	existing.IsDisabled = true
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+
+	//act
+	user, rerr := suite.AdminUserControl.SetUserDisabled(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID, true)
+
+	//assert
+	suite.Equal(existing, user)
+	AssertNoError(&suite.Suite, rerr)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminUserControlTestSuite) TestSetUserDisabled_WithErrorDeletingTokens_ReturnsInternalError() {
+	//arrange
+	existing := models.CreateNewUser("username", nil)
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteAllUserTokens", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	user, rerr := suite.AdminUserControl.SetUserDisabled(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID, true)
+
+	//assert
+	suite.Nil(user)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestSetUserDisabled_WithDisabledTrue_DisablesUserAndRevokesTokens() {
+	//arrange
+	existing := models.CreateNewUser("username", nil)
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteAllUserTokens", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	user, rerr := suite.AdminUserControl.SetUserDisabled(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID, true)
+
+	//assert
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByID", mock.Anything, existing.ID)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, existing)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllUserTokens", mock.Anything, existing)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionUserDisable && event.ActorID == suite.Admin.ID && event.TargetID == existing.ID
+	}))
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserDisabled, existing)
+
+	suite.True(user.IsDisabled)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestSetUserDisabled_WithDisabledFalse_EnablesUser() {
+	//arrange
+	existing := models.CreateNewUser("username", nil)
+	existing.IsDisabled = true
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	user, rerr := suite.AdminUserControl.SetUserDisabled(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID, false)
+
+	//assert
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, existing)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteAllUserTokens", mock.Anything, mock.Anything)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionUserEnable, models.AuditOutcomeSuccess)
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserEnabled, existing)
+
+	suite.False(user.IsDisabled)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestResetUserPassword_WherePasswordDoesNotMeetCriteria_ReturnsInvalidRequestError() {
+	//arrange
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaTooShort, ""))
+
+	//act
+	rerr := suite.AdminUserControl.ResetUserPassword(context.Background(), &suite.CRUDMock, suite.Admin, uuid.New(), "password")
+
+	//assert
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeInvalidRequest, "password", "not", "minimum criteria")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminUserControlTestSuite) TestResetUserPassword_WhereUserNotFound_ReturnsNotFoundError() {
+	//arrange
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	rerr := suite.AdminUserControl.ResetUserPassword(context.Background(), &suite.CRUDMock, suite.Admin, uuid.New(), "password")
+
+	//assert
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeNotFound, "user not found")
+}
+
+func (suite *AdminUserControlTestSuite) TestResetUserPassword_WithErrorUpdatingUser_ReturnsInternalError() {
+	//arrange
+	existing := models.CreateNewUser("username", nil)
+
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return([]byte("hash"), nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	rerr := suite.AdminUserControl.ResetUserPassword(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID, "password")
+
+	//assert
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestResetUserPassword_WithValidRequest_UpdatesPasswordAndRevokesTokens() {
+	//arrange
+	password := "new password"
+	passwordHash := []byte("hashed new password")
+	existing := models.CreateNewUser("username", []byte("hashed old password"))
+
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(passwordHash, nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteAllUserTokens", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	rerr := suite.AdminUserControl.ResetUserPassword(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID, password)
+
+	//assert
+	suite.PasswordCriteriaValidatorMock.AssertCalled(suite.T(), "ValidatePasswordCriteria", password)
+	suite.PasswordHasherMock.AssertCalled(suite.T(), "HashPassword", password)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, existing)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllUserTokens", mock.Anything, existing)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionPasswordReset && event.ActorID == suite.Admin.ID && event.TargetID == existing.ID
+	}))
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserPasswordChanged, existing)
+
+	suite.Equal(passwordHash, existing.PasswordHash)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestDeleteUserByID_ForOwnAccount_ReturnsForbiddenError() {
+	//act
+	rerr := suite.AdminUserControl.DeleteUserByID(context.Background(), &suite.CRUDMock, suite.Admin, suite.Admin.ID)
+
+	//assert
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeForbidden, "cannot delete their own account")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "DeleteUser", mock.Anything, mock.Anything)
+}
+
+func (suite *AdminUserControlTestSuite) TestDeleteUserByID_WithErrorDeletingUser_ReturnsInternalError() {
+	//arrange
+	existing := models.CreateNewUser("username", nil)
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+	suite.CRUDMock.On("DeleteUser", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	rerr := suite.AdminUserControl.DeleteUserByID(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID)
+
+	//assert
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AdminUserControlTestSuite) TestDeleteUserByID_WithValidRequest_DeletesUser() {
+	//arrange
+	existing := models.CreateNewUser("username", nil)
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(existing, nil)
+	suite.CRUDMock.On("DeleteUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	rerr := suite.AdminUserControl.DeleteUserByID(context.Background(), &suite.CRUDMock, suite.Admin, existing.ID)
+
+	//assert
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteUser", mock.Anything, existing)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionUserDelete && event.ActorID == suite.Admin.ID && event.TargetID == existing.ID
+	}))
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserDeleted, existing)
+
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func TestAdminUserControlTestSuite(t *testing.T) {
+	suite.Run(t, &AdminUserControlTestSuite{})
+}
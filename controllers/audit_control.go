@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/logger"
+	"authserver/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AuditControl handles requests to "/admin/audit-events" endpoints
+type AuditControl struct{}
+
+// GetAuditEvents gets the audit events that match the filter. The query itself is recorded as an admin action.
+func (c AuditControl) GetAuditEvents(ctx context.Context, CRUD AuditControllerCRUD, admin *models.User, filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	//get the events
+	events, err := CRUD.GetAuditEvents(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error getting audit events", err))
+		return nil, requesterror.InternalError()
+	}
+
+	//record the query
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionAuditLogQuery, models.AuditOutcomeSuccess, admin.ID, uuid.Nil, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return nil, requesterror.InternalError()
+	}
+
+	return events, nil
+}
@@ -0,0 +1,80 @@
+package controllers_test
+
+import (
+	"authserver/controllers"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditControlTestSuite struct {
+	suite.Suite
+	CRUDMock     databasemocks.CRUDOperations
+	AuditControl controllers.AuditControl
+}
+
+func (suite *AuditControlTestSuite) SetupTest() {
+	suite.CRUDMock = databasemocks.CRUDOperations{}
+	suite.AuditControl = controllers.AuditControl{}
+}
+
+func (suite *AuditControlTestSuite) TestGetAuditEvents_WithErrorGettingAuditEvents_ReturnsInternalError() {
+	//arrange
+	admin := &models.User{ID: uuid.New(), IsAdmin: true}
+
+	suite.CRUDMock.On("GetAuditEvents", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	events, rerr := suite.AuditControl.GetAuditEvents(context.Background(), &suite.CRUDMock, admin, models.AuditEventFilter{})
+
+	//assert
+	suite.Nil(events)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AuditControlTestSuite) TestGetAuditEvents_WithErrorSavingAuditEvent_ReturnsInternalError() {
+	//arrange
+	admin := &models.User{ID: uuid.New(), IsAdmin: true}
+
+	suite.CRUDMock.On("GetAuditEvents", mock.Anything, mock.Anything).Return([]*models.AuditEvent{}, nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	events, rerr := suite.AuditControl.GetAuditEvents(context.Background(), &suite.CRUDMock, admin, models.AuditEventFilter{})
+
+	//assert
+	suite.Nil(events)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *AuditControlTestSuite) TestGetAuditEvents_WithValidRequest_ReturnsEventsAndRecordsQuery() {
+	//arrange
+	admin := &models.User{ID: uuid.New(), IsAdmin: true}
+	filter := models.AuditEventFilter{Action: models.AuditActionLogin, Limit: 10}
+	expectedEvents := []*models.AuditEvent{models.CreateNewAuditEvent(models.AuditActionLogin, models.AuditOutcomeSuccess)}
+
+	suite.CRUDMock.On("GetAuditEvents", mock.Anything, mock.Anything).Return(expectedEvents, nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	events, rerr := suite.AuditControl.GetAuditEvents(context.Background(), &suite.CRUDMock, admin, filter)
+
+	//assert
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAuditEvents", mock.Anything, filter)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == models.AuditActionAuditLogQuery && event.ActorID == admin.ID
+	}))
+
+	suite.Equal(expectedEvents, events)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func TestAuditControlTestSuite(t *testing.T) {
+	suite.Run(t, &AuditControlTestSuite{})
+}
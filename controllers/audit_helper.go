@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"authserver/audit"
+	"authserver/models"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// newAuditEvent creates a new audit event for the request in the context with the provided fields.
+func newAuditEvent(ctx context.Context, action string, outcome string, actorID uuid.UUID, targetID uuid.UUID, clientID uuid.UUID) *models.AuditEvent {
+	event := audit.FromContext(ctx).NewEvent(action, outcome)
+	event.ActorID = actorID
+	event.TargetID = targetID
+	event.ClientID = clientID
+
+	return event
+}
+
+// recordAuditFailure records the event of a failed action.
+// It is not saved with the action's transaction since the transaction will be rolled back.
+func recordAuditFailure(ctx context.Context, event *models.AuditEvent, details string) {
+	event.Details = details
+	audit.FromContext(ctx).AddFailure(event)
+}
@@ -1,8 +1,9 @@
 package controllers
 
 import (
-	requesterror "authserver/common/request_error"
 	"authserver/models"
+	"context"
+	"crypto/x509"
 
 	"github.com/google/uuid"
 )
@@ -10,25 +11,62 @@ import (
 // Controllers encapsulates all other controller interfaces.
 type Controllers interface {
 	UserController
+	AdminUserController
 	TokenController
+	AuditController
+	OutboxController
 }
 
 // UserControllerCRUD encapsulates the CRUD operations required by the UserController.
 type UserControllerCRUD interface {
 	models.UserCRUD
 	models.AccessTokenCRUD
+	models.AuditEventCRUD
+	models.OutboxEventCRUD
 }
 
 // UserController provides workflows for user related operations.
 type UserController interface {
 	// CreateUser creates a new user with the given username and password.
-	CreateUser(CRUD UserControllerCRUD, username string, password string) (*models.User, requesterror.RequestError)
+	CreateUser(ctx context.Context, CRUD UserControllerCRUD, username string, password string) (*models.User, error)
+
+	// GetUser gets the current state of the given user's profile.
+	GetUser(ctx context.Context, CRUD UserControllerCRUD, user *models.User) (*models.User, error)
+
+	// UpdateUser updates the given user's profile, validating the changes the same way as CreateUser.
+	UpdateUser(ctx context.Context, CRUD UserControllerCRUD, user *models.User, update models.UserUpdate) (*models.User, error)
 
 	// DeleteUser deletes the given user.
-	DeleteUser(CRUD UserControllerCRUD, user *models.User) requesterror.RequestError
+	DeleteUser(ctx context.Context, CRUD UserControllerCRUD, user *models.User) error
 
 	// UpdateUserPassword updates the given user's password.
-	UpdateUserPassword(CRUD UserControllerCRUD, user *models.User, oldPassword string, newPassword string) requesterror.RequestError
+	UpdateUserPassword(ctx context.Context, CRUD UserControllerCRUD, user *models.User, oldPassword string, newPassword string) error
+}
+
+// AdminUserControllerCRUD encapsulates the CRUD operations required by the AdminUserController.
+type AdminUserControllerCRUD interface {
+	models.UserCRUD
+	models.AccessTokenCRUD
+	models.AuditEventCRUD
+	models.OutboxEventCRUD
+}
+
+// AdminUserController provides workflows for admins to manage other users.
+type AdminUserController interface {
+	// GetUsers gets the users that match the filter, and the total number of matching users, on behalf of the given admin.
+	GetUsers(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, filter models.UserFilter) ([]*models.User, int, error)
+
+	// GetUserByID gets the user with the given id on behalf of the given admin.
+	GetUserByID(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID) (*models.User, error)
+
+	// SetUserDisabled disables or enables the user with the given id. Disabling a user also revokes all of their tokens.
+	SetUserDisabled(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID, disabled bool) (*models.User, error)
+
+	// ResetUserPassword sets a new password for the user with the given id and revokes all of their tokens.
+	ResetUserPassword(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID, password string) error
+
+	// DeleteUserByID deletes the user with the given id on behalf of the given admin.
+	DeleteUserByID(ctx context.Context, CRUD AdminUserControllerCRUD, admin *models.User, ID uuid.UUID) error
 }
 
 // TokenControllerCRUD encapsulates the CRUD operations required by the TokenController.
@@ -37,22 +75,49 @@ type TokenControllerCRUD interface {
 	models.ClientCRUD
 	models.ScopeCRUD
 	models.AccessTokenCRUD
+	models.AuditEventCRUD
 }
 
 // TokenController provides workflows for access token related operations.
 type TokenController interface {
 	// CreateTokenFromPassword creates a new access token, authenticating using a password.
-	CreateTokenFromPassword(CRUD TokenControllerCRUD, username string, password string, clientID uuid.UUID, scopeName string) (*models.AccessToken, requesterror.OAuthRequestError)
+	// The client certificate is used to authenticate clients that use tls_client_auth, and may be nil otherwise.
+	CreateTokenFromPassword(ctx context.Context, CRUD TokenControllerCRUD, username string, password string, clientID uuid.UUID, clientCert *x509.Certificate, scopeName string) (*models.AccessToken, error)
 
 	// DeleteToken deletes the access token.
-	DeleteToken(CRUD TokenControllerCRUD, token *models.AccessToken) requesterror.RequestError
+	DeleteToken(ctx context.Context, CRUD TokenControllerCRUD, token *models.AccessToken) error
 
 	// DeleteToken deletes all of the user's tokens accept for the provided one.
-	DeleteAllOtherUserTokens(CRUD TokenControllerCRUD, token *models.AccessToken) requesterror.RequestError
+	DeleteAllOtherUserTokens(ctx context.Context, CRUD TokenControllerCRUD, token *models.AccessToken) error
+}
+
+// AuditControllerCRUD encapsulates the CRUD operations required by the AuditController.
+type AuditControllerCRUD interface {
+	models.AuditEventCRUD
+}
+
+// AuditController provides workflows for audit log related operations.
+type AuditController interface {
+	// GetAuditEvents gets the audit events that match the filter, on behalf of the given admin.
+	GetAuditEvents(ctx context.Context, CRUD AuditControllerCRUD, admin *models.User, filter models.AuditEventFilter) ([]*models.AuditEvent, error)
+}
+
+// OutboxControllerCRUD encapsulates the CRUD operations required by the OutboxController.
+type OutboxControllerCRUD interface {
+	models.OutboxEventCRUD
+}
+
+// OutboxController provides workflows for webhook outbox related operations.
+type OutboxController interface {
+	// GetDeadLetteredOutboxEvents gets a page of the outbox events that could not be delivered to their webhooks.
+	GetDeadLetteredOutboxEvents(ctx context.Context, CRUD OutboxControllerCRUD, filter models.OutboxEventFilter) ([]*models.OutboxEvent, error)
 }
 
 // Controls encapsulates all other control structs.
 type Controls struct {
 	UserControl
+	AdminUserControl
 	TokenControl
+	AuditControl
+	OutboxControl
 }
@@ -3,36 +3,68 @@ package controllers_test
 import (
 	"authserver/common"
 	requesterror "authserver/common/request_error"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"encoding/json"
+	"errors"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
-func AssertNoError(suite *suite.Suite, err requesterror.RequestError) {
-	suite.Require().NotNil(err)
-	suite.Equal(requesterror.ErrorTypeNone, err.Type)
+func AssertNoError(suite *suite.Suite, err error) {
+	suite.NoError(err)
 }
 
-func AssertClientError(suite *suite.Suite, err requesterror.RequestError, expectedSubStrs ...string) {
-	suite.Require().NotNil(err)
-	suite.Equal(requesterror.ErrorTypeClient, err.Type)
-	common.AssertContainsSubstrings(suite, err.Error(), expectedSubStrs...)
+func AssertRequestError(suite *suite.Suite, err error, expectedCode string, expectedSubStrs ...string) *requesterror.RequestError {
+	var rerr *requesterror.RequestError
+	suite.Require().True(errors.As(err, &rerr), "error should be a request error")
+	suite.Equal(expectedCode, rerr.Code)
+	common.AssertContainsSubstrings(suite, rerr.Message, expectedSubStrs...)
+
+	return rerr
 }
 
-func AssertInternalError(suite *suite.Suite, err requesterror.RequestError) {
-	suite.Require().NotNil(err)
-	suite.Equal(requesterror.ErrorTypeInternal, err.Type)
-	common.AssertContainsSubstrings(suite, err.Error(), "internal error")
+func AssertInternalError(suite *suite.Suite, err error) {
+	AssertRequestError(suite, err, requesterror.CodeInternal, "internal error")
 }
 
-func AssertOAuthNoError(suite *suite.Suite, err requesterror.OAuthRequestError) {
-	AssertNoError(suite, err.RequestError)
+func AssertOAuthClientError(suite *suite.Suite, err error, expectedErrorName string, expectedMessageSubStrs ...string) {
+	expectedCode := requesterror.CodeInvalidRequest
+	if expectedErrorName == requesterror.OAuthErrorInvalidClient {
+		expectedCode = requesterror.CodeUnauthorized
+	}
+
+	rerr := AssertRequestError(suite, err, expectedCode, expectedMessageSubStrs...)
+	suite.Equal(expectedErrorName, rerr.OAuthError)
 }
 
-func AssertOAuthClientError(suite *suite.Suite, err requesterror.OAuthRequestError, expectedErrorName string, expectedMessageSubStrs ...string) {
-	AssertClientError(suite, err.RequestError, expectedMessageSubStrs...)
-	suite.Equal(expectedErrorName, err.ErrorName)
+func AssertAuditEventSaved(suite *suite.Suite, CRUDMock *databasemocks.CRUDOperations, action string, outcome string) {
+	CRUDMock.AssertCalled(suite.T(), "SaveAuditEvent", mock.Anything, mock.MatchedBy(func(event *models.AuditEvent) bool {
+		return event.Action == action && event.Outcome == outcome
+	}))
 }
 
-func AssertOAuthInternalError(suite *suite.Suite, err requesterror.OAuthRequestError) {
-	AssertInternalError(suite, err.RequestError)
+func AssertAuditFailureRecorded(suite *suite.Suite, failures []*models.AuditEvent, action string, details string) {
+	suite.Require().Len(failures, 1)
+	suite.Equal(action, failures[0].Action)
+	suite.Equal(models.AuditOutcomeFailure, failures[0].Outcome)
+	suite.Equal(details, failures[0].Details)
+}
+
+func AssertOutboxEventSaved(suite *suite.Suite, CRUDMock *databasemocks.CRUDOperations, eventType string, user *models.User) {
+	CRUDMock.AssertCalled(suite.T(), "SaveOutboxEvent", mock.Anything, mock.MatchedBy(func(event *models.OutboxEvent) bool {
+		var payload struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+			Data struct {
+				UserID   string `json:"user_id"`
+				Username string `json:"username"`
+			} `json:"data"`
+		}
+		err := json.Unmarshal([]byte(event.Payload), &payload)
+
+		return err == nil && event.Type == eventType && payload.ID == event.ID.String() && payload.Type == eventType &&
+			payload.Data.UserID == user.ID.String() && payload.Data.Username == user.Username
+	}))
 }
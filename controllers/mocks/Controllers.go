@@ -4,14 +4,12 @@ package mocks
 
 import (
 	controllers "authserver/controllers"
-
-	mock "github.com/stretchr/testify/mock"
-
 	models "authserver/models"
-
-	requesterror "authserver/common/request_error"
+	context "context"
+	x509 "crypto/x509"
 
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Controllers is an autogenerated mock type for the Controllers type
@@ -19,103 +17,299 @@ type Controllers struct {
 	mock.Mock
 }
 
-// CreateTokenFromPassword provides a mock function with given fields: CRUD, username, password, clientID, scopeName
-func (_m *Controllers) CreateTokenFromPassword(CRUD controllers.TokenControllerCRUD, username string, password string, clientID uuid.UUID, scopeName string) (*models.AccessToken, requesterror.OAuthRequestError) {
-	ret := _m.Called(CRUD, username, password, clientID, scopeName)
+// CreateTokenFromPassword provides a mock function with given fields: ctx, CRUD, username, password, clientID, clientCert, scopeName
+func (_m *Controllers) CreateTokenFromPassword(ctx context.Context, CRUD controllers.TokenControllerCRUD, username string, password string, clientID uuid.UUID, clientCert *x509.Certificate, scopeName string) (*models.AccessToken, error) {
+	ret := _m.Called(ctx, CRUD, username, password, clientID, clientCert, scopeName)
 
 	var r0 *models.AccessToken
-	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, string, string, uuid.UUID, string) *models.AccessToken); ok {
-		r0 = rf(CRUD, username, password, clientID, scopeName)
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.TokenControllerCRUD, string, string, uuid.UUID, *x509.Certificate, string) *models.AccessToken); ok {
+		r0 = rf(ctx, CRUD, username, password, clientID, clientCert, scopeName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.AccessToken)
 		}
 	}
 
-	var r1 requesterror.OAuthRequestError
-	if rf, ok := ret.Get(1).(func(controllers.TokenControllerCRUD, string, string, uuid.UUID, string) requesterror.OAuthRequestError); ok {
-		r1 = rf(CRUD, username, password, clientID, scopeName)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.TokenControllerCRUD, string, string, uuid.UUID, *x509.Certificate, string) error); ok {
+		r1 = rf(ctx, CRUD, username, password, clientID, clientCert, scopeName)
 	} else {
-		r1 = ret.Get(1).(requesterror.OAuthRequestError)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// CreateUser provides a mock function with given fields: CRUD, username, password
-func (_m *Controllers) CreateUser(CRUD controllers.UserControllerCRUD, username string, password string) (*models.User, requesterror.RequestError) {
-	ret := _m.Called(CRUD, username, password)
+// CreateUser provides a mock function with given fields: ctx, CRUD, username, password
+func (_m *Controllers) CreateUser(ctx context.Context, CRUD controllers.UserControllerCRUD, username string, password string) (*models.User, error) {
+	ret := _m.Called(ctx, CRUD, username, password)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, string, string) *models.User); ok {
-		r0 = rf(CRUD, username, password)
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.UserControllerCRUD, string, string) *models.User); ok {
+		r0 = rf(ctx, CRUD, username, password)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
 		}
 	}
 
-	var r1 requesterror.RequestError
-	if rf, ok := ret.Get(1).(func(controllers.UserControllerCRUD, string, string) requesterror.RequestError); ok {
-		r1 = rf(CRUD, username, password)
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.UserControllerCRUD, string, string) error); ok {
+		r1 = rf(ctx, CRUD, username, password)
 	} else {
-		r1 = ret.Get(1).(requesterror.RequestError)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
 }
 
-// DeleteAllOtherUserTokens provides a mock function with given fields: CRUD, token
-func (_m *Controllers) DeleteAllOtherUserTokens(CRUD controllers.TokenControllerCRUD, token *models.AccessToken) requesterror.RequestError {
-	ret := _m.Called(CRUD, token)
+// DeleteAllOtherUserTokens provides a mock function with given fields: ctx, CRUD, token
+func (_m *Controllers) DeleteAllOtherUserTokens(ctx context.Context, CRUD controllers.TokenControllerCRUD, token *models.AccessToken) error {
+	ret := _m.Called(ctx, CRUD, token)
 
-	var r0 requesterror.RequestError
-	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, *models.AccessToken) requesterror.RequestError); ok {
-		r0 = rf(CRUD, token)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.TokenControllerCRUD, *models.AccessToken) error); ok {
+		r0 = rf(ctx, CRUD, token)
 	} else {
-		r0 = ret.Get(0).(requesterror.RequestError)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// DeleteToken provides a mock function with given fields: CRUD, token
-func (_m *Controllers) DeleteToken(CRUD controllers.TokenControllerCRUD, token *models.AccessToken) requesterror.RequestError {
-	ret := _m.Called(CRUD, token)
+// DeleteToken provides a mock function with given fields: ctx, CRUD, token
+func (_m *Controllers) DeleteToken(ctx context.Context, CRUD controllers.TokenControllerCRUD, token *models.AccessToken) error {
+	ret := _m.Called(ctx, CRUD, token)
 
-	var r0 requesterror.RequestError
-	if rf, ok := ret.Get(0).(func(controllers.TokenControllerCRUD, *models.AccessToken) requesterror.RequestError); ok {
-		r0 = rf(CRUD, token)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.TokenControllerCRUD, *models.AccessToken) error); ok {
+		r0 = rf(ctx, CRUD, token)
 	} else {
-		r0 = ret.Get(0).(requesterror.RequestError)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// DeleteUser provides a mock function with given fields: CRUD, user
-func (_m *Controllers) DeleteUser(CRUD controllers.UserControllerCRUD, user *models.User) requesterror.RequestError {
-	ret := _m.Called(CRUD, user)
+// DeleteUser provides a mock function with given fields: ctx, CRUD, user
+func (_m *Controllers) DeleteUser(ctx context.Context, CRUD controllers.UserControllerCRUD, user *models.User) error {
+	ret := _m.Called(ctx, CRUD, user)
 
-	var r0 requesterror.RequestError
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, *models.User) requesterror.RequestError); ok {
-		r0 = rf(CRUD, user)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.UserControllerCRUD, *models.User) error); ok {
+		r0 = rf(ctx, CRUD, user)
 	} else {
-		r0 = ret.Get(0).(requesterror.RequestError)
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// UpdateUserPassword provides a mock function with given fields: CRUD, user, oldPassword, newPassword
-func (_m *Controllers) UpdateUserPassword(CRUD controllers.UserControllerCRUD, user *models.User, oldPassword string, newPassword string) requesterror.RequestError {
-	ret := _m.Called(CRUD, user, oldPassword, newPassword)
+// DeleteUserByID provides a mock function with given fields: ctx, CRUD, admin, ID
+func (_m *Controllers) DeleteUserByID(ctx context.Context, CRUD controllers.AdminUserControllerCRUD, admin *models.User, ID uuid.UUID) error {
+	ret := _m.Called(ctx, CRUD, admin, ID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, uuid.UUID) error); ok {
+		r0 = rf(ctx, CRUD, admin, ID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAuditEvents provides a mock function with given fields: ctx, CRUD, admin, filter
+func (_m *Controllers) GetAuditEvents(ctx context.Context, CRUD controllers.AuditControllerCRUD, admin *models.User, filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	ret := _m.Called(ctx, CRUD, admin, filter)
+
+	var r0 []*models.AuditEvent
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.AuditControllerCRUD, *models.User, models.AuditEventFilter) []*models.AuditEvent); ok {
+		r0 = rf(ctx, CRUD, admin, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.AuditControllerCRUD, *models.User, models.AuditEventFilter) error); ok {
+		r1 = rf(ctx, CRUD, admin, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetteredOutboxEvents provides a mock function with given fields: ctx, CRUD, filter
+func (_m *Controllers) GetDeadLetteredOutboxEvents(ctx context.Context, CRUD controllers.OutboxControllerCRUD, filter models.OutboxEventFilter) ([]*models.OutboxEvent, error) {
+	ret := _m.Called(ctx, CRUD, filter)
+
+	var r0 []*models.OutboxEvent
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.OutboxControllerCRUD, models.OutboxEventFilter) []*models.OutboxEvent); ok {
+		r0 = rf(ctx, CRUD, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.OutboxControllerCRUD, models.OutboxEventFilter) error); ok {
+		r1 = rf(ctx, CRUD, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, CRUD, user
+func (_m *Controllers) GetUser(ctx context.Context, CRUD controllers.UserControllerCRUD, user *models.User) (*models.User, error) {
+	ret := _m.Called(ctx, CRUD, user)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.UserControllerCRUD, *models.User) *models.User); ok {
+		r0 = rf(ctx, CRUD, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.UserControllerCRUD, *models.User) error); ok {
+		r1 = rf(ctx, CRUD, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: ctx, CRUD, admin, ID
+func (_m *Controllers) GetUserByID(ctx context.Context, CRUD controllers.AdminUserControllerCRUD, admin *models.User, ID uuid.UUID) (*models.User, error) {
+	ret := _m.Called(ctx, CRUD, admin, ID)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, uuid.UUID) *models.User); ok {
+		r0 = rf(ctx, CRUD, admin, ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, uuid.UUID) error); ok {
+		r1 = rf(ctx, CRUD, admin, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: ctx, CRUD, admin, filter
+func (_m *Controllers) GetUsers(ctx context.Context, CRUD controllers.AdminUserControllerCRUD, admin *models.User, filter models.UserFilter) ([]*models.User, int, error) {
+	ret := _m.Called(ctx, CRUD, admin, filter)
+
+	var r0 []*models.User
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, models.UserFilter) []*models.User); ok {
+		r0 = rf(ctx, CRUD, admin, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, models.UserFilter) int); ok {
+		r1 = rf(ctx, CRUD, admin, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, models.UserFilter) error); ok {
+		r2 = rf(ctx, CRUD, admin, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ResetUserPassword provides a mock function with given fields: ctx, CRUD, admin, ID, password
+func (_m *Controllers) ResetUserPassword(ctx context.Context, CRUD controllers.AdminUserControllerCRUD, admin *models.User, ID uuid.UUID, password string) error {
+	ret := _m.Called(ctx, CRUD, admin, ID, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, CRUD, admin, ID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserDisabled provides a mock function with given fields: ctx, CRUD, admin, ID, disabled
+func (_m *Controllers) SetUserDisabled(ctx context.Context, CRUD controllers.AdminUserControllerCRUD, admin *models.User, ID uuid.UUID, disabled bool) (*models.User, error) {
+	ret := _m.Called(ctx, CRUD, admin, ID, disabled)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, uuid.UUID, bool) *models.User); ok {
+		r0 = rf(ctx, CRUD, admin, ID, disabled)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.AdminUserControllerCRUD, *models.User, uuid.UUID, bool) error); ok {
+		r1 = rf(ctx, CRUD, admin, ID, disabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUser provides a mock function with given fields: ctx, CRUD, user, update
+func (_m *Controllers) UpdateUser(ctx context.Context, CRUD controllers.UserControllerCRUD, user *models.User, update models.UserUpdate) (*models.User, error) {
+	ret := _m.Called(ctx, CRUD, user, update)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.UserControllerCRUD, *models.User, models.UserUpdate) *models.User); ok {
+		r0 = rf(ctx, CRUD, user, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, controllers.UserControllerCRUD, *models.User, models.UserUpdate) error); ok {
+		r1 = rf(ctx, CRUD, user, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateUserPassword provides a mock function with given fields: ctx, CRUD, user, oldPassword, newPassword
+func (_m *Controllers) UpdateUserPassword(ctx context.Context, CRUD controllers.UserControllerCRUD, user *models.User, oldPassword string, newPassword string) error {
+	ret := _m.Called(ctx, CRUD, user, oldPassword, newPassword)
 
-	var r0 requesterror.RequestError
-	if rf, ok := ret.Get(0).(func(controllers.UserControllerCRUD, *models.User, string, string) requesterror.RequestError); ok {
-		r0 = rf(CRUD, user, oldPassword, newPassword)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, controllers.UserControllerCRUD, *models.User, string, string) error); ok {
+		r0 = rf(ctx, CRUD, user, oldPassword, newPassword)
 	} else {
-		r0 = ret.Get(0).(requesterror.RequestError)
+		r0 = ret.Error(0)
 	}
 
 	return r0
@@ -3,39 +3,62 @@ package controllers
 import (
 	"authserver/common"
 	requesterror "authserver/common/request_error"
+	"authserver/logger"
 	"authserver/models"
-	"log"
+	"context"
+	"crypto/x509"
 
 	"github.com/google/uuid"
 )
 
-func parseClient(clientCRUD models.ClientCRUD, clientID uuid.UUID) (*models.Client, requesterror.OAuthRequestError) {
+func parseClient(ctx context.Context, clientCRUD models.ClientCRUD, clientID uuid.UUID) (*models.Client, error) {
 	//get the client
-	client, err := clientCRUD.GetClientByID(clientID)
+	client, err := clientCRUD.GetClientByID(ctx, clientID)
 	if err != nil {
-		log.Println(common.ChainError("error getting client by id", err))
-		return nil, requesterror.OAuthInternalError()
+		logger.FromContext(ctx).Error(common.ChainError("error getting client by id", err))
+		return nil, requesterror.InternalError()
 	}
 
 	//check client was found
 	if client == nil {
-		return nil, requesterror.OAuthClientError("invalid_client", "client with id not found")
+		return nil, requesterror.OAuthClientError(requesterror.OAuthErrorInvalidClient, "client with id not found")
 	}
 
-	return client, requesterror.OAuthNoError()
+	return client, nil
 }
 
-func parseScope(scopeCRUD models.ScopeCRUD, name string) (*models.Scope, requesterror.OAuthRequestError) {
+func authenticateClient(ctx context.Context, client *models.Client, cert *x509.Certificate) error {
+	//public clients do not need to authenticate
+	if client.TLSClientAuthSubjectDN == "" {
+		return nil
+	}
+
+	//the certificate chain has already been verified during the tls handshake, so only the subject needs to be checked
+	if cert == nil {
+		return requesterror.OAuthClientError(requesterror.OAuthErrorInvalidClient, "client certificate is required")
+	}
+	if cert.Subject.String() != client.TLSClientAuthSubjectDN {
+		logger.FromContext(ctx).
+			With("subject", cert.Subject.String()).
+			With("expected_subject", client.TLSClientAuthSubjectDN).
+			Warn("client certificate subject does not match")
+		return requesterror.OAuthClientError(requesterror.OAuthErrorInvalidClient, "client certificate is invalid")
+	}
+
+	return nil
+}
+
+func parseScope(ctx context.Context, scopeCRUD models.ScopeCRUD, name string) (*models.Scope, error) {
 	//get the scope
-	scope, err := scopeCRUD.GetScopeByName(name)
+	scope, err := scopeCRUD.GetScopeByName(ctx, name)
 	if err != nil {
-		log.Println(common.ChainError("error getting scope by name", err))
-		return nil, requesterror.OAuthInternalError()
+		logger.FromContext(ctx).Error(common.ChainError("error getting scope by name", err))
+		return nil, requesterror.InternalError()
 	}
 
 	if scope == nil {
 		return nil, requesterror.OAuthClientError("invalid_scope", "scope with name not found")
 	}
 
-	return scope, requesterror.OAuthNoError()
+	return scope, nil
 }
@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/logger"
+	"authserver/models"
+	"context"
+)
+
+// OutboxControl handles requests to "/admin/webhook-events" endpoints
+type OutboxControl struct{}
+
+// GetDeadLetteredOutboxEvents gets a page of the outbox events that could not be delivered to their webhooks.
+func (c OutboxControl) GetDeadLetteredOutboxEvents(ctx context.Context, CRUD OutboxControllerCRUD, filter models.OutboxEventFilter) ([]*models.OutboxEvent, error) {
+	events, err := CRUD.GetDeadLetteredOutboxEvents(ctx, filter)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error getting dead lettered outbox events", err))
+		return nil, requesterror.InternalError()
+	}
+
+	return events, nil
+}
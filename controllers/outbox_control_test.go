@@ -0,0 +1,57 @@
+package controllers_test
+
+import (
+	"authserver/controllers"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxControlTestSuite struct {
+	suite.Suite
+	CRUDMock      databasemocks.CRUDOperations
+	OutboxControl controllers.OutboxControl
+}
+
+func (suite *OutboxControlTestSuite) SetupTest() {
+	suite.CRUDMock = databasemocks.CRUDOperations{}
+	suite.OutboxControl = controllers.OutboxControl{}
+}
+
+func (suite *OutboxControlTestSuite) TestGetDeadLetteredOutboxEvents_WithErrorGettingEvents_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetDeadLetteredOutboxEvents", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	events, rerr := suite.OutboxControl.GetDeadLetteredOutboxEvents(context.Background(), &suite.CRUDMock, models.OutboxEventFilter{})
+
+	//assert
+	suite.Nil(events)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *OutboxControlTestSuite) TestGetDeadLetteredOutboxEvents_WithValidRequest_ReturnsEvents() {
+	//arrange
+	filter := models.OutboxEventFilter{Limit: 10, Offset: 20}
+	expectedEvents := []*models.OutboxEvent{models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")}
+
+	suite.CRUDMock.On("GetDeadLetteredOutboxEvents", mock.Anything, mock.Anything).Return(expectedEvents, nil)
+
+	//act
+	events, rerr := suite.OutboxControl.GetDeadLetteredOutboxEvents(context.Background(), &suite.CRUDMock, filter)
+
+	//assert
+	suite.CRUDMock.AssertCalled(suite.T(), "GetDeadLetteredOutboxEvents", mock.Anything, filter)
+
+	suite.Equal(expectedEvents, events)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func TestOutboxControlTestSuite(t *testing.T) {
+	suite.Run(t, &OutboxControlTestSuite{})
+}
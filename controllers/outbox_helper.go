@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// outboxEventPayload is the json body delivered to webhooks for an outbox event.
+type outboxEventPayload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// userEventData is the data of outbox events about a user.
+type userEventData struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// newUserOutboxEvent creates a new outbox event of the given type about the user. Returns the event and any errors.
+func newUserOutboxEvent(eventType string, user *models.User) (*models.OutboxEvent, error) {
+	event := models.CreateNewOutboxEvent(eventType, "")
+
+	payload, err := json.Marshal(outboxEventPayload{
+		ID:        event.ID.String(),
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt,
+		Data: userEventData{
+			UserID:   user.ID.String(),
+			Username: user.Username,
+		},
+	})
+	if err != nil {
+		return nil, common.ChainError("error encoding outbox event payload", err)
+	}
+
+	event.Payload = string(payload)
+	return event, nil
+}
+
+// saveUserOutboxEvent creates and saves a new outbox event of the given type about the user.
+// The event is saved with the CRUD's transaction so it is only delivered if the change is committed. Returns any errors.
+func saveUserOutboxEvent(ctx context.Context, CRUD models.OutboxEventCRUD, eventType string, user *models.User) error {
+	event, err := newUserOutboxEvent(eventType, user)
+	if err != nil {
+		return err
+	}
+
+	err = CRUD.SaveOutboxEvent(ctx, event)
+	if err != nil {
+		return common.ChainError("error saving outbox event", err)
+	}
+
+	return nil
+}
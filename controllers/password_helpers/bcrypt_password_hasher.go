@@ -2,16 +2,24 @@ package passwordhelpers
 
 import (
 	"authserver/common"
+	"authserver/metrics"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 // BCryptPasswordHasher is an implementation of the PasswordHasher that uses the bcrypt algorithm.
-type BCryptPasswordHasher struct{}
+type BCryptPasswordHasher struct {
+	// MetricsRecorder is used to record how long hashing takes. Optional.
+	MetricsRecorder metrics.Recorder
+}
 
 // HashPassword hashes the password using the bcrypt algorithm and returns the hash. Also returns any errors.
-func (BCryptPasswordHasher) HashPassword(password string) ([]byte, error) {
+func (h BCryptPasswordHasher) HashPassword(password string) ([]byte, error) {
+	start := time.Now()
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	h.observePasswordHash(metrics.PasswordHashOperationHash, time.Since(start))
+
 	if err != nil {
 		return nil, common.ChainError("bcrypt generate hash from password error", err)
 	}
@@ -20,11 +28,21 @@ func (BCryptPasswordHasher) HashPassword(password string) ([]byte, error) {
 }
 
 // ComparePasswords compares a password hash and a plain text password using the bcrypt algorithm and returns any errors.
-func (BCryptPasswordHasher) ComparePasswords(hash []byte, password string) error {
+func (h BCryptPasswordHasher) ComparePasswords(hash []byte, password string) error {
+	start := time.Now()
 	err := bcrypt.CompareHashAndPassword(hash, []byte(password))
+	h.observePasswordHash(metrics.PasswordHashOperationCompare, time.Since(start))
+
 	if err != nil {
 		return common.ChainError("bcrypt compare hash and password error", err)
 	}
 
 	return nil
 }
+
+// observePasswordHash records the duration of the operation if the hasher has a metrics recorder.
+func (h BCryptPasswordHasher) observePasswordHash(operation string, duration time.Duration) {
+	if h.MetricsRecorder != nil {
+		h.MetricsRecorder.ObservePasswordHash(operation, duration)
+	}
+}
@@ -2,24 +2,35 @@ package passwordhelpers_test
 
 import (
 	passwordhelpers "authserver/controllers/password_helpers"
+	"authserver/metrics"
+	metricsmocks "authserver/metrics/mocks"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 type BCryptPasswordHasherTestSuite struct {
 	suite.Suite
+	MetricsRecorderMock  metricsmocks.Recorder
 	BCryptPasswordHasher passwordhelpers.BCryptPasswordHasher
 }
 
 func (suite *BCryptPasswordHasherTestSuite) SetupTest() {
-	suite.BCryptPasswordHasher = passwordhelpers.BCryptPasswordHasher{}
+	suite.MetricsRecorderMock = metricsmocks.Recorder{}
+	suite.BCryptPasswordHasher = passwordhelpers.BCryptPasswordHasher{
+		MetricsRecorder: &suite.MetricsRecorderMock,
+	}
+
+	suite.MetricsRecorderMock.On("ObservePasswordHash", mock.Anything, mock.Anything)
 }
 
 func (suite *BCryptPasswordHasherTestSuite) TestHashPassword_WithNoError_ReturnsHashAndNilError() {
 	hash, err := suite.BCryptPasswordHasher.HashPassword("password")
 	suite.NotNil(hash)
 	suite.NoError(err)
+
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObservePasswordHash", metrics.PasswordHashOperationHash, mock.Anything)
 }
 
 func (suite *BCryptPasswordHasherTestSuite) TestComparePasswords_WherePasswordMatchesHash_ReturnsNilError() {
@@ -44,6 +55,20 @@ func (suite *BCryptPasswordHasherTestSuite) TestComparePasswords_WherePasswordDo
 
 	//assert
 	suite.Error(err)
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObservePasswordHash", metrics.PasswordHashOperationCompare, mock.Anything)
+}
+
+func (suite *BCryptPasswordHasherTestSuite) TestHashPasswordAndComparePasswords_WithNoMetricsRecorder_DoesNotPanic() {
+	//arrange
+	hasher := passwordhelpers.BCryptPasswordHasher{}
+
+	//act
+	hash, hashErr := hasher.HashPassword("password")
+	compareErr := hasher.ComparePasswords(hash, "password")
+
+	//assert
+	suite.NoError(hashErr)
+	suite.NoError(compareErr)
 }
 
 func TestBCryptPasswordHasherTestSuite(t *testing.T) {
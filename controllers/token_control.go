@@ -4,8 +4,11 @@ import (
 	"authserver/common"
 	requesterror "authserver/common/request_error"
 	passwordhelpers "authserver/controllers/password_helpers"
+	"authserver/logger"
+	"authserver/metrics"
 	"authserver/models"
-	"log"
+	"context"
+	"crypto/x509"
 
 	"github.com/google/uuid"
 )
@@ -13,76 +16,135 @@ import (
 // TokenControl handles requests to "/token" endpoints
 type TokenControl struct {
 	PasswordHasher passwordhelpers.PasswordHasher
+
+	// MetricsRecorder is used to record failed logins. Optional.
+	MetricsRecorder metrics.Recorder
 }
 
 // PostToken handles POST requests to "/token"
-func (c TokenControl) CreateTokenFromPassword(CRUD TokenControllerCRUD, username string, password string, clientID uuid.UUID, scopeName string) (*models.AccessToken, requesterror.OAuthRequestError) {
+func (c TokenControl) CreateTokenFromPassword(ctx context.Context, CRUD TokenControllerCRUD, username string, password string, clientID uuid.UUID, clientCert *x509.Certificate, scopeName string) (*models.AccessToken, error) {
 	//get the client
-	client, rerr := parseClient(CRUD, clientID)
-	if rerr.Type != requesterror.ErrorTypeNone {
+	client, rerr := parseClient(ctx, CRUD, clientID)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	//authenticate the client
+	rerr = authenticateClient(ctx, client, clientCert)
+	if rerr != nil {
+		c.observeLoginFailure("invalid_client_certificate")
+		recordAuditFailure(ctx, newAuditEvent(ctx, models.AuditActionLogin, models.AuditOutcomeFailure, uuid.Nil, uuid.Nil, client.ID), "invalid_client_certificate")
 		return nil, rerr
 	}
 
 	//get the scope
-	scope, rerr := parseScope(CRUD, scopeName)
-	if rerr.Type != requesterror.ErrorTypeNone {
+	scope, rerr := parseScope(ctx, CRUD, scopeName)
+	if rerr != nil {
 		return nil, rerr
 	}
 
 	//get the user
-	user, err := CRUD.GetUserByUsername(username)
+	user, err := CRUD.GetUserByUsername(ctx, username)
 	if err != nil {
-		log.Println(common.ChainError("error getting user by username", err))
-		return nil, requesterror.OAuthInternalError()
+		logger.FromContext(ctx).Error(common.ChainError("error getting user by username", err))
+		return nil, requesterror.InternalError()
 	}
 
 	//check if user was found
 	if user == nil {
+		c.observeLoginFailure("unknown_user")
+		recordAuditFailure(ctx, newAuditEvent(ctx, models.AuditActionLogin, models.AuditOutcomeFailure, uuid.Nil, uuid.Nil, client.ID), "unknown_user")
 		return nil, requesterror.OAuthClientError("invalid_grant", "invalid username and/or password")
 	}
 
 	//validate the password
 	err = c.PasswordHasher.ComparePasswords(user.PasswordHash, password)
 	if err != nil {
-		log.Println(common.ChainError("error comparing password hashes", err))
+		logger.FromContext(ctx).With("error", err.Error()).Info("password does not match")
+		c.observeLoginFailure("invalid_password")
+		recordAuditFailure(ctx, newAuditEvent(ctx, models.AuditActionLogin, models.AuditOutcomeFailure, user.ID, user.ID, client.ID), "invalid_password")
 		return nil, requesterror.OAuthClientError("invalid_grant", "invalid username and/or password")
 	}
 
+	//check the user has not been disabled by an admin
+	if user.IsDisabled {
+		c.observeLoginFailure("user_disabled")
+		recordAuditFailure(ctx, newAuditEvent(ctx, models.AuditActionLogin, models.AuditOutcomeFailure, user.ID, user.ID, client.ID), "user_disabled")
+		return nil, requesterror.OAuthClientError("invalid_grant", "user account is disabled")
+	}
+
 	//create a new access token
 	token := models.CreateNewAccessToken(user, client, scope)
 
 	//save the token
-	err = CRUD.SaveAccessToken(token)
+	err = CRUD.SaveAccessToken(ctx, token)
 	if err != nil {
-		log.Println(common.ChainError("error saving access token", err))
-		return nil, requesterror.OAuthInternalError()
+		logger.FromContext(ctx).Error(common.ChainError("error saving access token", err))
+		return nil, requesterror.InternalError()
 	}
 
-	return token, requesterror.OAuthNoError()
+	//record the login and token issue
+	loginEvent := newAuditEvent(ctx, models.AuditActionLogin, models.AuditOutcomeSuccess, user.ID, user.ID, client.ID)
+	tokenEvent := newAuditEvent(ctx, models.AuditActionTokenIssue, models.AuditOutcomeSuccess, user.ID, user.ID, client.ID)
+	tokenEvent.Details = scope.Name
+
+	for _, event := range []*models.AuditEvent{loginEvent, tokenEvent} {
+		err = CRUD.SaveAuditEvent(ctx, event)
+		if err != nil {
+			logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+			return nil, requesterror.InternalError()
+		}
+	}
+
+	return token, nil
 }
 
 // DeleteToken deletes the access token.
-func (c TokenControl) DeleteToken(CRUD TokenControllerCRUD, token *models.AccessToken) requesterror.RequestError {
+func (c TokenControl) DeleteToken(ctx context.Context, CRUD TokenControllerCRUD, token *models.AccessToken) error {
 	//delete the token
-	err := CRUD.DeleteAccessToken(token)
+	err := CRUD.DeleteAccessToken(ctx, token)
 	if err != nil {
-		log.Println(common.ChainError("error deleting access token", err))
+		logger.FromContext(ctx).Error(common.ChainError("error deleting access token", err))
+		return requesterror.InternalError()
+	}
+
+	//record the token revocation
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionTokenRevoke, models.AuditOutcomeSuccess, token.User.ID, token.User.ID, token.Client.ID))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
 		return requesterror.InternalError()
 	}
 
 	//return success
-	return requesterror.NoError()
+	return nil
 }
 
 // DeleteToken deletes all of the user's tokens accept for the provided one.
-func (c TokenControl) DeleteAllOtherUserTokens(CRUD TokenControllerCRUD, token *models.AccessToken) requesterror.RequestError {
+func (c TokenControl) DeleteAllOtherUserTokens(ctx context.Context, CRUD TokenControllerCRUD, token *models.AccessToken) error {
 	//delete the token
-	err := CRUD.DeleteAllOtherUserTokens(token)
+	err := CRUD.DeleteAllOtherUserTokens(ctx, token)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error deleting all other user tokens", err))
+		return requesterror.InternalError()
+	}
+
+	//record the token revocation
+	event := newAuditEvent(ctx, models.AuditActionTokenRevoke, models.AuditOutcomeSuccess, token.User.ID, token.User.ID, token.Client.ID)
+	event.Details = "all_other_tokens"
+
+	err = CRUD.SaveAuditEvent(ctx, event)
 	if err != nil {
-		log.Println(common.ChainError("error deleting all other user tokens", err))
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
 		return requesterror.InternalError()
 	}
 
 	//return success
-	return requesterror.NoError()
+	return nil
+}
+
+// observeLoginFailure records the failed login if the controller has a metrics recorder.
+func (c TokenControl) observeLoginFailure(reason string) {
+	if c.MetricsRecorder != nil {
+		c.MetricsRecorder.ObserveLoginFailure(reason)
+	}
 }
@@ -1,8 +1,12 @@
 package controllers_test
 
 import (
+	"authserver/audit"
 	"authserver/controllers"
 	"authserver/models"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"testing"
 
@@ -10,6 +14,7 @@ import (
 
 	passwordhelpermocks "authserver/controllers/password_helpers/mocks"
 	databasemocks "authserver/database/mocks"
+	metricsmocks "authserver/metrics/mocks"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
@@ -17,17 +22,22 @@ import (
 
 type TokenControlTestSuite struct {
 	suite.Suite
-	CRUDMock           databasemocks.CRUDOperations
-	PasswordHasherMock passwordhelpermocks.PasswordHasher
-	TokenControl       controllers.TokenControl
+	CRUDMock            databasemocks.CRUDOperations
+	PasswordHasherMock  passwordhelpermocks.PasswordHasher
+	MetricsRecorderMock metricsmocks.Recorder
+	TokenControl        controllers.TokenControl
 }
 
 func (suite *TokenControlTestSuite) SetupTest() {
 	suite.CRUDMock = databasemocks.CRUDOperations{}
 	suite.PasswordHasherMock = passwordhelpermocks.PasswordHasher{}
+	suite.MetricsRecorderMock = metricsmocks.Recorder{}
 	suite.TokenControl = controllers.TokenControl{
-		PasswordHasher: &suite.PasswordHasherMock,
+		PasswordHasher:  &suite.PasswordHasherMock,
+		MetricsRecorder: &suite.MetricsRecorderMock,
 	}
+
+	suite.MetricsRecorderMock.On("ObserveLoginFailure", mock.Anything)
 }
 
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorGettingClientByID_ReturnsInternalError() {
@@ -37,14 +47,14 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorGetting
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
-	AssertOAuthInternalError(&suite.Suite, rerr)
+	AssertInternalError(&suite.Suite, rerr)
 }
 
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereClientWithIDisNotFound_ReturnsInvalidClient() {
@@ -54,16 +64,50 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereClientWithI
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(nil, nil)
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
 	AssertOAuthClientError(&suite.Suite, rerr, "invalid_client", "")
 }
 
+func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereClientRequiresTLSClientAuth_WithMissingOrInvalidCertificate_ReturnsInvalidClient() {
+	var cert *x509.Certificate
+
+	testCase := func() {
+		//arrange
+		username := "username"
+		password := "password"
+		clientID := uuid.New()
+		scope := "scope"
+
+		client := &models.Client{
+			ID:                     clientID,
+			TLSClientAuthSubjectDN: "CN=client",
+		}
+		suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(client, nil)
+
+		//act
+		token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, cert, scope)
+
+		//assert
+		suite.Nil(token)
+		AssertOAuthClientError(&suite.Suite, rerr, "invalid_client", "client certificate")
+		suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveLoginFailure", "invalid_client_certificate")
+	}
+
+	cert = nil
+	suite.Run("MissingCertificate", testCase)
+
+	cert = &x509.Certificate{
+		Subject: pkix.Name{CommonName: "other"},
+	}
+	suite.Run("CertificateSubjectDoesNotMatch", testCase)
+}
+
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorGettingScopeByName_ReturnsInternalError() {
 	//arrange
 	username := "username"
@@ -71,15 +115,15 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorGetting
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(&models.Client{}, nil)
-	suite.CRUDMock.On("GetScopeByName", mock.Anything).Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
-	AssertOAuthInternalError(&suite.Suite, rerr)
+	AssertInternalError(&suite.Suite, rerr)
 }
 
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereNoScopeWithNameisNotFound_ReturnsInvalidScope() {
@@ -89,11 +133,11 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereNoScopeWith
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(&models.Client{}, nil)
-	suite.CRUDMock.On("GetScopeByName", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(nil, nil)
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
@@ -107,16 +151,16 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorGetting
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(&models.Client{}, nil)
-	suite.CRUDMock.On("GetScopeByName", mock.Anything).Return(&models.Scope{}, nil)
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(&models.Scope{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
-	AssertOAuthInternalError(&suite.Suite, rerr)
+	AssertInternalError(&suite.Suite, rerr)
 }
 
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereUserWithUsernameIsNotFound_ReturnsClientError() {
@@ -126,16 +170,17 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereUserWithUse
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(&models.Client{}, nil)
-	suite.CRUDMock.On("GetScopeByName", mock.Anything).Return(&models.Scope{}, nil)
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(&models.Scope{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(nil, nil)
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
 	AssertOAuthClientError(&suite.Suite, rerr, "invalid_grant", "username", "password")
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveLoginFailure", "unknown_user")
 }
 
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WherePasswordDoesNotMatch_ReturnsClientError() {
@@ -145,17 +190,47 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WherePasswordDoe
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(&models.Client{}, nil)
-	suite.CRUDMock.On("GetScopeByName", mock.Anything).Return(&models.Scope{}, nil)
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(&models.Scope{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(&models.User{}, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(errors.New(""))
 
+	trail := audit.CreateTrail("127.0.0.1", "user agent")
+
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(audit.NewContext(context.Background(), trail), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
 	AssertOAuthClientError(&suite.Suite, rerr, "invalid_grant", "username", "password")
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveLoginFailure", "invalid_password")
+	AssertAuditFailureRecorded(&suite.Suite, trail.Failures(), models.AuditActionLogin, "invalid_password")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveAuditEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereUserIsDisabled_ReturnsClientError() {
+	//arrange
+	username := "username"
+	password := "password"
+	clientID := uuid.New()
+	scope := "scope"
+
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(&models.Scope{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(&models.User{IsDisabled: true}, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+
+	trail := audit.CreateTrail("127.0.0.1", "user agent")
+
+	//act
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(audit.NewContext(context.Background(), trail), &suite.CRUDMock, username, password, clientID, nil, scope)
+
+	//assert
+	suite.Nil(token)
+	AssertOAuthClientError(&suite.Suite, rerr, "invalid_grant", "disabled")
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveLoginFailure", "user_disabled")
+	AssertAuditFailureRecorded(&suite.Suite, trail.Failures(), models.AuditActionLogin, "user_disabled")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveAccessToken", mock.Anything, mock.Anything)
 }
 
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorSavingAccessToken_ReturnsInternalError() {
@@ -165,18 +240,18 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorSavingA
 	clientID := uuid.New()
 	scope := "scope"
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(&models.Client{}, nil)
-	suite.CRUDMock.On("GetScopeByName", mock.Anything).Return(&models.Scope{}, nil)
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(&models.Scope{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(&models.User{}, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
-	suite.CRUDMock.On("SaveAccessToken", mock.Anything).Return(errors.New(""))
+	suite.CRUDMock.On("SaveAccessToken", mock.Anything, mock.Anything).Return(errors.New(""))
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scope)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
 
 	//assert
 	suite.Nil(token)
-	AssertOAuthInternalError(&suite.Suite, rerr)
+	AssertInternalError(&suite.Suite, rerr)
 }
 
 func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithValidRequest_ReturnsOK() {
@@ -190,38 +265,95 @@ func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithValidRequest
 	scope := &models.Scope{ID: uuid.New()}
 	user := &models.User{ID: uuid.New()}
 
-	suite.CRUDMock.On("GetClientByID", mock.Anything).Return(client, nil)
-	suite.CRUDMock.On("GetScopeByName", mock.Anything).Return(scope, nil)
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(user, nil)
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(scope, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(user, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
-	suite.CRUDMock.On("SaveAccessToken", mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAccessToken", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
 
 	//act
-	token, rerr := suite.TokenControl.CreateTokenFromPassword(&suite.CRUDMock, username, password, clientID, scopeName)
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scopeName)
 
 	//assert
-	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByID", clientID)
-	suite.CRUDMock.AssertCalled(suite.T(), "GetScopeByName", scopeName)
-	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetClientByID", mock.Anything, clientID)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetScopeByName", mock.Anything, scopeName)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", mock.Anything, username)
 	suite.PasswordHasherMock.AssertCalled(suite.T(), "ComparePasswords", mock.Anything, password)
-	suite.CRUDMock.AssertCalled(suite.T(), "SaveAccessToken", token)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveAccessToken", mock.Anything, token)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionLogin, models.AuditOutcomeSuccess)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionTokenIssue, models.AuditOutcomeSuccess)
 
 	suite.Require().NotNil(token)
 	suite.Equal(client, token.Client)
 	suite.Equal(scope, token.Scope)
 	suite.Equal(user, token.User)
 
-	AssertOAuthNoError(&suite.Suite, rerr)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WhereClientRequiresTLSClientAuth_WithMatchingCertificate_ReturnsOK() {
+	//arrange
+	username := "username"
+	password := "password"
+	clientID := uuid.New()
+	scopeName := "scope"
+
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "client", Organization: []string{"authserver"}},
+	}
+	client := &models.Client{
+		ID:                     clientID,
+		TLSClientAuthSubjectDN: "CN=client,O=authserver",
+	}
+
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(client, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(&models.Scope{ID: uuid.New()}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(&models.User{ID: uuid.New()}, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAccessToken", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, cert, scopeName)
+
+	//assert
+	suite.Require().NotNil(token)
+	suite.Equal(client, token.Client)
+
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func (suite *TokenControlTestSuite) TestCreateTokenFromPassword_WithErrorSavingAuditEvent_ReturnsInternalError() {
+	//arrange
+	username := "username"
+	password := "password"
+	clientID := uuid.New()
+	scope := "scope"
+
+	suite.CRUDMock.On("GetClientByID", mock.Anything, mock.Anything).Return(&models.Client{ID: clientID}, nil)
+	suite.CRUDMock.On("GetScopeByName", mock.Anything, mock.Anything).Return(&models.Scope{ID: uuid.New()}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(&models.User{ID: uuid.New()}, nil)
+	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAccessToken", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	token, rerr := suite.TokenControl.CreateTokenFromPassword(context.Background(), &suite.CRUDMock, username, password, clientID, nil, scope)
+
+	//assert
+	suite.Nil(token)
+	AssertInternalError(&suite.Suite, rerr)
 }
 
 func (suite *TokenControlTestSuite) TestDeleteToken_WithErrorDeletingAccessToken_ReturnsInternalError() {
 	//arrange
-	token := &models.AccessToken{}
+	token := models.CreateNewAccessToken(&models.User{ID: uuid.New()}, &models.Client{ID: uuid.New()}, &models.Scope{})
 
-	suite.CRUDMock.On("DeleteAccessToken", mock.Anything).Return(errors.New(""))
+	suite.CRUDMock.On("DeleteAccessToken", mock.Anything, mock.Anything).Return(errors.New(""))
 
 	//act
-	rerr := suite.TokenControl.DeleteToken(&suite.CRUDMock, token)
+	rerr := suite.TokenControl.DeleteToken(context.Background(), &suite.CRUDMock, token)
 
 	//assert
 	AssertInternalError(&suite.Suite, rerr)
@@ -229,27 +361,43 @@ func (suite *TokenControlTestSuite) TestDeleteToken_WithErrorDeletingAccessToken
 
 func (suite *TokenControlTestSuite) TestDeleteToken_WithValidRequest_ReturnsOK() {
 	//arrange
-	token := &models.AccessToken{}
+	token := models.CreateNewAccessToken(&models.User{ID: uuid.New()}, &models.Client{ID: uuid.New()}, &models.Scope{})
 
-	suite.CRUDMock.On("DeleteAccessToken", mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteAccessToken", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
 
 	//act
-	rerr := suite.TokenControl.DeleteToken(&suite.CRUDMock, token)
+	rerr := suite.TokenControl.DeleteToken(context.Background(), &suite.CRUDMock, token)
 
 	//assert
-	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAccessToken", token)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAccessToken", mock.Anything, token)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionTokenRevoke, models.AuditOutcomeSuccess)
 
 	AssertNoError(&suite.Suite, rerr)
 }
 
+func (suite *TokenControlTestSuite) TestDeleteToken_WithErrorSavingAuditEvent_ReturnsInternalError() {
+	//arrange
+	token := models.CreateNewAccessToken(&models.User{ID: uuid.New()}, &models.Client{ID: uuid.New()}, &models.Scope{})
+
+	suite.CRUDMock.On("DeleteAccessToken", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	rerr := suite.TokenControl.DeleteToken(context.Background(), &suite.CRUDMock, token)
+
+	//assert
+	AssertInternalError(&suite.Suite, rerr)
+}
+
 func (suite *TokenControlTestSuite) TestDeleteAllOtherUserTokens_WithErrorDeletingTokens_ReturnsInternalError() {
 	//arrange
-	token := &models.AccessToken{}
+	token := models.CreateNewAccessToken(&models.User{ID: uuid.New()}, &models.Client{ID: uuid.New()}, &models.Scope{})
 
-	suite.CRUDMock.On("DeleteAllOtherUserTokens", mock.Anything).Return(errors.New(""))
+	suite.CRUDMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything).Return(errors.New(""))
 
 	//act
-	rerr := suite.TokenControl.DeleteAllOtherUserTokens(&suite.CRUDMock, token)
+	rerr := suite.TokenControl.DeleteAllOtherUserTokens(context.Background(), &suite.CRUDMock, token)
 
 	//assert
 	AssertInternalError(&suite.Suite, rerr)
@@ -257,15 +405,17 @@ func (suite *TokenControlTestSuite) TestDeleteAllOtherUserTokens_WithErrorDeleti
 
 func (suite *TokenControlTestSuite) TestDeleteAllOtherUserTokens_WithValidRequest_ReturnsOK() {
 	//arrange
-	token := &models.AccessToken{}
+	token := models.CreateNewAccessToken(&models.User{ID: uuid.New()}, &models.Client{ID: uuid.New()}, &models.Scope{})
 
-	suite.CRUDMock.On("DeleteAllOtherUserTokens", mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
 
 	//act
-	rerr := suite.TokenControl.DeleteAllOtherUserTokens(&suite.CRUDMock, token)
+	rerr := suite.TokenControl.DeleteAllOtherUserTokens(context.Background(), &suite.CRUDMock, token)
 
 	//assert
-	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllOtherUserTokens", token)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteAllOtherUserTokens", mock.Anything, token)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionTokenRevoke, models.AuditOutcomeSuccess)
 
 	AssertNoError(&suite.Suite, rerr)
 }
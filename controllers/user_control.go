@@ -1,13 +1,16 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
-	"log"
 
 	"authserver/common"
 	requesterror "authserver/common/request_error"
 	passwordhelpers "authserver/controllers/password_helpers"
+	"authserver/logger"
 	"authserver/models"
+
+	"github.com/google/uuid"
 )
 
 // UserControl handles requests to "/user" endpoints
@@ -17,96 +20,218 @@ type UserControl struct {
 }
 
 // CreateUser creates a new user with the given username and password
-func (c UserControl) CreateUser(CRUD UserControllerCRUD, username string, password string) (*models.User, requesterror.RequestError) {
+func (c UserControl) CreateUser(ctx context.Context, CRUD UserControllerCRUD, username string, password string) (*models.User, error) {
 	//create the user model
 	user := models.CreateNewUser(username, nil)
 
 	//validate the username
-	verr := user.Validate()
-	if verr&models.ValidateUserEmptyUsername != 0 {
-		return nil, requesterror.ClientError("username cannot be empty")
-	} else if verr&models.ValidateUserUsernameTooLong != 0 {
-		return nil, requesterror.ClientError(fmt.Sprint("username cannot be longer than ", models.UserUsernameMaxLength, " characters"))
-	}
-
-	//validate username is unique
-	otherUser, err := CRUD.GetUserByUsername(username)
-	if err != nil {
-		log.Println(common.ChainError("error getting user by username", err))
-		return nil, requesterror.InternalError()
-	}
-	if otherUser != nil {
-		return nil, requesterror.ClientError("error creating user")
+	rerr := validateUsername(ctx, CRUD, user)
+	if rerr != nil {
+		return nil, rerr
 	}
 
 	//validate password meets criteria
 	vperr := c.PasswordCriteriaValidator.ValidatePasswordCriteria(password)
 	if vperr.Status != passwordhelpers.ValidatePasswordCriteriaValid {
-		log.Println(common.ChainError("error validating password criteria", vperr))
-		return nil, requesterror.ClientError("password does not meet minimum criteria")
+		logger.FromContext(ctx).With("error", vperr.Error()).Info("password does not meet criteria")
+		return nil, requesterror.InvalidFieldError("password", "password does not meet minimum criteria")
 	}
 
 	//hash the password
+	var err error
 	user.PasswordHash, err = c.PasswordHasher.HashPassword(password)
 	if err != nil {
-		log.Println(common.ChainError("error generating password hash", err))
+		logger.FromContext(ctx).Error(common.ChainError("error generating password hash", err))
 		return nil, requesterror.InternalError()
 	}
 
 	//save the user
-	err = CRUD.SaveUser(user)
+	err = CRUD.SaveUser(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving user", err))
+		return nil, requesterror.InternalError()
+	}
+
+	//record the user creation
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionUserCreate, models.AuditOutcomeSuccess, user.ID, user.ID, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return nil, requesterror.InternalError()
+	}
+
+	//notify webhooks of the user creation
+	err = saveUserOutboxEvent(ctx, CRUD, models.OutboxEventTypeUserCreated, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, requesterror.InternalError()
+	}
+
+	return user, nil
+}
+
+// GetUser gets the current state of the user from the database, since the given user may be a copy held by a cached token
+func (c UserControl) GetUser(ctx context.Context, CRUD UserControllerCRUD, user *models.User) (*models.User, error) {
+	current, err := CRUD.GetUserByID(ctx, user.ID)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error getting user by id", err))
+		return nil, requesterror.InternalError()
+	}
+	if current == nil {
+		return nil, requesterror.NotFoundError("user not found")
+	}
+
+	return current, nil
+}
+
+// UpdateUser updates the user's profile with the changes in the update
+func (c UserControl) UpdateUser(ctx context.Context, CRUD UserControllerCRUD, user *models.User, update models.UserUpdate) (*models.User, error) {
+	//get the current state of the user so the update doesn't overwrite other changes
+	user, rerr := c.GetUser(ctx, CRUD, user)
+	if rerr != nil {
+		return nil, rerr
+	}
+
+	//apply the changes
+	changed := false
+	if update.Username != nil && *update.Username != user.Username {
+		user.Username = *update.Username
+		changed = true
+
+		rerr = validateUsername(ctx, CRUD, user)
+		if rerr != nil {
+			return nil, rerr
+		}
+	}
+
+	if !changed {
+		return user, nil
+	}
+
+	//update the user
+	err := CRUD.UpdateUser(ctx, user)
 	if err != nil {
-		log.Println(common.ChainError("error saving user", err))
+		logger.FromContext(ctx).Error(common.ChainError("error updating user", err))
 		return nil, requesterror.InternalError()
 	}
 
-	return user, requesterror.NoError()
+	//record the user update
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionUserUpdate, models.AuditOutcomeSuccess, user.ID, user.ID, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return nil, requesterror.InternalError()
+	}
+
+	//notify webhooks of the user update
+	err = saveUserOutboxEvent(ctx, CRUD, models.OutboxEventTypeUserUpdated, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
+		return nil, requesterror.InternalError()
+	}
+
+	return user, nil
 }
 
 // DeleteUser deletes the user with the given id
-func (c UserControl) DeleteUser(CRUD UserControllerCRUD, user *models.User) requesterror.RequestError {
+func (c UserControl) DeleteUser(ctx context.Context, CRUD UserControllerCRUD, user *models.User) error {
 	//delete the user
-	err := CRUD.DeleteUser(user)
+	err := CRUD.DeleteUser(ctx, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error deleting user", err))
+		return requesterror.InternalError()
+	}
+
+	//record the user deletion
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionUserDelete, models.AuditOutcomeSuccess, user.ID, user.ID, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return requesterror.InternalError()
+	}
+
+	//notify webhooks of the user deletion
+	err = saveUserOutboxEvent(ctx, CRUD, models.OutboxEventTypeUserDeleted, user)
 	if err != nil {
-		log.Println(common.ChainError("error deleting user", err))
+		logger.FromContext(ctx).Error(err)
 		return requesterror.InternalError()
 	}
 
 	//return success
-	return requesterror.NoError()
+	return nil
 }
 
 // UpdateUserPassword updates the given user's password
-func (c UserControl) UpdateUserPassword(CRUD UserControllerCRUD, user *models.User, oldPassword string, newPassword string) requesterror.RequestError {
+func (c UserControl) UpdateUserPassword(ctx context.Context, CRUD UserControllerCRUD, user *models.User, oldPassword string, newPassword string) error {
+	//get the current state of the user so the old password is checked against the current hash, and the update doesn't overwrite other changes
+	user, rerr := c.GetUser(ctx, CRUD, user)
+	if rerr != nil {
+		return rerr
+	}
+
 	//validate old password
 	err := c.PasswordHasher.ComparePasswords(user.PasswordHash, oldPassword)
 	if err != nil {
-		log.Println(common.ChainError("error comparing password hashes", err))
-		return requesterror.ClientError("old password is invalid")
+		logger.FromContext(ctx).With("error", err.Error()).Info("old password does not match")
+		recordAuditFailure(ctx, newAuditEvent(ctx, models.AuditActionPasswordChange, models.AuditOutcomeFailure, user.ID, user.ID, uuid.Nil), "invalid_password")
+		return requesterror.InvalidFieldError("oldPassword", "old password is invalid")
 	}
 
 	//validate new password meets critera
 	verr := c.PasswordCriteriaValidator.ValidatePasswordCriteria(newPassword)
 	if verr.Status != passwordhelpers.ValidatePasswordCriteriaValid {
-		log.Println(common.ChainError("error validating password criteria", verr))
-		return requesterror.ClientError("password does not meet minimum criteria")
+		logger.FromContext(ctx).With("error", verr.Error()).Info("password does not meet criteria")
+		return requesterror.InvalidFieldError("newPassword", "password does not meet minimum criteria")
 	}
 
 	//hash the password
 	hash, err := c.PasswordHasher.HashPassword(newPassword)
 	if err != nil {
-		log.Println(common.ChainError("error generating password hash", err))
+		logger.FromContext(ctx).Error(common.ChainError("error generating password hash", err))
 		return requesterror.InternalError()
 	}
 
 	//update the user
 	user.PasswordHash = hash
-	err = CRUD.UpdateUser(user)
+	err = CRUD.UpdateUser(ctx, user)
 	if err != nil {
-		log.Println(common.ChainError("error updating user", err))
+		logger.FromContext(ctx).Error(common.ChainError("error updating user", err))
+		return requesterror.InternalError()
+	}
+
+	//record the password change
+	err = CRUD.SaveAuditEvent(ctx, newAuditEvent(ctx, models.AuditActionPasswordChange, models.AuditOutcomeSuccess, user.ID, user.ID, uuid.Nil))
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+		return requesterror.InternalError()
+	}
+
+	//notify webhooks of the password change
+	err = saveUserOutboxEvent(ctx, CRUD, models.OutboxEventTypeUserPasswordChanged, user)
+	if err != nil {
+		logger.FromContext(ctx).Error(err)
 		return requesterror.InternalError()
 	}
 
 	//return success
-	return requesterror.NoError()
+	return nil
+}
+
+// validateUsername validates the user's username is valid and not taken by another user
+func validateUsername(ctx context.Context, CRUD UserControllerCRUD, user *models.User) error {
+	verr := user.Validate()
+	if verr&models.ValidateUserEmptyUsername != 0 {
+		return requesterror.InvalidFieldError("username", "username cannot be empty")
+	} else if verr&models.ValidateUserUsernameTooLong != 0 {
+		return requesterror.InvalidFieldError("username", fmt.Sprint("username cannot be longer than ", models.UserUsernameMaxLength, " characters"))
+	}
+
+	//validate username is unique
+	otherUser, err := CRUD.GetUserByUsername(ctx, user.Username)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error getting user by username", err))
+		return requesterror.InternalError()
+	}
+	if otherUser != nil && otherUser.ID != user.ID {
+		return requesterror.ConflictError("username is already taken")
+	}
+
+	return nil
 }
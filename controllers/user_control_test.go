@@ -1,11 +1,14 @@
 package controllers_test
 
 import (
+	"authserver/audit"
+	requesterror "authserver/common/request_error"
 	"authserver/controllers"
 	passwordhelpers "authserver/controllers/password_helpers"
 	passwordhelpermocks "authserver/controllers/password_helpers/mocks"
 	databasemocks "authserver/database/mocks"
 	"authserver/models"
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -32,30 +35,31 @@ func (suite *UserControlTestSuite) SetupTest() {
 	}
 }
 
-func (suite *UserControlTestSuite) TestCreateUser_WithEmptyUsername_ReturnsClientError() {
+func (suite *UserControlTestSuite) TestCreateUser_WithEmptyUsername_ReturnsInvalidFieldError() {
 	//arrange
 	username := ""
 	password := "password"
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
 	suite.Nil(user)
-	AssertClientError(&suite.Suite, rerr, "username cannot be empty")
+	result := AssertRequestError(&suite.Suite, rerr, requesterror.CodeInvalidRequest, "username cannot be empty")
+	suite.Equal([]requesterror.FieldError{{Field: "username", Message: "username cannot be empty"}}, result.Fields)
 }
 
-func (suite *UserControlTestSuite) TestCreateUser_WithUsernameLongerThanMax_ReturnsClientError() {
+func (suite *UserControlTestSuite) TestCreateUser_WithUsernameLongerThanMax_ReturnsInvalidRequestError() {
 	//arrange
 	username := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" //31 chars
 	password := "password"
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
 	suite.Nil(user)
-	AssertClientError(&suite.Suite, rerr, "username cannot be longer", fmt.Sprint(models.UserUsernameMaxLength))
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeInvalidRequest, "username cannot be longer", fmt.Sprint(models.UserUsernameMaxLength))
 }
 
 func (suite *UserControlTestSuite) TestCreateUser_WithErrorGettingUserByUsername_ReturnsInternalError() {
@@ -63,45 +67,45 @@ func (suite *UserControlTestSuite) TestCreateUser_WithErrorGettingUserByUsername
 	username := "username"
 	password := "password"
 
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
 	suite.Nil(user)
 	AssertInternalError(&suite.Suite, rerr)
 }
 
-func (suite *UserControlTestSuite) TestCreateUser_WithNonUniqueUsername_ReturnsClientError() {
+func (suite *UserControlTestSuite) TestCreateUser_WithNonUniqueUsername_ReturnsConflictError() {
 	//arrange
 	username := "username"
 	password := "password"
 
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(&models.User{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(&models.User{}, nil)
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
 	suite.Nil(user)
-	AssertClientError(&suite.Suite, rerr, "error creating user")
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeConflict, "username is already taken")
 }
 
-func (suite *UserControlTestSuite) TestCreateUser_WherePasswordDoesNotMeetCriteria_ReturnsClientError() {
+func (suite *UserControlTestSuite) TestCreateUser_WherePasswordDoesNotMeetCriteria_ReturnsInvalidRequestError() {
 	//arrange
 	username := "username"
 	password := "password"
 
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(nil, nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaTooShort, ""))
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
 	suite.Nil(user)
-	AssertClientError(&suite.Suite, rerr, "password", "not", "minimum criteria")
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeInvalidRequest, "password", "not", "minimum criteria")
 }
 
 func (suite *UserControlTestSuite) TestCreateUser_WithErrorHashingNewPassword_ReturnsInternalError() {
@@ -109,12 +113,12 @@ func (suite *UserControlTestSuite) TestCreateUser_WithErrorHashingNewPassword_Re
 	username := "username"
 	password := "password"
 
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(nil, nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
 	suite.Nil(user)
@@ -126,13 +130,13 @@ func (suite *UserControlTestSuite) TestCreateUser_WithErrorCreatingUser_ReturnsI
 	username := "username"
 	password := "password"
 
-	suite.CRUDMock.On("GetUserByUsername", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, mock.Anything).Return(nil, nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, nil)
-	suite.CRUDMock.On("SaveUser", mock.Anything).Return(errors.New(""))
+	suite.CRUDMock.On("SaveUser", mock.Anything, mock.Anything).Return(errors.New(""))
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
 	suite.Nil(user)
@@ -146,20 +150,24 @@ func (suite *UserControlTestSuite) TestCreateUser_WithValidRequest_ReturnsOK() {
 
 	hash := []byte("password hash")
 
-	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything).Return(&models.AccessToken{}, nil)
-	suite.CRUDMock.On("GetUserByUsername", username).Return(nil, nil)
+	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything, mock.Anything).Return(&models.AccessToken{}, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, username).Return(nil, nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(hash, nil)
-	suite.CRUDMock.On("SaveUser", mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
 
 	//act
-	user, rerr := suite.UserControl.CreateUser(&suite.CRUDMock, username, password)
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
 
 	//assert
-	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", username)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetUserByUsername", mock.Anything, username)
 	suite.PasswordCriteriaValidatorMock.AssertCalled(suite.T(), "ValidatePasswordCriteria", password)
 	suite.PasswordHasherMock.AssertCalled(suite.T(), "HashPassword", password)
-	suite.CRUDMock.AssertCalled(suite.T(), "SaveUser", user)
+	suite.CRUDMock.AssertCalled(suite.T(), "SaveUser", mock.Anything, user)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionUserCreate, models.AuditOutcomeSuccess)
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserCreated, user)
 
 	suite.Require().NotNil(user)
 	suite.Equal(username, user.Username)
@@ -168,14 +176,193 @@ func (suite *UserControlTestSuite) TestCreateUser_WithValidRequest_ReturnsOK() {
 	AssertNoError(&suite.Suite, rerr)
 }
 
+func (suite *UserControlTestSuite) TestCreateUser_WithErrorSavingAuditEvent_ReturnsInternalError() {
+	//arrange
+	username := "username"
+	password := "password"
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, username).Return(nil, nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return([]byte("password hash"), nil)
+	suite.CRUDMock.On("SaveUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
+
+	//assert
+	suite.Nil(user)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *UserControlTestSuite) TestCreateUser_WithErrorSavingOutboxEvent_ReturnsInternalError() {
+	//arrange
+	username := "username"
+	password := "password"
+
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, username).Return(nil, nil)
+	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
+	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return([]byte("password hash"), nil)
+	suite.CRUDMock.On("SaveUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	user, rerr := suite.UserControl.CreateUser(context.Background(), &suite.CRUDMock, username, password)
+
+	//assert
+	suite.Nil(user)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *UserControlTestSuite) TestGetUser_WithErrorGettingUserByID_ReturnsInternalError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	user, rerr := suite.UserControl.GetUser(context.Background(), &suite.CRUDMock, models.CreateNewUser("username", nil))
+
+	//assert
+	suite.Nil(user)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *UserControlTestSuite) TestGetUser_WhereUserNotFound_ReturnsNotFoundError() {
+	//arrange
+	suite.CRUDMock.On("GetUserByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	user, rerr := suite.UserControl.GetUser(context.Background(), &suite.CRUDMock, models.CreateNewUser("username", nil))
+
+	//assert
+	suite.Nil(user)
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeNotFound, "user not found")
+}
+
+func (suite *UserControlTestSuite) TestGetUser_WithValidRequest_ReturnsCurrentUser() {
+	//arrange
+	user := models.CreateNewUser("old username", nil)
+	current := &models.User{ID: user.ID, Username: "new username"}
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(current, nil)
+
+	//act
+	result, rerr := suite.UserControl.GetUser(context.Background(), &suite.CRUDMock, user)
+
+	//assert
+	suite.Equal(current, result)
+	AssertNoError(&suite.Suite, rerr)
+}
+
+func (suite *UserControlTestSuite) TestUpdateUser_WithInvalidUsername_ReturnsInvalidFieldError() {
+	usernames := map[string]string{
+		"":                                     "username cannot be empty",
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": fmt.Sprint("username cannot be longer than ", models.UserUsernameMaxLength, " characters"),
+	}
+
+	for username, message := range usernames {
+		//arrange
+		user := models.CreateNewUser("username", nil)
+		suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+		//act
+		result, rerr := suite.UserControl.UpdateUser(context.Background(), &suite.CRUDMock, user, models.UserUpdate{Username: &username})
+
+		//assert
+		suite.Nil(result)
+		res := AssertRequestError(&suite.Suite, rerr, requesterror.CodeInvalidRequest, message)
+		suite.Equal([]requesterror.FieldError{{Field: "username", Message: message}}, res.Fields)
+	}
+
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *UserControlTestSuite) TestUpdateUser_WithUsernameOfAnotherUser_ReturnsConflictError() {
+	//arrange
+	user := models.CreateNewUser("username", nil)
+	username := "taken"
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, username).Return(models.CreateNewUser(username, nil), nil)
+
+	//act
+	result, rerr := suite.UserControl.UpdateUser(context.Background(), &suite.CRUDMock, user, models.UserUpdate{Username: &username})
+
+	//assert
+	suite.Nil(result)
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeConflict, "username is already taken")
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *UserControlTestSuite) TestUpdateUser_WithNoChanges_DoesNotUpdateUser() {
+	//arrange
+	user := models.CreateNewUser("username", nil)
+	username := user.Username
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+
+	//act
+	result, rerr := suite.UserControl.UpdateUser(context.Background(), &suite.CRUDMock, user, models.UserUpdate{Username: &username})
+
+	//assert
+	suite.Equal(user, result)
+	AssertNoError(&suite.Suite, rerr)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "SaveAuditEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *UserControlTestSuite) TestUpdateUser_WithErrorUpdatingUser_ReturnsInternalError() {
+	//arrange
+	user := models.CreateNewUser("username", nil)
+	username := "new username"
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, username).Return(nil, nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	result, rerr := suite.UserControl.UpdateUser(context.Background(), &suite.CRUDMock, user, models.UserUpdate{Username: &username})
+
+	//assert
+	suite.Nil(result)
+	AssertInternalError(&suite.Suite, rerr)
+}
+
+func (suite *UserControlTestSuite) TestUpdateUser_WithValidRequest_ReturnsUpdatedUser() {
+	//arrange
+	user := models.CreateNewUser("username", nil)
+	current := &models.User{ID: user.ID, Username: user.Username, PasswordHash: []byte("changed password hash")}
+	username := "new username"
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(current, nil)
+	suite.CRUDMock.On("GetUserByUsername", mock.Anything, username).Return(nil, nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	result, rerr := suite.UserControl.UpdateUser(context.Background(), &suite.CRUDMock, user, models.UserUpdate{Username: &username})
+
+	//assert
+	AssertNoError(&suite.Suite, rerr)
+	suite.Require().NotNil(result)
+	suite.Equal(username, result.Username)
+
+	//the current state of the user is updated, not the given copy
+	suite.Equal(current.PasswordHash, result.PasswordHash)
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, current)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionUserUpdate, models.AuditOutcomeSuccess)
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserUpdated, result)
+}
+
 func (suite *UserControlTestSuite) TestDeleteUser_WithErrorDeletingUser_ReturnsInternalError() {
 	//arrange
 	user := models.CreateNewUser("username", []byte("password hash"))
 
-	suite.CRUDMock.On("DeleteUser", mock.Anything).Return(errors.New(""))
+	suite.CRUDMock.On("DeleteUser", mock.Anything, mock.Anything).Return(errors.New(""))
 
 	//act
-	rerr := suite.UserControl.DeleteUser(&suite.CRUDMock, user)
+	rerr := suite.UserControl.DeleteUser(context.Background(), &suite.CRUDMock, user)
 
 	//assert
 	AssertInternalError(&suite.Suite, rerr)
@@ -185,46 +372,69 @@ func (suite *UserControlTestSuite) TestDeleteUser_WithValidRequest_ReturnsOK() {
 	//arrange
 	user := models.CreateNewUser("username", []byte("password hash"))
 
-	suite.CRUDMock.On("DeleteUser", mock.Anything).Return(nil)
+	suite.CRUDMock.On("DeleteUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
 
 	//act
-	rerr := suite.UserControl.DeleteUser(&suite.CRUDMock, user)
+	rerr := suite.UserControl.DeleteUser(context.Background(), &suite.CRUDMock, user)
 
 	//assert
-	suite.CRUDMock.AssertCalled(suite.T(), "DeleteUser", user)
+	suite.CRUDMock.AssertCalled(suite.T(), "DeleteUser", mock.Anything, user)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionUserDelete, models.AuditOutcomeSuccess)
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserDeleted, user)
 
 	AssertNoError(&suite.Suite, rerr)
 }
 
-func (suite *UserControlTestSuite) TestUpdateUserPassword_WhereOldPasswordIsInvalid_ReturnsClientError() {
+func (suite *UserControlTestSuite) TestUpdateUserPassword_WithErrorGettingUser_ReturnsInternalError() {
+	//arrange
+	user := models.CreateNewUser("username", nil)
+
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(nil, errors.New(""))
+
+	//act
+	rerr := suite.UserControl.UpdateUserPassword(context.Background(), &suite.CRUDMock, user, "old password", "new password")
+
+	//assert
+	AssertInternalError(&suite.Suite, rerr)
+	suite.CRUDMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func (suite *UserControlTestSuite) TestUpdateUserPassword_WhereOldPasswordIsInvalid_ReturnsInvalidRequestError() {
 	//arrange
 	oldPassword := "old password"
 	newPassword := "new password"
 	user := &models.User{}
 
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(errors.New(""))
 
+	trail := audit.CreateTrail("127.0.0.1", "user agent")
+
 	//act
-	rerr := suite.UserControl.UpdateUserPassword(&suite.CRUDMock, user, oldPassword, newPassword)
+	rerr := suite.UserControl.UpdateUserPassword(audit.NewContext(context.Background(), trail), &suite.CRUDMock, user, oldPassword, newPassword)
 
 	//assert
-	AssertClientError(&suite.Suite, rerr, "old password", "invalid")
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeInvalidRequest, "old password", "invalid")
+	AssertAuditFailureRecorded(&suite.Suite, trail.Failures(), models.AuditActionPasswordChange, "invalid_password")
 }
 
-func (suite *UserControlTestSuite) TestUpdateUserPassword_WhereNewPasswordDoesNotMeetCriteria_ReturnsClientError() {
+func (suite *UserControlTestSuite) TestUpdateUserPassword_WhereNewPasswordDoesNotMeetCriteria_ReturnsInvalidRequestError() {
 	//arrange
 	oldPassword := "old password"
 	newPassword := "new password"
 	user := &models.User{}
 
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaError(passwordhelpers.ValidatePasswordCriteriaTooShort, ""))
 
 	//act
-	rerr := suite.UserControl.UpdateUserPassword(&suite.CRUDMock, user, oldPassword, newPassword)
+	rerr := suite.UserControl.UpdateUserPassword(context.Background(), &suite.CRUDMock, user, oldPassword, newPassword)
 
 	//assert
-	AssertClientError(&suite.Suite, rerr, "password", "not", "minimum criteria")
+	AssertRequestError(&suite.Suite, rerr, requesterror.CodeInvalidRequest, "password", "not", "minimum criteria")
 }
 
 func (suite *UserControlTestSuite) TestUpdateUserPassword_WithErrorHashingNewPassword_ReturnsInternalError() {
@@ -233,12 +443,13 @@ func (suite *UserControlTestSuite) TestUpdateUserPassword_WithErrorHashingNewPas
 	newPassword := "new password"
 	user := &models.User{}
 
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, errors.New(""))
 
 	//act
-	rerr := suite.UserControl.UpdateUserPassword(&suite.CRUDMock, user, oldPassword, newPassword)
+	rerr := suite.UserControl.UpdateUserPassword(context.Background(), &suite.CRUDMock, user, oldPassword, newPassword)
 
 	//assert
 	AssertInternalError(&suite.Suite, rerr)
@@ -250,13 +461,14 @@ func (suite *UserControlTestSuite) TestUpdateUserPassword_WithErrorUpdatingUser_
 	newPassword := "new password"
 	user := &models.User{}
 
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(user, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(nil, nil)
-	suite.CRUDMock.On("UpdateUser", mock.Anything).Return(errors.New(""))
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(errors.New(""))
 
 	//act
-	rerr := suite.UserControl.UpdateUserPassword(&suite.CRUDMock, user, oldPassword, newPassword)
+	rerr := suite.UserControl.UpdateUserPassword(context.Background(), &suite.CRUDMock, user, oldPassword, newPassword)
 
 	//assert
 	AssertInternalError(&suite.Suite, rerr)
@@ -270,23 +482,31 @@ func (suite *UserControlTestSuite) TestUpdateUserPassword_WithValidRequest_Retur
 	oldPasswordHash := []byte("hashed old password")
 	newPasswordHash := []byte("hashed new password")
 
-	user := models.CreateNewUser("username", oldPasswordHash)
+	user := models.CreateNewUser("username", []byte("stale password hash"))
+	current := &models.User{ID: user.ID, Username: user.Username, PasswordHash: oldPasswordHash, IsDisabled: true}
 
+	suite.CRUDMock.On("GetUserByID", mock.Anything, user.ID).Return(current, nil)
 	suite.PasswordHasherMock.On("ComparePasswords", mock.Anything, mock.Anything).Return(nil)
 	suite.PasswordCriteriaValidatorMock.On("ValidatePasswordCriteria", mock.Anything).Return(passwordhelpers.CreateValidatePasswordCriteriaValid())
 	suite.PasswordHasherMock.On("HashPassword", mock.Anything).Return(newPasswordHash, nil)
-	suite.CRUDMock.On("UpdateUser", mock.Anything).Return(nil)
+	suite.CRUDMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.CRUDMock.On("SaveOutboxEvent", mock.Anything, mock.Anything).Return(nil)
 
 	//act
-	rerr := suite.UserControl.UpdateUserPassword(&suite.CRUDMock, user, oldPassword, newPassword)
+	rerr := suite.UserControl.UpdateUserPassword(context.Background(), &suite.CRUDMock, user, oldPassword, newPassword)
 
 	//assert
 	suite.PasswordHasherMock.AssertCalled(suite.T(), "ComparePasswords", oldPasswordHash, oldPassword)
 	suite.PasswordCriteriaValidatorMock.AssertCalled(suite.T(), "ValidatePasswordCriteria", newPassword)
 	suite.PasswordHasherMock.AssertCalled(suite.T(), "HashPassword", newPassword)
-	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", user)
+	AssertAuditEventSaved(&suite.Suite, &suite.CRUDMock, models.AuditActionPasswordChange, models.AuditOutcomeSuccess)
+	AssertOutboxEventSaved(&suite.Suite, &suite.CRUDMock, models.OutboxEventTypeUserPasswordChanged, current)
 
-	suite.Equal(newPasswordHash, user.PasswordHash)
+	//the current state of the user is updated, not the given copy
+	suite.CRUDMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, current)
+	suite.Equal(newPasswordHash, current.PasswordHash)
+	suite.True(current.IsDisabled)
 	AssertNoError(&suite.Suite, rerr)
 }
 
@@ -0,0 +1,58 @@
+package consistency
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type trackerContextKey struct{}
+type primaryContextKey struct{}
+
+// Tracker records whether a request has written to the database,
+// so databases with read replicas can route the request's later reads to the primary and it always reads its own writes.
+type Tracker struct {
+	written int32
+}
+
+// CreateTracker creates a new tracker for a request that has not written yet.
+func CreateTracker() *Tracker {
+	return &Tracker{}
+}
+
+// MarkWritten records that the request has written to the database.
+func (t *Tracker) MarkWritten() {
+	atomic.StoreInt32(&t.written, 1)
+}
+
+// HasWritten returns whether the request has written to the database.
+func (t *Tracker) HasWritten() bool {
+	return atomic.LoadInt32(&t.written) == 1
+}
+
+// NewContext returns a child of the context that carries the tracker.
+func NewContext(ctx context.Context, t *Tracker) context.Context {
+	return context.WithValue(ctx, trackerContextKey{}, t)
+}
+
+// FromContext returns the tracker carried by the context.
+// If the context has no tracker, such as outside of a request, a new tracker is returned.
+func FromContext(ctx context.Context) *Tracker {
+	t, ok := ctx.Value(trackerContextKey{}).(*Tracker)
+	if !ok {
+		return CreateTracker()
+	}
+
+	return t
+}
+
+// WithPrimary returns a child of the context whose reads are always routed to the primary,
+// such as reads that must never see stale data.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
+// RequiresPrimary returns whether the context's reads must be routed to the primary.
+func RequiresPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryContextKey{}).(bool)
+	return primary
+}
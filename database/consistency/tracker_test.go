@@ -0,0 +1,76 @@
+package consistency_test
+
+import (
+	"authserver/database/consistency"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TrackerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TrackerTestSuite) TestCreateTracker_HasNotWritten() {
+	//act
+	tracker := consistency.CreateTracker()
+
+	//assert
+	suite.False(tracker.HasWritten())
+}
+
+func (suite *TrackerTestSuite) TestMarkWritten_HasWritten() {
+	//arrange
+	tracker := consistency.CreateTracker()
+
+	//act
+	tracker.MarkWritten()
+
+	//assert
+	suite.True(tracker.HasWritten())
+}
+
+func (suite *TrackerTestSuite) TestFromContext_ReturnsTrackerCarriedByContext() {
+	//arrange
+	tracker := consistency.CreateTracker()
+	ctx := consistency.NewContext(context.Background(), tracker)
+
+	//act
+	result := consistency.FromContext(ctx)
+
+	//assert
+	suite.Same(tracker, result)
+}
+
+func (suite *TrackerTestSuite) TestFromContext_WhereContextHasNoTracker_ReturnsNewTracker() {
+	//act
+	result := consistency.FromContext(context.Background())
+
+	//assert
+	suite.Require().NotNil(result)
+	suite.False(result.HasWritten())
+}
+
+func (suite *TrackerTestSuite) TestRequiresPrimary_WithPrimaryContext_ReturnsTrue() {
+	//arrange
+	ctx := consistency.WithPrimary(context.Background())
+
+	//act
+	result := consistency.RequiresPrimary(ctx)
+
+	//assert
+	suite.True(result)
+}
+
+func (suite *TrackerTestSuite) TestRequiresPrimary_WithOtherContext_ReturnsFalse() {
+	//act
+	result := consistency.RequiresPrimary(context.Background())
+
+	//assert
+	suite.False(result)
+}
+
+func TestTrackerTestSuite(t *testing.T) {
+	suite.Run(t, &TrackerTestSuite{})
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"errors"
+)
+
+// Database error classes.
+const (
+	ErrorClassUnknown              = "unknown"
+	ErrorClassSerializationFailure = "serialization_failure"
+	ErrorClassDeadlock             = "deadlock"
+	ErrorClassLockTimeout          = "lock_timeout"
+	ErrorClassUniqueViolation      = "unique_violation"
+)
+
+// Error is an error returned by the database, classified so callers can handle it without knowing which driver produced it.
+type Error struct {
+	// Class is the class of the error. One of the ErrorClass constants.
+	Class string
+
+	// Err is the error returned by the driver.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Retryable returns whether the error was caused by contention with other transactions,
+// so retrying the transaction from the start may succeed.
+func (e *Error) Retryable() bool {
+	switch e.Class {
+	case ErrorClassSerializationFailure, ErrorClassDeadlock, ErrorClassLockTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// ClassOf returns the class of the database error in the error's chain, or ErrorClassUnknown if there is none.
+func ClassOf(err error) string {
+	var dbErr *Error
+	if !errors.As(err, &dbErr) {
+		return ErrorClassUnknown
+	}
+
+	return dbErr.Class
+}
+
+// IsRetryable returns whether the error's chain contains a database error that can be resolved by retrying the transaction.
+func IsRetryable(err error) bool {
+	var dbErr *Error
+	return errors.As(err, &dbErr) && dbErr.Retryable()
+}
@@ -0,0 +1,67 @@
+package database_test
+
+import (
+	"authserver/common"
+	"authserver/database"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ErrorsTestSuite) TestClassOf_WithChainedDatabaseError_ReturnsClass() {
+	//arrange
+	err := common.ChainError("error committing transaction", &database.Error{Class: database.ErrorClassDeadlock, Err: errors.New("deadlock detected")})
+
+	//act
+	class := database.ClassOf(err)
+
+	//assert
+	suite.Equal(database.ErrorClassDeadlock, class)
+	common.AssertError(&suite.Suite, err, "error committing transaction", "deadlock detected")
+}
+
+func (suite *ErrorsTestSuite) TestClassOf_WithoutDatabaseError_ReturnsUnknown() {
+	//act
+	class := database.ClassOf(common.ChainError("message", errors.New("")))
+
+	//assert
+	suite.Equal(database.ErrorClassUnknown, class)
+}
+
+func (suite *ErrorsTestSuite) TestIsRetryable_ReturnsWhetherErrorIsCausedByContention() {
+	classes := map[string]bool{
+		database.ErrorClassSerializationFailure: true,
+		database.ErrorClassDeadlock:             true,
+		database.ErrorClassLockTimeout:          true,
+		database.ErrorClassUniqueViolation:      false,
+		database.ErrorClassUnknown:              false,
+	}
+
+	for class, expected := range classes {
+		//arrange
+		err := common.ChainError("message", &database.Error{Class: class, Err: errors.New("")})
+
+		//act
+		result := database.IsRetryable(err)
+
+		//assert
+		suite.Equal(expected, result, class)
+	}
+}
+
+func (suite *ErrorsTestSuite) TestIsRetryable_WithoutDatabaseError_ReturnsFalse() {
+	//act
+	result := database.IsRetryable(errors.New(""))
+
+	//assert
+	suite.False(result)
+}
+
+func TestErrorsTestSuite(t *testing.T) {
+	suite.Run(t, &ErrorsTestSuite{})
+}
@@ -0,0 +1,150 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SaveAccessToken validates the access token model is valid and inserts it into the access tokens table.
+// Returns an error if the id is already taken or the token's user, client or scope does not exist, or any other errors.
+func (adapter *Adapter) SaveAccessToken(ctx context.Context, token *models.AccessToken) error {
+	verr := token.Validate()
+	if verr != models.ValidateAccessTokenValid {
+		return errors.New(fmt.Sprint("error validating access token model:", verr))
+	}
+
+	row := accessTokenRow{
+		ID:       token.ID,
+		UserID:   token.User.ID,
+		ClientID: token.Client.ID,
+		ScopeID:  token.Scope.ID,
+	}
+	err := adapter.executor.write(ctx, func(s *store) error {
+		if _, ok := s.accessTokens[row.ID]; ok {
+			return uniqueViolation("access_token_pk")
+		}
+		if _, ok := s.users[row.UserID]; !ok {
+			return foreignKeyViolation("access_token_user_fk")
+		}
+		if _, ok := s.clients[row.ClientID]; !ok {
+			return foreignKeyViolation("access_token_client_fk")
+		}
+		if _, ok := s.scopes[row.ScopeID]; !ok {
+			return foreignKeyViolation("access_token_scope_fk")
+		}
+
+		s.accessTokens[row.ID] = row
+		s.wroteRow(tableAccessToken, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save access token statement", err)
+	}
+
+	return nil
+}
+
+// GetAccessTokenByID gets the access token with the matching id, along with its user, client and scope.
+// Returns a copy of the token, or nil if it was not found. Also returns any errors.
+func (adapter *Adapter) GetAccessTokenByID(ctx context.Context, ID uuid.UUID) (*models.AccessToken, error) {
+	var token *models.AccessToken
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableAccessToken, ID)
+		row, ok := s.accessTokens[ID]
+		if !ok {
+			return
+		}
+
+		s.readRow(tableUser, row.UserID)
+		s.readRow(tableClient, row.ClientID)
+		s.readRow(tableScope, row.ScopeID)
+
+		user, userOk := s.users[row.UserID]
+		client, clientOk := s.clients[row.ClientID]
+		scope, scopeOk := s.scopes[row.ScopeID]
+		if !userOk || !clientOk || !scopeOk {
+			return
+		}
+
+		token = &models.AccessToken{
+			ID:     row.ID,
+			User:   newUser(user),
+			Client: &client,
+			Scope:  &scope,
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get access token by id query", err)
+	}
+
+	return token, nil
+}
+
+// DeleteAccessToken deletes the access token with the matching id.
+// Returns any errors.
+func (adapter *Adapter) DeleteAccessToken(ctx context.Context, token *models.AccessToken) error {
+	ID := token.ID
+	err := adapter.executor.write(ctx, func(s *store) error {
+		delete(s.accessTokens, ID)
+		s.wroteRow(tableAccessToken, ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing delete access token statement", err)
+	}
+
+	return nil
+}
+
+// DeleteAllOtherUserTokens deletes all the access tokens with the matching user id, and not the token id.
+// Returns any errors.
+func (adapter *Adapter) DeleteAllOtherUserTokens(ctx context.Context, token *models.AccessToken) error {
+	ID := token.ID
+	userID := token.User.ID
+	err := adapter.executor.write(ctx, func(s *store) error {
+		for tokenID, row := range s.accessTokens {
+			if row.UserID == userID && tokenID != ID {
+				delete(s.accessTokens, tokenID)
+				s.wroteRow(tableAccessToken, tokenID)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing delete all other user tokens statement", err)
+	}
+
+	return nil
+}
+
+// DeleteAllUserTokens deletes all the access tokens with the matching user id.
+// Returns any errors.
+func (adapter *Adapter) DeleteAllUserTokens(ctx context.Context, user *models.User) error {
+	userID := user.ID
+	err := adapter.executor.write(ctx, func(s *store) error {
+		for tokenID, row := range s.accessTokens {
+			if row.UserID == userID {
+				delete(s.accessTokens, tokenID)
+				s.wroteRow(tableAccessToken, tokenID)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing delete all user tokens statement", err)
+	}
+
+	return nil
+}
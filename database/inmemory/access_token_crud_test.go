@@ -0,0 +1,138 @@
+package inmemory_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AccessTokenCRUDTestSuite struct {
+	InMemoryTestSuite
+}
+
+func (suite *AccessTokenCRUDTestSuite) TestSaveAccessToken_WithMissingUser_ReturnsError() {
+	//arrange
+	token := models.CreateNewAccessToken(
+		models.CreateNewUser("username", []byte("password")),
+		models.CreateNewClient(),
+		models.CreateNewScope("name"),
+	)
+
+	err := suite.DB.SaveClient(context.Background(), token.Client)
+	suite.Require().NoError(err)
+
+	err = suite.DB.SaveScope(context.Background(), token.Scope)
+	suite.Require().NoError(err)
+
+	//act
+	err = suite.DB.SaveAccessToken(context.Background(), token)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "foreign key", "access_token_user_fk")
+}
+
+func (suite *AccessTokenCRUDTestSuite) TestGetAccessTokenByID_GetsTheAccessTokenWithItsFields() {
+	//arrange
+	token := models.CreateNewAccessToken(
+		models.CreateNewUser("username", []byte("password")),
+		models.CreateNewClient(),
+		models.CreateNewScope("name"),
+	)
+	suite.SaveAccessTokenAndFields(token)
+
+	//act
+	resultToken, err := suite.DB.GetAccessTokenByID(context.Background(), token.ID)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(token, resultToken)
+}
+
+func (suite *AccessTokenCRUDTestSuite) TestDeleteAllOtherUserTokens_DeletesOnlyTheUsersOtherTokens() {
+	//arrange
+	token1 := models.CreateNewAccessToken(
+		models.CreateNewUser("username", []byte("password")),
+		models.CreateNewClient(),
+		models.CreateNewScope("name"),
+	)
+	suite.SaveAccessTokenAndFields(token1)
+
+	token2 := models.CreateNewAccessToken(token1.User, token1.Client, token1.Scope)
+	err := suite.DB.SaveAccessToken(context.Background(), token2)
+	suite.Require().NoError(err)
+
+	token3 := models.CreateNewAccessToken(
+		models.CreateNewUser("username2", []byte("password")),
+		token1.Client,
+		token1.Scope,
+	)
+	suite.SaveUser(token3.User)
+	err = suite.DB.SaveAccessToken(context.Background(), token3)
+	suite.Require().NoError(err)
+
+	//act
+	err = suite.DB.DeleteAllOtherUserTokens(context.Background(), token1)
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultToken, err := suite.DB.GetAccessTokenByID(context.Background(), token1.ID)
+	suite.NoError(err)
+	suite.NotNil(resultToken)
+
+	resultToken, err = suite.DB.GetAccessTokenByID(context.Background(), token2.ID)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	resultToken, err = suite.DB.GetAccessTokenByID(context.Background(), token3.ID)
+	suite.NoError(err)
+	suite.NotNil(resultToken)
+}
+
+func (suite *AccessTokenCRUDTestSuite) TestDeleteAllUserTokens_DeletesOnlyTheUsersTokens() {
+	//arrange
+	token1 := models.CreateNewAccessToken(
+		models.CreateNewUser("username", []byte("password")),
+		models.CreateNewClient(),
+		models.CreateNewScope("name"),
+	)
+	suite.SaveAccessTokenAndFields(token1)
+
+	token2 := models.CreateNewAccessToken(token1.User, token1.Client, token1.Scope)
+	err := suite.DB.SaveAccessToken(context.Background(), token2)
+	suite.Require().NoError(err)
+
+	token3 := models.CreateNewAccessToken(
+		models.CreateNewUser("username2", []byte("password")),
+		token1.Client,
+		token1.Scope,
+	)
+	suite.SaveUser(token3.User)
+	err = suite.DB.SaveAccessToken(context.Background(), token3)
+	suite.Require().NoError(err)
+
+	//act
+	err = suite.DB.DeleteAllUserTokens(context.Background(), token1.User)
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultToken, err := suite.DB.GetAccessTokenByID(context.Background(), token1.ID)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	resultToken, err = suite.DB.GetAccessTokenByID(context.Background(), token2.ID)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+
+	resultToken, err = suite.DB.GetAccessTokenByID(context.Background(), token3.ID)
+	suite.NoError(err)
+	suite.NotNil(resultToken)
+}
+
+func TestAccessTokenCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &AccessTokenCRUDTestSuite{})
+}
@@ -0,0 +1,30 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+)
+
+// errNotOpen is returned when the database is used before its connection is opened or after it is closed.
+var errNotOpen = errors.New("database connection is not open")
+
+// errConcurrentUpdate is returned when a row a transaction read or wrote was changed by another transaction after its snapshot was taken.
+var errConcurrentUpdate = errors.New("could not serialize access due to concurrent update")
+
+// errTxDone is returned when a transaction is used after it has been committed or rolled back.
+var errTxDone = errors.New("transaction has already been committed or rolled back")
+
+// executor is an interface for running operations against a store.
+type executor interface {
+	// read runs the function with a store it must not modify, unless the context is done. Returns any errors.
+	read(ctx context.Context, f func(s *store)) error
+
+	// write runs the function with a store it may modify, unless the context is done. Returns any errors.
+	// The function must check for errors before making any changes, so a failed write leaves the store unchanged.
+	write(ctx context.Context, f func(s *store) error) error
+}
+
+// Adapter contains the CRUD operations common to the in-memory db and transaction structs.
+type Adapter struct {
+	executor executor
+}
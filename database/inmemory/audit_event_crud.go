@@ -0,0 +1,102 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// SaveAuditEvent validates the audit event model is valid and inserts it into the audit events table.
+// Returns any errors.
+func (adapter *Adapter) SaveAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	verr := event.Validate()
+	if verr != models.ValidateAuditEventValid {
+		return errors.New(fmt.Sprint("error validating audit event model:", verr))
+	}
+
+	row := *event
+	err := adapter.executor.write(ctx, func(s *store) error {
+		if _, ok := s.auditEvents[row.ID]; ok {
+			return uniqueViolation("audit_event_pk")
+		}
+
+		s.auditEvents[row.ID] = row
+		s.wroteRow(tableAuditEvent, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save audit event statement", err)
+	}
+
+	return nil
+}
+
+// GetAuditEvents gets the audit events that match the filter, newest first.
+// The filter's limit and offset are normalized before being used.
+// Returns copies of the events and any errors.
+func (adapter *Adapter) GetAuditEvents(ctx context.Context, filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	filter.Normalize()
+
+	events := []*models.AuditEvent{}
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableAuditEvent, nil)
+		for _, row := range s.auditEvents {
+			if matchesAuditEventFilter(row, filter) {
+				event := row
+				events = append(events, &event)
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get audit events query", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].Timestamp.Equal(events[j].Timestamp) {
+			return events[i].Timestamp.After(events[j].Timestamp)
+		}
+		return events[i].ID.String() < events[j].ID.String()
+	})
+
+	start, end := pageBounds(len(events), filter.Limit, filter.Offset)
+	return events[start:end], nil
+}
+
+func matchesAuditEventFilter(event models.AuditEvent, filter models.AuditEventFilter) bool {
+	if filter.Action != "" && event.Action != filter.Action {
+		return false
+	}
+	if filter.Outcome != "" && event.Outcome != filter.Outcome {
+		return false
+	}
+
+	ids := []struct {
+		filter uuid.UUID
+		value  uuid.UUID
+	}{
+		{filter.ActorID, event.ActorID},
+		{filter.TargetID, event.TargetID},
+		{filter.ClientID, event.ClientID},
+	}
+	for _, id := range ids {
+		if id.filter != uuid.Nil && id.value != id.filter {
+			return false
+		}
+	}
+
+	if !filter.Since.IsZero() && event.Timestamp.Before(filter.Since) {
+		return false
+	}
+	if !filter.Until.IsZero() && !event.Timestamp.Before(filter.Until) {
+		return false
+	}
+
+	return true
+}
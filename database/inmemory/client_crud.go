@@ -0,0 +1,55 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// SaveClient validates the client model is valid and inserts it into the clients table.
+// Returns an error if the id is already taken, or any other errors.
+func (adapter *Adapter) SaveClient(ctx context.Context, client *models.Client) error {
+	verr := client.Validate()
+	if verr != models.ValidateClientValid {
+		return errors.New(fmt.Sprint("error validating client model:", verr))
+	}
+
+	row := *client
+	err := adapter.executor.write(ctx, func(s *store) error {
+		if _, ok := s.clients[row.ID]; ok {
+			return uniqueViolation("client_pk")
+		}
+
+		s.clients[row.ID] = row
+		s.wroteRow(tableClient, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save client statement", err)
+	}
+
+	return nil
+}
+
+// GetClientByID gets the client with the matching id.
+// Returns a copy of the client, or nil if it was not found. Also returns any errors.
+func (adapter *Adapter) GetClientByID(ctx context.Context, ID uuid.UUID) (*models.Client, error) {
+	var client *models.Client
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableClient, ID)
+		if row, ok := s.clients[ID]; ok {
+			client = &row
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get client by id query", err)
+	}
+
+	return client, nil
+}
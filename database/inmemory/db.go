@@ -0,0 +1,174 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/config"
+	"authserver/logger"
+	"authserver/models"
+	"context"
+	"sync"
+)
+
+// DB is an in-memory implementation of the Database interface. Operations outside of a transaction are committed immediately.
+// Data is kept for the lifetime of the DB, including after its connection is closed.
+type DB struct {
+	Adapter
+
+	// Logger is a dependency for logging. Optional, the default logger is used if not set.
+	Logger logger.Logger
+
+	mutex     sync.RWMutex
+	open      bool
+	committed *store
+
+	// version is incremented by every commit that writes rows.
+	// versions holds the version of the last commit to write each row, and each table.
+	version  uint64
+	versions map[rowKey]uint64
+}
+
+// CreateDB creates a new, empty in-memory DB.
+func CreateDB() *DB {
+	db := &DB{
+		versions: map[rowKey]uint64{},
+	}
+	db.executor = dbExecutor{db: db}
+
+	return db
+}
+
+// OpenConnection opens the connection to the in-memory database.
+// The first time the connection is opened, the database is seeded with the rows the sql migrations add,
+// namely the "all" scope and this app's client. Returns any errors.
+func (db *DB) OpenConnection() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.committed == nil {
+		s := newStore()
+
+		scope := models.CreateNewScope("all")
+		s.scopes[scope.ID] = *scope
+
+		client := models.Client{ID: config.GetAppId()}
+		s.clients[client.ID] = client
+
+		db.committed = s
+	}
+
+	db.open = true
+
+	db.log().Info("database connection opened")
+	return nil
+}
+
+// CloseConnection closes the connection to the in-memory database. Its data is kept in case the connection is reopened.
+// Returns any errors.
+func (db *DB) CloseConnection() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.open = false
+
+	db.log().Info("database connection closed")
+	return nil
+}
+
+// Ping verifies the connection to the in-memory database is open.
+// Returns an error if it is not.
+func (db *DB) Ping() error {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if !db.open {
+		return common.ChainError("error pinging database", errNotOpen)
+	}
+
+	return nil
+}
+
+// log returns the db's logger, or the default logger if it has none.
+func (db *DB) log() logger.Logger {
+	if db.Logger == nil {
+		return logger.Default()
+	}
+
+	return db.Logger
+}
+
+// recordWrites increments the db's version and sets it as the version of the written rows and their tables.
+// The caller must hold the db's lock.
+func (db *DB) recordWrites(rows rowSet) {
+	if len(rows) == 0 {
+		return
+	}
+
+	db.version++
+	for row := range rows {
+		db.versions[row] = db.version
+		db.versions[rowKey{table: row.table}] = db.version
+	}
+}
+
+// writtenSince returns true if any of the rows was written by a commit after the given version.
+// The caller must hold the db's lock.
+func (db *DB) writtenSince(version uint64, rows rowSet) bool {
+	for row := range rows {
+		if db.versions[row] > version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dbExecutor runs operations directly against the db's committed store.
+type dbExecutor struct {
+	db *DB
+}
+
+func (e dbExecutor) read(ctx context.Context, f func(s *store)) error {
+	e.db.mutex.RLock()
+	defer e.db.mutex.RUnlock()
+
+	if !e.db.open {
+		return errNotOpen
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	f(e.db.committed)
+	return nil
+}
+
+func (e dbExecutor) write(ctx context.Context, f func(s *store) error) error {
+	e.db.mutex.Lock()
+	defer e.db.mutex.Unlock()
+
+	if !e.db.open {
+		return errNotOpen
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	//record the rows the write changes so transactions that used them fail to commit
+	s := e.db.committed
+	s.writes = rowSet{}
+	defer func() {
+		s.writes = nil
+	}()
+
+	err = f(s)
+	if err != nil {
+		return err
+	}
+
+	e.db.recordWrites(s.writes)
+	return nil
+}
@@ -0,0 +1,102 @@
+package inmemory_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DBTestSuite struct {
+	InMemoryTestSuite
+}
+
+func (suite *DBTestSuite) TestOpenConnection_SeedsAllScopeAndAppClient() {
+	//act
+	scope, scopeErr := suite.DB.GetScopeByName(context.Background(), "all")
+	client, clientErr := suite.DB.GetClientByID(context.Background(), suite.AppID)
+
+	//assert
+	suite.NoError(scopeErr)
+	suite.NotNil(scope)
+
+	suite.NoError(clientErr)
+	suite.NotNil(client)
+}
+
+func (suite *DBTestSuite) TestPing_WithOpenConnection_ReturnsNoError() {
+	//act
+	err := suite.DB.Ping()
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *DBTestSuite) TestPing_WithClosedConnection_ReturnsError() {
+	//arrange
+	suite.DB.CloseConnection()
+
+	//act
+	err := suite.DB.Ping()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "not open")
+}
+
+func (suite *DBTestSuite) TestOperations_WithClosedConnection_ReturnError() {
+	//arrange
+	suite.DB.CloseConnection()
+
+	//act
+	_, getErr := suite.DB.GetScopeByName(context.Background(), "all")
+	saveErr := suite.DB.SaveUser(context.Background(), models.CreateNewUser("username", []byte("password")))
+	_, txErr := suite.TransactionFactory.CreateTransaction(context.Background())
+
+	//assert
+	common.AssertError(&suite.Suite, getErr, "not open")
+	common.AssertError(&suite.Suite, saveErr, "not open")
+	common.AssertError(&suite.Suite, txErr, "not open")
+}
+
+func (suite *DBTestSuite) TestCloseConnection_KeepsDataForWhenConnectionIsReopened() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	//act
+	suite.DB.CloseConnection()
+	err := suite.DB.OpenConnection()
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Equal(user, resultUser)
+}
+
+func (suite *DBTestSuite) TestReturnedModels_AreCopies() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	//act
+	user.Username = "changed"
+	user.PasswordHash[0] = 'X'
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.Require().NoError(err)
+	resultUser.PasswordHash[1] = 'Y'
+
+	//assert
+	resultUser, err = suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.Require().NoError(err)
+	suite.Equal("username", resultUser.Username)
+	suite.Equal([]byte("password"), resultUser.PasswordHash)
+}
+
+func TestDBTestSuite(t *testing.T) {
+	suite.Run(t, &DBTestSuite{})
+}
@@ -0,0 +1,56 @@
+package inmemory_test
+
+import (
+	"authserver/database/inmemory"
+	"authserver/models"
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+)
+
+type InMemoryTestSuite struct {
+	suite.Suite
+	AppID              uuid.UUID
+	DB                 *inmemory.DB
+	TransactionFactory inmemory.TransactionFactory
+}
+
+func (suite *InMemoryTestSuite) SetupTest() {
+	suite.AppID = uuid.New()
+	viper.Set("app_id", suite.AppID.String())
+
+	suite.DB = inmemory.CreateDB()
+	suite.TransactionFactory = inmemory.TransactionFactory{
+		DB: suite.DB,
+	}
+
+	err := suite.DB.OpenConnection()
+	suite.Require().NoError(err)
+}
+
+func (suite *InMemoryTestSuite) CreateTransaction() *inmemory.Transaction {
+	tx, err := suite.TransactionFactory.CreateTransaction(context.Background())
+	suite.Require().NoError(err)
+
+	return tx.(*inmemory.Transaction)
+}
+
+func (suite *InMemoryTestSuite) SaveUser(user *models.User) {
+	err := suite.DB.SaveUser(context.Background(), user)
+	suite.Require().NoError(err)
+}
+
+func (suite *InMemoryTestSuite) SaveAccessTokenAndFields(token *models.AccessToken) {
+	suite.SaveUser(token.User)
+
+	err := suite.DB.SaveClient(context.Background(), token.Client)
+	suite.Require().NoError(err)
+
+	err = suite.DB.SaveScope(context.Background(), token.Scope)
+	suite.Require().NoError(err)
+
+	err = suite.DB.SaveAccessToken(context.Background(), token)
+	suite.Require().NoError(err)
+}
@@ -0,0 +1,97 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Setup does nothing, since the in-memory migrations table always exists.
+func (adapter *Adapter) Setup() error {
+	return nil
+}
+
+// CreateMigration validates the given timestamp and inserts it into the migrations table.
+// Returns any errors.
+func (adapter *Adapter) CreateMigration(timestamp string) error {
+	//create and validate migration model
+	migration := models.CreateNewMigration(timestamp)
+	verr := migration.Validate()
+	if verr != models.ValidateMigrationValid {
+		return errors.New(fmt.Sprint("error validating migration model:", verr))
+	}
+
+	row := *migration
+	err := adapter.executor.write(context.Background(), func(s *store) error {
+		if _, ok := s.migrations[row.Timestamp]; ok {
+			return uniqueViolation("migration_pk")
+		}
+
+		s.migrations[row.Timestamp] = row
+		s.wroteRow(tableMigration, row.Timestamp)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save migration statment", err)
+	}
+
+	return nil
+}
+
+// GetMigrationByTimestamp gets the migration with the matching timestamp.
+// Returns a copy of the migration, or nil if it was not found. Also returns any errors.
+func (adapter *Adapter) GetMigrationByTimestamp(timestamp string) (*models.Migration, error) {
+	var migration *models.Migration
+	err := adapter.executor.read(context.Background(), func(s *store) {
+		s.readRow(tableMigration, timestamp)
+		if row, ok := s.migrations[timestamp]; ok {
+			migration = &row
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get migration by timestamp query", err)
+	}
+
+	return migration, nil
+}
+
+// GetLatestTimestamp returns the latest timestamp of all migrations.
+// If there are no migrations, hasLatest will be false, else it will be true.
+// Returns any errors.
+func (adapter *Adapter) GetLatestTimestamp() (timestamp string, hasLatest bool, err error) {
+	err = adapter.executor.read(context.Background(), func(s *store) {
+		s.readRow(tableMigration, nil)
+		for t := range s.migrations {
+			if t > timestamp {
+				timestamp = t
+			}
+		}
+		hasLatest = len(s.migrations) > 0
+	})
+
+	if err != nil {
+		return "", false, common.ChainError("error executing get latest timestamp query", err)
+	}
+
+	return timestamp, hasLatest, nil
+}
+
+// DeleteMigrationByTimestamp deletes the migration with the matching timestamp.
+// Returns any errors.
+func (adapter *Adapter) DeleteMigrationByTimestamp(timestamp string) error {
+	err := adapter.executor.write(context.Background(), func(s *store) error {
+		delete(s.migrations, timestamp)
+		s.wroteRow(tableMigration, timestamp)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing delete migration by timestamp statement", err)
+	}
+
+	return nil
+}
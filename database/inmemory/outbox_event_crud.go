@@ -0,0 +1,132 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SaveOutboxEvent validates the outbox event model is valid and inserts it into the outbox events table.
+// Returns any errors.
+func (adapter *Adapter) SaveOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	verr := event.Validate()
+	if verr != models.ValidateOutboxEventValid {
+		return errors.New(fmt.Sprint("error validating outbox event model:", verr))
+	}
+
+	row := *event
+	err := adapter.executor.write(ctx, func(s *store) error {
+		if _, ok := s.outboxEvents[row.ID]; ok {
+			return uniqueViolation("outbox_event_pk")
+		}
+
+		s.outboxEvents[row.ID] = row
+		s.wroteRow(tableOutboxEvent, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save outbox event statement", err)
+	}
+
+	return nil
+}
+
+// GetPendingOutboxEvents gets up to limit outbox events that are undelivered and due for an attempt at the given time, earliest due first.
+// Unlike the sql adapter, the events are not locked, so of concurrent dispatchers that claim the same events, only the first can commit.
+// Returns copies of the events and any errors.
+func (adapter *Adapter) GetPendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*models.OutboxEvent, error) {
+	events := []*models.OutboxEvent{}
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableOutboxEvent, nil)
+		for _, row := range s.outboxEvents {
+			if row.DeliveredAt.IsZero() && !row.DeadLettered && !row.NextAttemptAt.After(now) {
+				event := row
+				events = append(events, &event)
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get pending outbox events query", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].NextAttemptAt.Equal(events[j].NextAttemptAt) {
+			return events[i].NextAttemptAt.Before(events[j].NextAttemptAt)
+		}
+		return events[i].ID.String() < events[j].ID.String()
+	})
+
+	start, end := pageBounds(len(events), limit, 0)
+	return events[start:end], nil
+}
+
+// GetDeadLetteredOutboxEvents gets the dead lettered outbox events, newest first.
+// The filter's limit and offset are normalized before being used.
+// Returns copies of the events and any errors.
+func (adapter *Adapter) GetDeadLetteredOutboxEvents(ctx context.Context, filter models.OutboxEventFilter) ([]*models.OutboxEvent, error) {
+	filter.Normalize()
+
+	events := []*models.OutboxEvent{}
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableOutboxEvent, nil)
+		for _, row := range s.outboxEvents {
+			if row.DeadLettered {
+				event := row
+				events = append(events, &event)
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get dead lettered outbox events query", err)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.After(events[j].CreatedAt)
+		}
+		return events[i].ID.String() < events[j].ID.String()
+	})
+
+	start, end := pageBounds(len(events), filter.Limit, filter.Offset)
+	return events[start:end], nil
+}
+
+// UpdateOutboxEvent validates the outbox event model is valid and updates the delivery state of the outbox event with the matching id.
+// Does nothing if no event has the id. Returns any errors.
+func (adapter *Adapter) UpdateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	verr := event.Validate()
+	if verr != models.ValidateOutboxEventValid {
+		return errors.New(fmt.Sprint("error validating outbox event model:", verr))
+	}
+
+	update := *event
+	err := adapter.executor.write(ctx, func(s *store) error {
+		row, ok := s.outboxEvents[update.ID]
+		if !ok {
+			return nil
+		}
+
+		row.Attempts = update.Attempts
+		row.NextAttemptAt = update.NextAttemptAt
+		row.LastError = update.LastError
+		row.DeliveredAt = update.DeliveredAt
+		row.DeadLettered = update.DeadLettered
+
+		s.outboxEvents[row.ID] = row
+		s.wroteRow(tableOutboxEvent, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing update outbox event statement", err)
+	}
+
+	return nil
+}
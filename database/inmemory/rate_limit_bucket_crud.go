@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetRateLimitBucketByKey gets the rate limit bucket with the matching key.
+// Unlike the sql adapter, the bucket is not locked, so of concurrent transactions that take its tokens, only the first can commit.
+// Returns a copy of the bucket, or nil if it was not found. Also returns any errors.
+func (adapter *Adapter) GetRateLimitBucketByKey(ctx context.Context, key string) (*models.RateLimitBucket, error) {
+	var bucket *models.RateLimitBucket
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableRateLimitBucket, key)
+		if row, ok := s.rateLimitBuckets[key]; ok {
+			bucket = &row
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get rate limit bucket by key query", err)
+	}
+
+	return bucket, nil
+}
+
+// SaveRateLimitBucket validates the rate limit bucket model is valid and saves it in the rate limit buckets table, replacing any bucket with the same key.
+// Returns any errors.
+func (adapter *Adapter) SaveRateLimitBucket(ctx context.Context, bucket *models.RateLimitBucket) error {
+	verr := bucket.Validate()
+	if verr != models.ValidateRateLimitBucketValid {
+		return errors.New(fmt.Sprint("error validating rate limit bucket model:", verr))
+	}
+
+	row := *bucket
+	err := adapter.executor.write(ctx, func(s *store) error {
+		s.rateLimitBuckets[row.Key] = row
+		s.wroteRow(tableRateLimitBucket, row.Key)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save rate limit bucket statement", err)
+	}
+
+	return nil
+}
+
+// DeleteRateLimitBucketsUpdatedBefore deletes the rate limit buckets last updated before the given time.
+// Returns any errors.
+func (adapter *Adapter) DeleteRateLimitBucketsUpdatedBefore(ctx context.Context, before time.Time) error {
+	err := adapter.executor.write(ctx, func(s *store) error {
+		for key, row := range s.rateLimitBuckets {
+			if row.UpdatedAt.Before(before) {
+				delete(s.rateLimitBuckets, key)
+				s.wroteRow(tableRateLimitBucket, key)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing delete rate limit buckets updated before statement", err)
+	}
+
+	return nil
+}
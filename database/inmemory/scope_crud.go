@@ -0,0 +1,68 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SaveScope validates the scope model is valid and inserts it into the scopes table.
+// Returns an error if the id or name is already taken, or any other errors.
+func (adapter *Adapter) SaveScope(ctx context.Context, scope *models.Scope) error {
+	verr := scope.Validate()
+	if verr != models.ValidateScopeValid {
+		return errors.New(fmt.Sprint("error validating scope model:", verr))
+	}
+
+	row := *scope
+	err := adapter.executor.write(ctx, func(s *store) error {
+		if _, ok := s.scopes[row.ID]; ok {
+			return uniqueViolation("scope_pk")
+		}
+		if findScopeByName(s, row.Name) != nil {
+			return uniqueViolation("scope_name_un")
+		}
+
+		s.scopes[row.ID] = row
+		s.wroteRow(tableScope, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save scope statement", err)
+	}
+
+	return nil
+}
+
+// GetScopeByName gets the scope with the matching name.
+// Returns a copy of the scope, or nil if it was not found. Also returns any errors.
+func (adapter *Adapter) GetScopeByName(ctx context.Context, name string) (*models.Scope, error) {
+	var scope *models.Scope
+	err := adapter.executor.read(ctx, func(s *store) {
+		scope = findScopeByName(s, name)
+		if scope != nil {
+			s.readRow(tableScope, scope.ID)
+		} else {
+			s.readRow(tableScope, nil)
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get scope by name query", err)
+	}
+
+	return scope, nil
+}
+
+func findScopeByName(s *store, name string) *models.Scope {
+	for _, row := range s.scopes {
+		if row.Name == name {
+			return &row
+		}
+	}
+
+	return nil
+}
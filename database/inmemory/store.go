@@ -0,0 +1,140 @@
+package inmemory
+
+import (
+	"authserver/models"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// accessTokenRow is how an access token is stored. Like the access_token table, it references its user, client and scope by id.
+type accessTokenRow struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	ClientID uuid.UUID
+	ScopeID  uuid.UUID
+}
+
+// Names of the tables, used to identify the rows read and written.
+const (
+	tableMigration       = "migration"
+	tableUser            = "user"
+	tableClient          = "client"
+	tableScope           = "scope"
+	tableAccessToken     = "access_token"
+	tableAuditEvent      = "audit_event"
+	tableOutboxEvent     = "outbox_event"
+	tableRateLimitBucket = "rate_limit_bucket"
+)
+
+// rowKey identifies a row by its table and primary key. A nil key identifies the whole table.
+type rowKey struct {
+	table string
+	key   interface{}
+}
+
+// rowSet is a set of rows.
+type rowSet map[rowKey]struct{}
+
+// store holds the rows of every table. Rows are stored by value so changes to the models passed in or returned do not affect it.
+type store struct {
+	migrations   map[string]models.Migration
+	users        map[uuid.UUID]models.User
+	clients      map[uuid.UUID]models.Client
+	scopes       map[uuid.UUID]models.Scope
+	accessTokens map[uuid.UUID]accessTokenRow
+	auditEvents  map[uuid.UUID]models.AuditEvent
+	outboxEvents map[uuid.UUID]models.OutboxEvent
+
+	rateLimitBuckets map[string]models.RateLimitBucket
+
+	// reads and writes record the rows operations read and write. They are nil if the store doesn't record them.
+	reads  rowSet
+	writes rowSet
+}
+
+func newStore() *store {
+	return &store{
+		migrations:   map[string]models.Migration{},
+		users:        map[uuid.UUID]models.User{},
+		clients:      map[uuid.UUID]models.Client{},
+		scopes:       map[uuid.UUID]models.Scope{},
+		accessTokens: map[uuid.UUID]accessTokenRow{},
+		auditEvents:  map[uuid.UUID]models.AuditEvent{},
+		outboxEvents: map[uuid.UUID]models.OutboxEvent{},
+
+		rateLimitBuckets: map[string]models.RateLimitBucket{},
+	}
+}
+
+// clone creates a copy of the store. Rows are never modified in place, so they can be shared between the copies.
+// The rows read and written are not copied.
+func (s *store) clone() *store {
+	c := newStore()
+
+	for k, v := range s.migrations {
+		c.migrations[k] = v
+	}
+	for k, v := range s.users {
+		c.users[k] = v
+	}
+	for k, v := range s.clients {
+		c.clients[k] = v
+	}
+	for k, v := range s.scopes {
+		c.scopes[k] = v
+	}
+	for k, v := range s.accessTokens {
+		c.accessTokens[k] = v
+	}
+	for k, v := range s.auditEvents {
+		c.auditEvents[k] = v
+	}
+	for k, v := range s.outboxEvents {
+		c.outboxEvents[k] = v
+	}
+	for k, v := range s.rateLimitBuckets {
+		c.rateLimitBuckets[k] = v
+	}
+
+	return c
+}
+
+// readRow records that the row with the key was read. A nil key records that the whole table was scanned.
+func (s *store) readRow(table string, key interface{}) {
+	if s.reads != nil {
+		s.reads[rowKey{table: table, key: key}] = struct{}{}
+	}
+}
+
+// wroteRow records that the row with the key was inserted, updated or deleted.
+func (s *store) wroteRow(table string, key interface{}) {
+	if s.writes != nil {
+		s.writes[rowKey{table: table, key: key}] = struct{}{}
+	}
+}
+
+// uniqueViolation creates the error returned when a row would violate a unique or primary key constraint.
+func uniqueViolation(constraint string) error {
+	return errors.New("duplicate key value violates unique constraint \"" + constraint + "\"")
+}
+
+// foreignKeyViolation creates the error returned when a row references a row that does not exist.
+func foreignKeyViolation(constraint string) error {
+	return errors.New("insert or update violates foreign key constraint \"" + constraint + "\"")
+}
+
+// pageBounds returns the start and end indices of the page of a slice with the given length.
+func pageBounds(length int, limit int, offset int) (int, int) {
+	start := offset
+	if start > length {
+		start = length
+	}
+
+	end := start + limit
+	if end > length {
+		end = length
+	}
+
+	return start, end
+}
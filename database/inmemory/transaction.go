@@ -0,0 +1,198 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/database"
+	"authserver/logger"
+	"authserver/metrics"
+	"context"
+	"sync"
+)
+
+// Transaction is an in-memory implementation of the Transaction interface.
+// It reads and writes a snapshot of the database taken when it was created, so its writes are isolated from other transactions until it is committed.
+// Committing replays its writes against the latest committed data, checking constraints again,
+// so a write that conflicts with a transaction committed in the meantime fails the commit.
+// A transaction with writes also fails to commit if a row it read or wrote was changed after its snapshot was taken.
+// Like a sql transaction, it cannot be committed once the context it was created with is done.
+type Transaction struct {
+	Adapter
+
+	ctx     context.Context
+	db      *DB
+	log     logger.Logger
+	metrics metrics.Recorder
+
+	mutex    sync.Mutex
+	done     bool
+	snapshot *store
+	version  uint64
+	writes   []func(s *store) error
+}
+
+// TransactionFactory is an in-memory implementation of the TransactionFactory interface.
+type TransactionFactory struct {
+	// DB is the in-memory db instance to create transactions for.
+	DB *DB
+
+	// MetricsRecorder is a dependency for recording transaction outcomes. Optional.
+	MetricsRecorder metrics.Recorder
+}
+
+// CreateTransaction creates a new in-memory transaction. Returns any errors.
+// The transaction logs using the logger carried by the context, and cannot be committed once the context is done.
+func (f TransactionFactory) CreateTransaction(ctx context.Context) (database.Transaction, error) {
+	f.DB.mutex.RLock()
+	defer f.DB.mutex.RUnlock()
+
+	if !f.DB.open {
+		return nil, common.ChainError("error beginning transaction", errNotOpen)
+	}
+
+	tx := &Transaction{
+		ctx:      ctx,
+		db:       f.DB,
+		log:      logger.FromContext(ctx),
+		metrics:  f.MetricsRecorder,
+		snapshot: f.DB.committed.clone(),
+		version:  f.DB.version,
+	}
+	tx.snapshot.reads = rowSet{}
+	tx.executor = txExecutor{tx: tx}
+
+	tx.log.Debug("transaction started")
+	return tx, nil
+}
+
+// CommitTransaction applies the transaction's writes to the db.
+// If any write fails against the latest committed data, or a row the transaction read or wrote was changed after its snapshot was taken,
+// none of them are applied. Such a failure is classified as a serialization failure.
+// Returns any errors.
+func (tx *Transaction) CommitTransaction() error {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		tx.observeTransaction(metrics.TransactionCommitError)
+		return errTxDone
+	}
+	tx.done = true
+
+	err := tx.ctx.Err()
+	if err != nil {
+		tx.observeTransaction(metrics.TransactionCommitError)
+		return common.ChainError("error committing transaction", err)
+	}
+
+	tx.db.mutex.Lock()
+	defer tx.db.mutex.Unlock()
+
+	if !tx.db.open {
+		tx.observeTransaction(metrics.TransactionCommitError)
+		return errNotOpen
+	}
+
+	//replay the writes against a copy of the latest data so nothing is applied if one fails
+	s := tx.db.committed.clone()
+	s.writes = rowSet{}
+	for _, write := range tx.writes {
+		err := write(s)
+		if err != nil {
+			tx.observeTransaction(metrics.TransactionCommitError)
+			return serializationFailure(err)
+		}
+	}
+
+	//the writes may depend on what the transaction read, so neither can have changed since the snapshot
+	if len(tx.writes) > 0 && (tx.db.writtenSince(tx.version, tx.snapshot.reads) || tx.db.writtenSince(tx.version, s.writes)) {
+		tx.observeTransaction(metrics.TransactionCommitError)
+		return serializationFailure(errConcurrentUpdate)
+	}
+
+	tx.db.recordWrites(s.writes)
+	s.writes = nil
+	tx.db.committed = s
+
+	tx.log.Debug("transaction committed")
+	tx.observeTransaction(metrics.TransactionCommit)
+	return nil
+}
+
+// serializationFailure creates the error returned when the transaction can't be committed because of a concurrent transaction.
+func serializationFailure(err error) error {
+	return common.ChainError("error committing transaction", &database.Error{
+		Class: database.ErrorClassSerializationFailure,
+		Err:   err,
+	})
+}
+
+// RetryableError always returns nil, since the transaction's operations only fail because of contention when it is committed.
+func (tx *Transaction) RetryableError() error {
+	return nil
+}
+
+// RollbackTransaction discards the transaction's writes. Does nothing if the transaction has already been committed or rolled back.
+func (tx *Transaction) RollbackTransaction() {
+	tx.mutex.Lock()
+	defer tx.mutex.Unlock()
+
+	if tx.done {
+		return
+	}
+	tx.done = true
+	tx.writes = nil
+
+	tx.log.Debug("transaction rolled back")
+	tx.observeTransaction(metrics.TransactionRollback)
+}
+
+// observeTransaction records the outcome of the transaction if it has a metrics recorder.
+func (tx *Transaction) observeTransaction(outcome string) {
+	if tx.metrics != nil {
+		tx.metrics.ObserveTransaction(outcome)
+	}
+}
+
+// txExecutor runs operations against the transaction's snapshot, recording writes so they can be replayed on commit.
+type txExecutor struct {
+	tx *Transaction
+}
+
+func (e txExecutor) read(ctx context.Context, f func(s *store)) error {
+	e.tx.mutex.Lock()
+	defer e.tx.mutex.Unlock()
+
+	if e.tx.done {
+		return errTxDone
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	f(e.tx.snapshot)
+	return nil
+}
+
+func (e txExecutor) write(ctx context.Context, f func(s *store) error) error {
+	e.tx.mutex.Lock()
+	defer e.tx.mutex.Unlock()
+
+	if e.tx.done {
+		return errTxDone
+	}
+
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	err = f(e.tx.snapshot)
+	if err != nil {
+		return err
+	}
+
+	e.tx.writes = append(e.tx.writes, f)
+	return nil
+}
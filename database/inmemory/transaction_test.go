@@ -0,0 +1,287 @@
+package inmemory_test
+
+import (
+	"authserver/common"
+	"authserver/database"
+	"authserver/models"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionTestSuite struct {
+	InMemoryTestSuite
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_AppliesWrites() {
+	//arrange
+	tx := suite.CreateTransaction()
+	user := models.CreateNewUser("username", []byte("password"))
+
+	err := tx.SaveUser(context.Background(), user)
+	suite.Require().NoError(err)
+
+	//act
+	err = tx.CommitTransaction()
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Equal(user, resultUser)
+}
+
+func (suite *TransactionTestSuite) TestRollbackTransaction_DiscardsWrites() {
+	//arrange
+	tx := suite.CreateTransaction()
+	user := models.CreateNewUser("username", []byte("password"))
+
+	err := tx.SaveUser(context.Background(), user)
+	suite.Require().NoError(err)
+
+	//act
+	tx.RollbackTransaction()
+
+	//assert
+	resultUser, err := suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Nil(resultUser)
+}
+
+func (suite *TransactionTestSuite) TestWrites_AreIsolatedUntilCommitted() {
+	//arrange
+	tx1 := suite.CreateTransaction()
+	tx2 := suite.CreateTransaction()
+	user := models.CreateNewUser("username", []byte("password"))
+
+	//act
+	err := tx1.SaveUser(context.Background(), user)
+	suite.Require().NoError(err)
+
+	//assert
+	resultUser, err := tx1.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.NotNil(resultUser, "the transaction should see its own writes")
+
+	resultUser, err = tx2.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Nil(resultUser, "other transactions should not see uncommitted writes")
+
+	resultUser, err = suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Nil(resultUser, "the db should not see uncommitted writes")
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_WhereWriteConflictsWithCommittedData_ReturnsErrorAndAppliesNothing() {
+	//arrange
+	tx1 := suite.CreateTransaction()
+	tx2 := suite.CreateTransaction()
+
+	otherUser := models.CreateNewUser("other", []byte("password"))
+	err := tx1.SaveUser(context.Background(), otherUser)
+	suite.Require().NoError(err)
+
+	err = tx1.SaveUser(context.Background(), models.CreateNewUser("username", []byte("password")))
+	suite.Require().NoError(err)
+
+	err = tx2.SaveUser(context.Background(), models.CreateNewUser("username", []byte("password")))
+	suite.Require().NoError(err)
+
+	err = tx2.CommitTransaction()
+	suite.Require().NoError(err)
+
+	//act
+	err = tx1.CommitTransaction()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "user_username_un")
+	suite.True(database.IsRetryable(err), "a write that only conflicts with data committed since the transaction started should be retryable")
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), otherUser.ID)
+	suite.NoError(err)
+	suite.Nil(resultUser)
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_WhereRowWrittenWasUpdatedSinceSnapshot_ReturnsErrorAndAppliesNothing() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	tx1 := suite.CreateTransaction()
+	tx2 := suite.CreateTransaction()
+
+	user1 := *user
+	user1.IsAdmin = true
+	err := tx1.UpdateUser(context.Background(), &user1)
+	suite.Require().NoError(err)
+
+	user2 := *user
+	user2.IsDisabled = true
+	err = tx2.UpdateUser(context.Background(), &user2)
+	suite.Require().NoError(err)
+
+	err = tx2.CommitTransaction()
+	suite.Require().NoError(err)
+
+	//act
+	err = tx1.CommitTransaction()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "could not serialize access")
+	suite.True(database.IsRetryable(err))
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.False(resultUser.IsAdmin)
+	suite.True(resultUser.IsDisabled)
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_WhereRowReadWasUpdatedSinceSnapshot_ReturnsErrorAndAppliesNothing() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	tx := suite.CreateTransaction()
+
+	_, err := tx.GetUserByID(context.Background(), user.ID)
+	suite.Require().NoError(err)
+
+	audit := models.CreateNewAuditEvent(models.AuditActionUserUpdate, models.AuditOutcomeSuccess)
+	err = tx.SaveAuditEvent(context.Background(), audit)
+	suite.Require().NoError(err)
+
+	user.IsDisabled = true
+	err = suite.DB.UpdateUser(context.Background(), user)
+	suite.Require().NoError(err)
+
+	//act
+	err = tx.CommitTransaction()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "could not serialize access")
+	suite.True(database.IsRetryable(err))
+
+	events, err := suite.DB.GetAuditEvents(context.Background(), models.AuditEventFilter{})
+	suite.NoError(err)
+	suite.Empty(events)
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_WhereTableScannedHasNewRow_ReturnsError() {
+	//arrange
+	tx := suite.CreateTransaction()
+
+	count, err := tx.CountUsers(context.Background(), models.UserFilter{})
+	suite.Require().NoError(err)
+	suite.Require().Zero(count)
+
+	err = tx.SaveUser(context.Background(), models.CreateNewUser("first", []byte("password")))
+	suite.Require().NoError(err)
+
+	suite.SaveUser(models.CreateNewUser("other", []byte("password")))
+
+	//act
+	err = tx.CommitTransaction()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "could not serialize access")
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_WhereOtherRowsWereUpdatedSinceSnapshot_AppliesWrites() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	other := models.CreateNewUser("other", []byte("password"))
+	suite.SaveUser(user)
+	suite.SaveUser(other)
+
+	tx := suite.CreateTransaction()
+
+	resultUser, err := tx.GetUserByID(context.Background(), user.ID)
+	suite.Require().NoError(err)
+
+	resultUser.IsAdmin = true
+	err = tx.UpdateUser(context.Background(), resultUser)
+	suite.Require().NoError(err)
+
+	other.IsDisabled = true
+	err = suite.DB.UpdateUser(context.Background(), other)
+	suite.Require().NoError(err)
+
+	//act
+	err = tx.CommitTransaction()
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultUser, err = suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.True(resultUser.IsAdmin)
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_WithoutWritesWhereRowReadWasUpdatedSinceSnapshot_ReturnsNoError() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	tx := suite.CreateTransaction()
+
+	_, err := tx.GetUserByID(context.Background(), user.ID)
+	suite.Require().NoError(err)
+
+	user.IsDisabled = true
+	err = suite.DB.UpdateUser(context.Background(), user)
+	suite.Require().NoError(err)
+
+	//act
+	err = tx.CommitTransaction()
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *TransactionTestSuite) TestCommitTransaction_WhereContextIsDone_ReturnsErrorAndAppliesNothing() {
+	//arrange
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tx, err := suite.TransactionFactory.CreateTransaction(ctx)
+	suite.Require().NoError(err)
+
+	user := models.CreateNewUser("username", []byte("password"))
+	err = tx.SaveUser(ctx, user)
+	suite.Require().NoError(err)
+
+	cancel()
+
+	//act
+	err = tx.CommitTransaction()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "context canceled")
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Nil(resultUser)
+}
+
+func (suite *TransactionTestSuite) TestOperations_AfterTransactionIsDone_ReturnError() {
+	//arrange
+	tx := suite.CreateTransaction()
+	err := tx.CommitTransaction()
+	suite.Require().NoError(err)
+
+	//act
+	_, getErr := tx.GetScopeByName(context.Background(), "all")
+	saveErr := tx.SaveUser(context.Background(), models.CreateNewUser("username", []byte("password")))
+	commitErr := tx.CommitTransaction()
+	tx.RollbackTransaction()
+
+	//assert
+	common.AssertError(&suite.Suite, getErr, "already been committed or rolled back")
+	common.AssertError(&suite.Suite, saveErr, "already been committed or rolled back")
+	common.AssertError(&suite.Suite, commitErr, "already been committed or rolled back")
+}
+
+func TestTransactionTestSuite(t *testing.T) {
+	suite.Run(t, &TransactionTestSuite{})
+}
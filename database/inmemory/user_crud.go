@@ -0,0 +1,212 @@
+package inmemory
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SaveUser validates the user model is valid and inserts it into the users table.
+// Returns an error if the id or username is already taken, or any other errors.
+func (adapter *Adapter) SaveUser(ctx context.Context, user *models.User) error {
+	verr := user.Validate()
+	if verr != models.ValidateUserValid {
+		return errors.New(fmt.Sprint("error validating user model:", verr))
+	}
+
+	row := copyUser(user)
+	err := adapter.executor.write(ctx, func(s *store) error {
+		if _, ok := s.users[row.ID]; ok {
+			return uniqueViolation("user_pk")
+		}
+		if findUserByUsername(s, row.Username) != nil {
+			return uniqueViolation("user_username_un")
+		}
+
+		s.users[row.ID] = row
+		s.wroteRow(tableUser, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing save user statement", err)
+	}
+
+	return nil
+}
+
+// GetUserByID gets the user with the matching id.
+// Returns a copy of the user, or nil if it was not found. Also returns any errors.
+func (adapter *Adapter) GetUserByID(ctx context.Context, ID uuid.UUID) (*models.User, error) {
+	var user *models.User
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableUser, ID)
+		if row, ok := s.users[ID]; ok {
+			user = newUser(row)
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get user by id query", err)
+	}
+
+	return user, nil
+}
+
+// GetUserByUsername gets the user with the matching username.
+// Returns a copy of the user, or nil if it was not found. Also returns any errors.
+func (adapter *Adapter) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	var user *models.User
+	err := adapter.executor.read(ctx, func(s *store) {
+		if row := findUserByUsername(s, username); row != nil {
+			s.readRow(tableUser, row.ID)
+			user = newUser(*row)
+		} else {
+			s.readRow(tableUser, nil)
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get user by username query", err)
+	}
+
+	return user, nil
+}
+
+// GetUsers gets the users that match the filter, ordered by username.
+// The filter's limit and offset are normalized before being used.
+// Returns copies of the users and any errors.
+func (adapter *Adapter) GetUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	filter.Normalize()
+
+	users := []*models.User{}
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableUser, nil)
+		for _, row := range s.users {
+			if matchesUserFilter(row, filter) {
+				users = append(users, newUser(row))
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, common.ChainError("error executing get users query", err)
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].Username != users[j].Username {
+			return users[i].Username < users[j].Username
+		}
+		return users[i].ID.String() < users[j].ID.String()
+	})
+
+	start, end := pageBounds(len(users), filter.Limit, filter.Offset)
+	return users[start:end], nil
+}
+
+// CountUsers counts the users that match the filter, ignoring its limit and offset.
+// Returns the count and any errors.
+func (adapter *Adapter) CountUsers(ctx context.Context, filter models.UserFilter) (int, error) {
+	count := 0
+	err := adapter.executor.read(ctx, func(s *store) {
+		s.readRow(tableUser, nil)
+		for _, row := range s.users {
+			if matchesUserFilter(row, filter) {
+				count++
+			}
+		}
+	})
+
+	if err != nil {
+		return 0, common.ChainError("error executing count users query", err)
+	}
+
+	return count, nil
+}
+
+// UpdateUser validates the user model is valid and updates the user with the matching id.
+// Does nothing if no user has the id. Returns an error if the username is taken by another user, or any other errors.
+func (adapter *Adapter) UpdateUser(ctx context.Context, user *models.User) error {
+	verr := user.Validate()
+	if verr != models.ValidateUserValid {
+		return errors.New(fmt.Sprint("error validating user model:", verr))
+	}
+
+	row := copyUser(user)
+	err := adapter.executor.write(ctx, func(s *store) error {
+		if _, ok := s.users[row.ID]; !ok {
+			return nil
+		}
+		if other := findUserByUsername(s, row.Username); other != nil && other.ID != row.ID {
+			return uniqueViolation("user_username_un")
+		}
+
+		s.users[row.ID] = row
+		s.wroteRow(tableUser, row.ID)
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing update user statement", err)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes the user with the matching id, cascading to the user's access tokens.
+// Returns any errors.
+func (adapter *Adapter) DeleteUser(ctx context.Context, user *models.User) error {
+	ID := user.ID
+	err := adapter.executor.write(ctx, func(s *store) error {
+		delete(s.users, ID)
+		s.wroteRow(tableUser, ID)
+
+		for tokenID, token := range s.accessTokens {
+			if token.UserID == ID {
+				delete(s.accessTokens, tokenID)
+				s.wroteRow(tableAccessToken, tokenID)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return common.ChainError("error executing delete user statement", err)
+	}
+
+	return nil
+}
+
+func findUserByUsername(s *store, username string) *models.User {
+	for _, row := range s.users {
+		if row.Username == username {
+			return &row
+		}
+	}
+
+	return nil
+}
+
+func matchesUserFilter(row models.User, filter models.UserFilter) bool {
+	return filter.Username == "" || strings.Contains(strings.ToLower(row.Username), strings.ToLower(filter.Username))
+}
+
+// copyUser copies the user, including its password hash, so the copy does not share memory with the original.
+func copyUser(user *models.User) models.User {
+	row := *user
+	row.PasswordHash = append([]byte(nil), user.PasswordHash...)
+
+	return row
+}
+
+func newUser(row models.User) *models.User {
+	user := copyUser(&row)
+	return &user
+}
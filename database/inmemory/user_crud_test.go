@@ -0,0 +1,188 @@
+package inmemory_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type UserCRUDTestSuite struct {
+	InMemoryTestSuite
+}
+
+func (suite *UserCRUDTestSuite) TestSaveUser_WithInvalidUser_ReturnsError() {
+	//act
+	err := suite.DB.SaveUser(context.Background(), models.CreateNewUser("", nil))
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error", "user model")
+}
+
+func (suite *UserCRUDTestSuite) TestSaveUser_WithDuplicateUsername_ReturnsError() {
+	//arrange
+	suite.SaveUser(models.CreateNewUser("username", []byte("password")))
+
+	//act
+	err := suite.DB.SaveUser(context.Background(), models.CreateNewUser("username", []byte("password")))
+
+	//assert
+	common.AssertError(&suite.Suite, err, "user_username_un")
+}
+
+func (suite *UserCRUDTestSuite) TestGetUserByUsername_GetsTheUserWithUsername() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	//act
+	resultUser, err := suite.DB.GetUserByUsername(context.Background(), user.Username)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(user, resultUser)
+}
+
+func (suite *UserCRUDTestSuite) TestGetUsers_GetsMatchingUsersOrderedByUsername() {
+	//arrange
+	user1 := models.CreateNewUser("user-bob", []byte("password"))
+	user2 := models.CreateNewUser("user-alice", []byte("password"))
+	user2.IsDisabled = true
+
+	for _, user := range []*models.User{user1, user2, models.CreateNewUser("other", []byte("password"))} {
+		suite.SaveUser(user)
+	}
+
+	//act
+	users, err := suite.DB.GetUsers(context.Background(), models.UserFilter{Username: "USER-"})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]*models.User{user2, user1}, users)
+}
+
+func (suite *UserCRUDTestSuite) TestGetUsers_WithLimitAndOffset_GetsPageOfUsers() {
+	//arrange
+	users := []*models.User{
+		models.CreateNewUser("a", []byte("password")),
+		models.CreateNewUser("b", []byte("password")),
+		models.CreateNewUser("c", []byte("password")),
+	}
+	for _, user := range users {
+		suite.SaveUser(user)
+	}
+
+	//act
+	result, err := suite.DB.GetUsers(context.Background(), models.UserFilter{Limit: 1, Offset: 1})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]*models.User{users[1]}, result)
+}
+
+func (suite *UserCRUDTestSuite) TestCountUsers_CountsAllMatchingUsers() {
+	//arrange
+	for _, username := range []string{"user-a", "user-b", "other"} {
+		suite.SaveUser(models.CreateNewUser(username, []byte("password")))
+	}
+
+	//act
+	count, err := suite.DB.CountUsers(context.Background(), models.UserFilter{Username: "user", Limit: 1})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
+func (suite *UserCRUDTestSuite) TestGetUserByID_WhereUserNotFound_ReturnsNilUser() {
+	//act
+	user, err := suite.DB.GetUserByID(context.Background(), uuid.New())
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(user)
+}
+
+func (suite *UserCRUDTestSuite) TestGetUserByID_WithCanceledContext_ReturnsError() {
+	//arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//act
+	_, err := suite.DB.GetUserByID(ctx, uuid.New())
+
+	//assert
+	common.AssertError(&suite.Suite, err, "context canceled")
+}
+
+func (suite *UserCRUDTestSuite) TestUpdateUser_UpdatesUserWithId() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	//act
+	user.Username = "username2"
+	user.IsAdmin = true
+	err := suite.DB.UpdateUser(context.Background(), user)
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), user.ID)
+	suite.NoError(err)
+	suite.Equal(user, resultUser)
+}
+
+func (suite *UserCRUDTestSuite) TestUpdateUser_WithUsernameOfOtherUser_ReturnsError() {
+	//arrange
+	suite.SaveUser(models.CreateNewUser("other", []byte("password")))
+
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.SaveUser(user)
+
+	//act
+	user.Username = "other"
+	err := suite.DB.UpdateUser(context.Background(), user)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "user_username_un")
+}
+
+func (suite *UserCRUDTestSuite) TestUpdateUser_WithNoUserToUpdate_ReturnsNilError() {
+	//act
+	err := suite.DB.UpdateUser(context.Background(), models.CreateNewUser("username", []byte("password")))
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *UserCRUDTestSuite) TestDeleteUser_AlsoDeletesAllUserTokens() {
+	//arrange
+	token := models.CreateNewAccessToken(
+		models.CreateNewUser("username", []byte("password")),
+		models.CreateNewClient(),
+		models.CreateNewScope("name"),
+	)
+	suite.SaveAccessTokenAndFields(token)
+
+	//act
+	err := suite.DB.DeleteUser(context.Background(), token.User)
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultUser, err := suite.DB.GetUserByID(context.Background(), token.User.ID)
+	suite.NoError(err)
+	suite.Nil(resultUser)
+
+	resultToken, err := suite.DB.GetAccessTokenByID(context.Background(), token.ID)
+	suite.NoError(err)
+	suite.Nil(resultToken)
+}
+
+func TestUserCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &UserCRUDTestSuite{})
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"authserver/models"
+	"context"
 )
 
 // CRUDOperations is an interface that encapsulates various model CRUD interfaces.
@@ -11,6 +12,9 @@ type CRUDOperations interface {
 	models.ClientCRUD
 	models.ScopeCRUD
 	models.AccessTokenCRUD
+	models.AuditEventCRUD
+	models.OutboxEventCRUD
+	models.RateLimitBucketCRUD
 }
 
 // DBConnection is an interface for controlling the connection to the database.
@@ -33,6 +37,10 @@ type TransactionOperations interface {
 
 	// RollbackTransaction rollbacks the transaction.
 	RollbackTransaction()
+
+	// RetryableError returns the first error the transaction encountered that can be resolved by retrying it from the start,
+	// such as a serialization failure or deadlock. Returns nil if there were none.
+	RetryableError() error
 }
 
 // Database is an interface that encapsulates the database connection and CRUD operations interfaces.
@@ -50,5 +58,6 @@ type Transaction interface {
 // TransactionFactory is an interface for creating new transactions.
 type TransactionFactory interface {
 	// CreateTransaction creates a new transaction and returns and errors.
-	CreateTransaction() (Transaction, error)
+	// The context carries request scoped values, such as the logger, for the transaction to use.
+	CreateTransaction(ctx context.Context) (Transaction, error)
 }
@@ -3,11 +3,13 @@
 package mocks
 
 import (
-	models "authserver/models"
+	context "context"
 
-	mock "github.com/stretchr/testify/mock"
+	models "authserver/models"
+	time "time"
 
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // CRUDOperations is an autogenerated mock type for the CRUDOperations type
@@ -15,6 +17,27 @@ type CRUDOperations struct {
 	mock.Mock
 }
 
+// CountUsers provides a mock function with given fields: ctx, filter
+func (_m *CRUDOperations) CountUsers(ctx context.Context, filter models.UserFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateMigration provides a mock function with given fields: timestamp
 func (_m *CRUDOperations) CreateMigration(timestamp string) error {
 	ret := _m.Called(timestamp)
@@ -29,13 +52,27 @@ func (_m *CRUDOperations) CreateMigration(timestamp string) error {
 	return r0
 }
 
-// DeleteAccessToken provides a mock function with given fields: token
-func (_m *CRUDOperations) DeleteAccessToken(token *models.AccessToken) error {
-	ret := _m.Called(token)
+// DeleteAccessToken provides a mock function with given fields: ctx, token
+func (_m *CRUDOperations) DeleteAccessToken(ctx context.Context, token *models.AccessToken) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllOtherUserTokens provides a mock function with given fields: ctx, token
+func (_m *CRUDOperations) DeleteAllOtherUserTokens(ctx context.Context, token *models.AccessToken) error {
+	ret := _m.Called(ctx, token)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.AccessToken) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessToken) error); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -43,13 +80,13 @@ func (_m *CRUDOperations) DeleteAccessToken(token *models.AccessToken) error {
 	return r0
 }
 
-// DeleteAllOtherUserTokens provides a mock function with given fields: token
-func (_m *CRUDOperations) DeleteAllOtherUserTokens(token *models.AccessToken) error {
-	ret := _m.Called(token)
+// DeleteAllUserTokens provides a mock function with given fields: ctx, user
+func (_m *CRUDOperations) DeleteAllUserTokens(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.AccessToken) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -71,13 +108,27 @@ func (_m *CRUDOperations) DeleteMigrationByTimestamp(timestamp string) error {
 	return r0
 }
 
-// DeleteUser provides a mock function with given fields: user
-func (_m *CRUDOperations) DeleteUser(user *models.User) error {
-	ret := _m.Called(user)
+// DeleteRateLimitBucketsUpdatedBefore provides a mock function with given fields: ctx, before
+func (_m *CRUDOperations) DeleteRateLimitBucketsUpdatedBefore(ctx context.Context, before time.Time) error {
+	ret := _m.Called(ctx, before)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUser provides a mock function with given fields: ctx, user
+func (_m *CRUDOperations) DeleteUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -85,13 +136,13 @@ func (_m *CRUDOperations) DeleteUser(user *models.User) error {
 	return r0
 }
 
-// GetAccessTokenByID provides a mock function with given fields: ID
-func (_m *CRUDOperations) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken, error) {
-	ret := _m.Called(ID)
+// GetAccessTokenByID provides a mock function with given fields: ctx, ID
+func (_m *CRUDOperations) GetAccessTokenByID(ctx context.Context, ID uuid.UUID) (*models.AccessToken, error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *models.AccessToken
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.AccessToken); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.AccessToken); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.AccessToken)
@@ -99,8 +150,8 @@ func (_m *CRUDOperations) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -108,13 +159,36 @@ func (_m *CRUDOperations) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken,
 	return r0, r1
 }
 
-// GetClientByID provides a mock function with given fields: ID
-func (_m *CRUDOperations) GetClientByID(ID uuid.UUID) (*models.Client, error) {
-	ret := _m.Called(ID)
+// GetAuditEvents provides a mock function with given fields: ctx, filter
+func (_m *CRUDOperations) GetAuditEvents(ctx context.Context, filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*models.AuditEvent
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditEventFilter) []*models.AuditEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.AuditEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientByID provides a mock function with given fields: ctx, ID
+func (_m *CRUDOperations) GetClientByID(ctx context.Context, ID uuid.UUID) (*models.Client, error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *models.Client
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Client); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Client); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Client)
@@ -122,8 +196,31 @@ func (_m *CRUDOperations) GetClientByID(ID uuid.UUID) (*models.Client, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetteredOutboxEvents provides a mock function with given fields: ctx, filter
+func (_m *CRUDOperations) GetDeadLetteredOutboxEvents(ctx context.Context, filter models.OutboxEventFilter) ([]*models.OutboxEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*models.OutboxEvent
+	if rf, ok := ret.Get(0).(func(context.Context, models.OutboxEventFilter) []*models.OutboxEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.OutboxEventFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,13 +279,59 @@ func (_m *CRUDOperations) GetMigrationByTimestamp(timestamp string) (*models.Mig
 	return r0, r1
 }
 
-// GetScopeByName provides a mock function with given fields: name
-func (_m *CRUDOperations) GetScopeByName(name string) (*models.Scope, error) {
-	ret := _m.Called(name)
+// GetPendingOutboxEvents provides a mock function with given fields: ctx, now, limit
+func (_m *CRUDOperations) GetPendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*models.OutboxEvent, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []*models.OutboxEvent
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*models.OutboxEvent); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRateLimitBucketByKey provides a mock function with given fields: ctx, key
+func (_m *CRUDOperations) GetRateLimitBucketByKey(ctx context.Context, key string) (*models.RateLimitBucket, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *models.RateLimitBucket
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RateLimitBucket); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateLimitBucket)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScopeByName provides a mock function with given fields: ctx, name
+func (_m *CRUDOperations) GetScopeByName(ctx context.Context, name string) (*models.Scope, error) {
+	ret := _m.Called(ctx, name)
 
 	var r0 *models.Scope
-	if rf, ok := ret.Get(0).(func(string) *models.Scope); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Scope); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Scope)
@@ -196,8 +339,8 @@ func (_m *CRUDOperations) GetScopeByName(name string) (*models.Scope, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -205,13 +348,13 @@ func (_m *CRUDOperations) GetScopeByName(name string) (*models.Scope, error) {
 	return r0, r1
 }
 
-// GetUserByID provides a mock function with given fields: ID
-func (_m *CRUDOperations) GetUserByID(ID uuid.UUID) (*models.User, error) {
-	ret := _m.Called(ID)
+// GetUserByID provides a mock function with given fields: ctx, ID
+func (_m *CRUDOperations) GetUserByID(ctx context.Context, ID uuid.UUID) (*models.User, error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.User); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.User); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -219,8 +362,8 @@ func (_m *CRUDOperations) GetUserByID(ID uuid.UUID) (*models.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -228,13 +371,13 @@ func (_m *CRUDOperations) GetUserByID(ID uuid.UUID) (*models.User, error) {
 	return r0, r1
 }
 
-// GetUserByUsername provides a mock function with given fields: username
-func (_m *CRUDOperations) GetUserByUsername(username string) (*models.User, error) {
-	ret := _m.Called(username)
+// GetUserByUsername provides a mock function with given fields: ctx, username
+func (_m *CRUDOperations) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	ret := _m.Called(ctx, username)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, username)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -242,8 +385,31 @@ func (_m *CRUDOperations) GetUserByUsername(username string) (*models.User, erro
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: ctx, filter
+func (_m *CRUDOperations) GetUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*models.User
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserFilter) []*models.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -251,13 +417,13 @@ func (_m *CRUDOperations) GetUserByUsername(username string) (*models.User, erro
 	return r0, r1
 }
 
-// SaveAccessToken provides a mock function with given fields: token
-func (_m *CRUDOperations) SaveAccessToken(token *models.AccessToken) error {
-	ret := _m.Called(token)
+// SaveAccessToken provides a mock function with given fields: ctx, token
+func (_m *CRUDOperations) SaveAccessToken(ctx context.Context, token *models.AccessToken) error {
+	ret := _m.Called(ctx, token)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.AccessToken) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessToken) error); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -265,13 +431,13 @@ func (_m *CRUDOperations) SaveAccessToken(token *models.AccessToken) error {
 	return r0
 }
 
-// SaveClient provides a mock function with given fields: client
-func (_m *CRUDOperations) SaveClient(client *models.Client) error {
-	ret := _m.Called(client)
+// SaveAuditEvent provides a mock function with given fields: ctx, event
+func (_m *CRUDOperations) SaveAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	ret := _m.Called(ctx, event)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Client) error); ok {
-		r0 = rf(client)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -279,13 +445,13 @@ func (_m *CRUDOperations) SaveClient(client *models.Client) error {
 	return r0
 }
 
-// SaveScope provides a mock function with given fields: scope
-func (_m *CRUDOperations) SaveScope(scope *models.Scope) error {
-	ret := _m.Called(scope)
+// SaveClient provides a mock function with given fields: ctx, client
+func (_m *CRUDOperations) SaveClient(ctx context.Context, client *models.Client) error {
+	ret := _m.Called(ctx, client)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Scope) error); ok {
-		r0 = rf(scope)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Client) error); ok {
+		r0 = rf(ctx, client)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -293,13 +459,55 @@ func (_m *CRUDOperations) SaveScope(scope *models.Scope) error {
 	return r0
 }
 
-// SaveUser provides a mock function with given fields: user
-func (_m *CRUDOperations) SaveUser(user *models.User) error {
-	ret := _m.Called(user)
+// SaveOutboxEvent provides a mock function with given fields: ctx, event
+func (_m *CRUDOperations) SaveOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveRateLimitBucket provides a mock function with given fields: ctx, bucket
+func (_m *CRUDOperations) SaveRateLimitBucket(ctx context.Context, bucket *models.RateLimitBucket) error {
+	ret := _m.Called(ctx, bucket)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RateLimitBucket) error); ok {
+		r0 = rf(ctx, bucket)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveScope provides a mock function with given fields: ctx, scope
+func (_m *CRUDOperations) SaveScope(ctx context.Context, scope *models.Scope) error {
+	ret := _m.Called(ctx, scope)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Scope) error); ok {
+		r0 = rf(ctx, scope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveUser provides a mock function with given fields: ctx, user
+func (_m *CRUDOperations) SaveUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -321,13 +529,27 @@ func (_m *CRUDOperations) Setup() error {
 	return r0
 }
 
-// UpdateUser provides a mock function with given fields: user
-func (_m *CRUDOperations) UpdateUser(user *models.User) error {
-	ret := _m.Called(user)
+// UpdateOutboxEvent provides a mock function with given fields: ctx, event
+func (_m *CRUDOperations) UpdateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *CRUDOperations) UpdateUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
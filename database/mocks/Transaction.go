@@ -3,11 +3,13 @@
 package mocks
 
 import (
-	models "authserver/models"
+	context "context"
 
-	mock "github.com/stretchr/testify/mock"
+	models "authserver/models"
+	time "time"
 
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
 )
 
 // Transaction is an autogenerated mock type for the Transaction type
@@ -29,6 +31,27 @@ func (_m *Transaction) CommitTransaction() error {
 	return r0
 }
 
+// CountUsers provides a mock function with given fields: ctx, filter
+func (_m *Transaction) CountUsers(ctx context.Context, filter models.UserFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateMigration provides a mock function with given fields: timestamp
 func (_m *Transaction) CreateMigration(timestamp string) error {
 	ret := _m.Called(timestamp)
@@ -43,13 +66,13 @@ func (_m *Transaction) CreateMigration(timestamp string) error {
 	return r0
 }
 
-// DeleteAccessToken provides a mock function with given fields: token
-func (_m *Transaction) DeleteAccessToken(token *models.AccessToken) error {
-	ret := _m.Called(token)
+// DeleteAccessToken provides a mock function with given fields: ctx, token
+func (_m *Transaction) DeleteAccessToken(ctx context.Context, token *models.AccessToken) error {
+	ret := _m.Called(ctx, token)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.AccessToken) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessToken) error); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -57,13 +80,27 @@ func (_m *Transaction) DeleteAccessToken(token *models.AccessToken) error {
 	return r0
 }
 
-// DeleteAllOtherUserTokens provides a mock function with given fields: token
-func (_m *Transaction) DeleteAllOtherUserTokens(token *models.AccessToken) error {
-	ret := _m.Called(token)
+// DeleteAllOtherUserTokens provides a mock function with given fields: ctx, token
+func (_m *Transaction) DeleteAllOtherUserTokens(ctx context.Context, token *models.AccessToken) error {
+	ret := _m.Called(ctx, token)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.AccessToken) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAllUserTokens provides a mock function with given fields: ctx, user
+func (_m *Transaction) DeleteAllUserTokens(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -85,13 +122,13 @@ func (_m *Transaction) DeleteMigrationByTimestamp(timestamp string) error {
 	return r0
 }
 
-// DeleteUser provides a mock function with given fields: user
-func (_m *Transaction) DeleteUser(user *models.User) error {
-	ret := _m.Called(user)
+// DeleteRateLimitBucketsUpdatedBefore provides a mock function with given fields: ctx, before
+func (_m *Transaction) DeleteRateLimitBucketsUpdatedBefore(ctx context.Context, before time.Time) error {
+	ret := _m.Called(ctx, before)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) error); ok {
+		r0 = rf(ctx, before)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -99,13 +136,27 @@ func (_m *Transaction) DeleteUser(user *models.User) error {
 	return r0
 }
 
-// GetAccessTokenByID provides a mock function with given fields: ID
-func (_m *Transaction) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken, error) {
-	ret := _m.Called(ID)
+// DeleteUser provides a mock function with given fields: ctx, user
+func (_m *Transaction) DeleteUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAccessTokenByID provides a mock function with given fields: ctx, ID
+func (_m *Transaction) GetAccessTokenByID(ctx context.Context, ID uuid.UUID) (*models.AccessToken, error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *models.AccessToken
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.AccessToken); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.AccessToken); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.AccessToken)
@@ -113,8 +164,8 @@ func (_m *Transaction) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken, er
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,13 +173,36 @@ func (_m *Transaction) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken, er
 	return r0, r1
 }
 
-// GetClientByID provides a mock function with given fields: ID
-func (_m *Transaction) GetClientByID(ID uuid.UUID) (*models.Client, error) {
-	ret := _m.Called(ID)
+// GetAuditEvents provides a mock function with given fields: ctx, filter
+func (_m *Transaction) GetAuditEvents(ctx context.Context, filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*models.AuditEvent
+	if rf, ok := ret.Get(0).(func(context.Context, models.AuditEventFilter) []*models.AuditEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AuditEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.AuditEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClientByID provides a mock function with given fields: ctx, ID
+func (_m *Transaction) GetClientByID(ctx context.Context, ID uuid.UUID) (*models.Client, error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *models.Client
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.Client); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Client); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Client)
@@ -136,8 +210,31 @@ func (_m *Transaction) GetClientByID(ID uuid.UUID) (*models.Client, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetteredOutboxEvents provides a mock function with given fields: ctx, filter
+func (_m *Transaction) GetDeadLetteredOutboxEvents(ctx context.Context, filter models.OutboxEventFilter) ([]*models.OutboxEvent, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*models.OutboxEvent
+	if rf, ok := ret.Get(0).(func(context.Context, models.OutboxEventFilter) []*models.OutboxEvent); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.OutboxEventFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -196,13 +293,59 @@ func (_m *Transaction) GetMigrationByTimestamp(timestamp string) (*models.Migrat
 	return r0, r1
 }
 
-// GetScopeByName provides a mock function with given fields: name
-func (_m *Transaction) GetScopeByName(name string) (*models.Scope, error) {
-	ret := _m.Called(name)
+// GetPendingOutboxEvents provides a mock function with given fields: ctx, now, limit
+func (_m *Transaction) GetPendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*models.OutboxEvent, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	var r0 []*models.OutboxEvent
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*models.OutboxEvent); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OutboxEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRateLimitBucketByKey provides a mock function with given fields: ctx, key
+func (_m *Transaction) GetRateLimitBucketByKey(ctx context.Context, key string) (*models.RateLimitBucket, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *models.RateLimitBucket
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.RateLimitBucket); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateLimitBucket)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetScopeByName provides a mock function with given fields: ctx, name
+func (_m *Transaction) GetScopeByName(ctx context.Context, name string) (*models.Scope, error) {
+	ret := _m.Called(ctx, name)
 
 	var r0 *models.Scope
-	if rf, ok := ret.Get(0).(func(string) *models.Scope); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Scope); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Scope)
@@ -210,8 +353,8 @@ func (_m *Transaction) GetScopeByName(name string) (*models.Scope, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -219,13 +362,13 @@ func (_m *Transaction) GetScopeByName(name string) (*models.Scope, error) {
 	return r0, r1
 }
 
-// GetUserByID provides a mock function with given fields: ID
-func (_m *Transaction) GetUserByID(ID uuid.UUID) (*models.User, error) {
-	ret := _m.Called(ID)
+// GetUserByID provides a mock function with given fields: ctx, ID
+func (_m *Transaction) GetUserByID(ctx context.Context, ID uuid.UUID) (*models.User, error) {
+	ret := _m.Called(ctx, ID)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(uuid.UUID) *models.User); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.User); ok {
+		r0 = rf(ctx, ID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -233,8 +376,8 @@ func (_m *Transaction) GetUserByID(ID uuid.UUID) (*models.User, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = rf(ID)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, ID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -242,13 +385,13 @@ func (_m *Transaction) GetUserByID(ID uuid.UUID) (*models.User, error) {
 	return r0, r1
 }
 
-// GetUserByUsername provides a mock function with given fields: username
-func (_m *Transaction) GetUserByUsername(username string) (*models.User, error) {
-	ret := _m.Called(username)
+// GetUserByUsername provides a mock function with given fields: ctx, username
+func (_m *Transaction) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	ret := _m.Called(ctx, username)
 
 	var r0 *models.User
-	if rf, ok := ret.Get(0).(func(string) *models.User); ok {
-		r0 = rf(username)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, username)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.User)
@@ -256,8 +399,8 @@ func (_m *Transaction) GetUserByUsername(username string) (*models.User, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(username)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -265,18 +408,97 @@ func (_m *Transaction) GetUserByUsername(username string) (*models.User, error)
 	return r0, r1
 }
 
+// GetUsers provides a mock function with given fields: ctx, filter
+func (_m *Transaction) GetUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*models.User
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserFilter) []*models.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryableError provides a mock function with given fields:
+func (_m *Transaction) RetryableError() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RollbackTransaction provides a mock function with given fields:
 func (_m *Transaction) RollbackTransaction() {
 	_m.Called()
 }
 
-// SaveAccessToken provides a mock function with given fields: token
-func (_m *Transaction) SaveAccessToken(token *models.AccessToken) error {
-	ret := _m.Called(token)
+// SaveAccessToken provides a mock function with given fields: ctx, token
+func (_m *Transaction) SaveAccessToken(ctx context.Context, token *models.AccessToken) error {
+	ret := _m.Called(ctx, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessToken) error); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveAuditEvent provides a mock function with given fields: ctx, event
+func (_m *Transaction) SaveAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveClient provides a mock function with given fields: ctx, client
+func (_m *Transaction) SaveClient(ctx context.Context, client *models.Client) error {
+	ret := _m.Called(ctx, client)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Client) error); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveOutboxEvent provides a mock function with given fields: ctx, event
+func (_m *Transaction) SaveOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.AccessToken) error); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -284,13 +506,13 @@ func (_m *Transaction) SaveAccessToken(token *models.AccessToken) error {
 	return r0
 }
 
-// SaveClient provides a mock function with given fields: client
-func (_m *Transaction) SaveClient(client *models.Client) error {
-	ret := _m.Called(client)
+// SaveRateLimitBucket provides a mock function with given fields: ctx, bucket
+func (_m *Transaction) SaveRateLimitBucket(ctx context.Context, bucket *models.RateLimitBucket) error {
+	ret := _m.Called(ctx, bucket)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Client) error); ok {
-		r0 = rf(client)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.RateLimitBucket) error); ok {
+		r0 = rf(ctx, bucket)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -298,13 +520,13 @@ func (_m *Transaction) SaveClient(client *models.Client) error {
 	return r0
 }
 
-// SaveScope provides a mock function with given fields: scope
-func (_m *Transaction) SaveScope(scope *models.Scope) error {
-	ret := _m.Called(scope)
+// SaveScope provides a mock function with given fields: ctx, scope
+func (_m *Transaction) SaveScope(ctx context.Context, scope *models.Scope) error {
+	ret := _m.Called(ctx, scope)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Scope) error); ok {
-		r0 = rf(scope)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Scope) error); ok {
+		r0 = rf(ctx, scope)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -312,13 +534,13 @@ func (_m *Transaction) SaveScope(scope *models.Scope) error {
 	return r0
 }
 
-// SaveUser provides a mock function with given fields: user
-func (_m *Transaction) SaveUser(user *models.User) error {
-	ret := _m.Called(user)
+// SaveUser provides a mock function with given fields: ctx, user
+func (_m *Transaction) SaveUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -340,13 +562,27 @@ func (_m *Transaction) Setup() error {
 	return r0
 }
 
-// UpdateUser provides a mock function with given fields: user
-func (_m *Transaction) UpdateUser(user *models.User) error {
-	ret := _m.Called(user)
+// UpdateOutboxEvent provides a mock function with given fields: ctx, event
+func (_m *Transaction) UpdateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUser provides a mock function with given fields: ctx, user
+func (_m *Transaction) UpdateUser(ctx context.Context, user *models.User) error {
+	ret := _m.Called(ctx, user)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.User) error); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	database "authserver/database"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,13 @@ type TransactionFactory struct {
 	mock.Mock
 }
 
-// CreateTransaction provides a mock function with given fields:
-func (_m *TransactionFactory) CreateTransaction() (database.Transaction, error) {
-	ret := _m.Called()
+// CreateTransaction provides a mock function with given fields: ctx
+func (_m *TransactionFactory) CreateTransaction(ctx context.Context) (database.Transaction, error) {
+	ret := _m.Called(ctx)
 
 	var r0 database.Transaction
-	if rf, ok := ret.Get(0).(func() database.Transaction); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) database.Transaction); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(database.Transaction)
@@ -27,8 +29,8 @@ func (_m *TransactionFactory) CreateTransaction() (database.Transaction, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,7 @@ package sqladapter
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -12,13 +13,13 @@ import (
 
 // SaveAccessToken validates the access token model is valid and inserts a new row into the access_token table.
 // Returns any errors.
-func (adapter *SQLAdapter) SaveAccessToken(token *models.AccessToken) error {
+func (adapter *SQLAdapter) SaveAccessToken(ctx context.Context, token *models.AccessToken) error {
 	verr := token.Validate()
 	if verr != models.ValidateAccessTokenValid {
 		return errors.New(fmt.Sprint("error validating access token model:", verr))
 	}
 
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveAccessTokenScript(),
 		token.ID, token.User.ID, token.Client.ID, token.Scope.ID)
 	cancel()
@@ -32,8 +33,8 @@ func (adapter *SQLAdapter) SaveAccessToken(token *models.AccessToken) error {
 
 // GetAccessTokenByID gets the row in the access_token table with the matching id, and creates a new access token model with associated models using its data.
 // Returns the model and any errors.
-func (adapter *SQLAdapter) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken, error) {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) GetAccessTokenByID(ctx context.Context, ID uuid.UUID) (*models.AccessToken, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetAccessTokenByIdScript(), ID)
 	defer cancel()
 
@@ -47,8 +48,8 @@ func (adapter *SQLAdapter) GetAccessTokenByID(ID uuid.UUID) (*models.AccessToken
 
 // DeleteAccessToken deletes the row in the access_token table with the matching id.
 // Returns any errors.
-func (adapter *SQLAdapter) DeleteAccessToken(token *models.AccessToken) error {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) DeleteAccessToken(ctx context.Context, token *models.AccessToken) error {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.DeleteAccessTokenScript(), token.ID)
 	cancel()
 
@@ -61,8 +62,8 @@ func (adapter *SQLAdapter) DeleteAccessToken(token *models.AccessToken) error {
 
 // DeleteAllOtherUserTokens deletes all the rows in the access_token table with the matching user id, and not the token id.
 // Returns any errors.
-func (adapter *SQLAdapter) DeleteAllOtherUserTokens(token *models.AccessToken) error {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) DeleteAllOtherUserTokens(ctx context.Context, token *models.AccessToken) error {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.DeleteAllOtherUserTokensScript(), token.User.ID, token.ID)
 	cancel()
 
@@ -73,6 +74,20 @@ func (adapter *SQLAdapter) DeleteAllOtherUserTokens(token *models.AccessToken) e
 	return nil
 }
 
+// DeleteAllUserTokens deletes all the rows in the access_token table with the matching user id.
+// Returns any errors.
+func (adapter *SQLAdapter) DeleteAllUserTokens(ctx context.Context, user *models.User) error {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.DeleteAllUserTokensScript(), user.ID)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete all user tokens statement", err)
+	}
+
+	return nil
+}
+
 func readAccessTokenData(rows *sql.Rows) (*models.AccessToken, error) {
 	//check if there was a result
 	if !rows.Next() {
@@ -94,7 +109,7 @@ func readAccessTokenData(rows *sql.Rows) (*models.AccessToken, error) {
 	//get the result
 	err := rows.Scan(
 		&token.ID,
-		&token.User.ID, &token.User.Username, &token.User.PasswordHash,
+		&token.User.ID, &token.User.Username, &token.User.PasswordHash, &token.User.IsAdmin, &token.User.IsDisabled,
 		&token.Client.ID,
 		&token.Scope.ID, &token.Scope.Name,
 	)
@@ -3,6 +3,7 @@ package sqladapter_test
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -15,7 +16,7 @@ type AccessTokenCRUDTestSuite struct {
 
 func (suite *AccessTokenCRUDTestSuite) TestSaveAccessToken_WithInvalidAccessToken_ReturnsError() {
 	//act
-	err := suite.Tx.SaveAccessToken(models.CreateNewAccessToken(nil, nil, nil))
+	err := suite.Tx.SaveAccessToken(context.Background(), models.CreateNewAccessToken(nil, nil, nil))
 
 	//assert
 	common.AssertError(&suite.Suite, err, "error", "access token model")
@@ -23,7 +24,7 @@ func (suite *AccessTokenCRUDTestSuite) TestSaveAccessToken_WithInvalidAccessToke
 
 func (suite *AccessTokenCRUDTestSuite) TestGetAccessTokenById_WhereAccessTokenNotFound_ReturnsNilAccessToken() {
 	//act
-	token, err := suite.Tx.GetAccessTokenByID(uuid.New())
+	token, err := suite.Tx.GetAccessTokenByID(context.Background(), uuid.New())
 
 	//assert
 	suite.NoError(err)
@@ -40,7 +41,7 @@ func (suite *AccessTokenCRUDTestSuite) TestGetAccessTokenById_GetsTheAccessToken
 	suite.SaveAccessTokenAndFields(suite.Tx, token)
 
 	//act
-	resultAccessToken, err := suite.Tx.GetAccessTokenByID(token.ID)
+	resultAccessToken, err := suite.Tx.GetAccessTokenByID(context.Background(), token.ID)
 
 	//assert
 	suite.NoError(err)
@@ -49,7 +50,7 @@ func (suite *AccessTokenCRUDTestSuite) TestGetAccessTokenById_GetsTheAccessToken
 
 func (suite *AccessTokenCRUDTestSuite) TestDeleteAccessToken_WithNoAccessTokenToDelete_ReturnsNilError() {
 	//act
-	err := suite.Tx.DeleteAccessToken(models.CreateNewAccessToken(nil, nil, nil))
+	err := suite.Tx.DeleteAccessToken(context.Background(), models.CreateNewAccessToken(nil, nil, nil))
 
 	//assert
 	suite.NoError(err)
@@ -65,19 +66,19 @@ func (suite *AccessTokenCRUDTestSuite) TestDeleteAccessToken_DeletesAccessTokenW
 	suite.SaveAccessTokenAndFields(suite.Tx, token)
 
 	//act
-	err := suite.Tx.DeleteAccessToken(token)
+	err := suite.Tx.DeleteAccessToken(context.Background(), token)
 
 	//assert
 	suite.Require().NoError(err)
 
-	resultAccessToken, err := suite.Tx.GetAccessTokenByID(token.ID)
+	resultAccessToken, err := suite.Tx.GetAccessTokenByID(context.Background(), token.ID)
 	suite.NoError(err)
 	suite.Nil(resultAccessToken)
 }
 
 func (suite *AccessTokenCRUDTestSuite) TestDeleteAllOtherUserTokens_WithNoAccessTokensToDelete_ReturnsNilError() {
 	//act
-	err := suite.Tx.DeleteAllOtherUserTokens(models.CreateNewAccessToken(models.CreateNewUser("", nil), nil, nil))
+	err := suite.Tx.DeleteAllOtherUserTokens(context.Background(), models.CreateNewAccessToken(models.CreateNewUser("", nil), nil, nil))
 
 	//assert
 	suite.NoError(err)
@@ -97,25 +98,67 @@ func (suite *AccessTokenCRUDTestSuite) TestDeleteAllOtherUserTokens_DeletesAllOt
 		token1.Client,
 		token1.Scope,
 	)
-	suite.Tx.SaveAccessToken(token2)
+	suite.Tx.SaveAccessToken(context.Background(), token2)
 
 	//act
-	err := suite.Tx.DeleteAllOtherUserTokens(token1)
+	err := suite.Tx.DeleteAllOtherUserTokens(context.Background(), token1)
 
 	//assert
 	suite.Require().NoError(err)
 
 	//can still find token1
-	resultAccessToken, err := suite.Tx.GetAccessTokenByID(token1.ID)
+	resultAccessToken, err := suite.Tx.GetAccessTokenByID(context.Background(), token1.ID)
 	suite.NoError(err)
 	suite.EqualValues(token1, resultAccessToken)
 
 	//token2 was deleted
-	resultAccessToken, err = suite.Tx.GetAccessTokenByID(token2.ID)
+	resultAccessToken, err = suite.Tx.GetAccessTokenByID(context.Background(), token2.ID)
 	suite.NoError(err)
 	suite.Nil(resultAccessToken)
 }
 
+func (suite *AccessTokenCRUDTestSuite) TestDeleteAllUserTokens_DeletesAllTokensWithUserId() {
+	//arrange
+	token1 := models.CreateNewAccessToken(
+		models.CreateNewUser("username", []byte("password")),
+		models.CreateNewClient(),
+		models.CreateNewScope("name1"),
+	)
+	suite.SaveAccessTokenAndFields(suite.Tx, token1)
+
+	token2 := models.CreateNewAccessToken(
+		token1.User,
+		token1.Client,
+		token1.Scope,
+	)
+	suite.Tx.SaveAccessToken(context.Background(), token2)
+
+	otherToken := models.CreateNewAccessToken(
+		models.CreateNewUser("other", []byte("password")),
+		token1.Client,
+		token1.Scope,
+	)
+	suite.SaveUser(suite.Tx, otherToken.User)
+	suite.Tx.SaveAccessToken(context.Background(), otherToken)
+
+	//act
+	err := suite.Tx.DeleteAllUserTokens(context.Background(), token1.User)
+
+	//assert
+	suite.Require().NoError(err)
+
+	for _, token := range []*models.AccessToken{token1, token2} {
+		resultAccessToken, err := suite.Tx.GetAccessTokenByID(context.Background(), token.ID)
+		suite.NoError(err)
+		suite.Nil(resultAccessToken)
+	}
+
+	//the other user's token was not deleted
+	resultAccessToken, err := suite.Tx.GetAccessTokenByID(context.Background(), otherToken.ID)
+	suite.NoError(err)
+	suite.EqualValues(otherToken, resultAccessToken)
+}
+
 func TestAccessTokenCRUDTestSuite(t *testing.T) {
 	suite.Run(t, &AccessTokenCRUDTestSuite{})
 }
@@ -0,0 +1,109 @@
+package sqladapter
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SaveAuditEvent validates the audit event model is valid and inserts a new row into the audit_event table.
+// Returns any errors.
+func (adapter *SQLAdapter) SaveAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	verr := event.Validate()
+	if verr != models.ValidateAuditEventValid {
+		return errors.New(fmt.Sprint("error validating audit event model:", verr))
+	}
+
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveAuditEventScript(),
+		event.ID, event.Timestamp, event.Action, event.Outcome,
+		nullUUID(event.ActorID), nullUUID(event.TargetID), nullUUID(event.ClientID),
+		event.IPAddress, event.UserAgent, event.Details)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save audit event statement", err)
+	}
+
+	return nil
+}
+
+// GetAuditEvents gets the rows in the audit_event table that match the filter, newest first, and creates new audit event models using their data.
+// The filter's limit and offset are normalized before being used.
+// Returns the models and any errors.
+func (adapter *SQLAdapter) GetAuditEvents(ctx context.Context, filter models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	filter.Normalize()
+
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetAuditEventsScript(),
+		nullString(filter.Action), nullString(filter.Outcome),
+		nullUUID(filter.ActorID), nullUUID(filter.TargetID), nullUUID(filter.ClientID),
+		nullTime(filter.Since), nullTime(filter.Until),
+		filter.Limit, filter.Offset)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get audit events query", err)
+	}
+	defer rows.Close()
+
+	return readAuditEventsData(rows)
+}
+
+func readAuditEventsData(rows *sql.Rows) ([]*models.AuditEvent, error) {
+	events := []*models.AuditEvent{}
+
+	for rows.Next() {
+		//null ids are scanned as nil uuids
+		event := &models.AuditEvent{}
+		err := rows.Scan(
+			&event.ID, &event.Timestamp, &event.Action, &event.Outcome,
+			&event.ActorID, &event.TargetID, &event.ClientID,
+			&event.IPAddress, &event.UserAgent, &event.Details,
+		)
+		if err != nil {
+			return nil, common.ChainError("error reading row", err)
+		}
+
+		event.Timestamp = event.Timestamp.UTC()
+
+		events = append(events, event)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, common.ChainError("error preparing next row", err)
+	}
+
+	return events, nil
+}
+
+// nullUUID converts a nil uuid to a sql null value.
+func nullUUID(ID uuid.UUID) interface{} {
+	if ID == uuid.Nil {
+		return nil
+	}
+	return ID
+}
+
+// nullString converts an empty string to a sql null value.
+func nullString(str string) interface{} {
+	if str == "" {
+		return nil
+	}
+	return str
+}
+
+// nullTime converts a zero time to a sql null value, and any other time to UTC so it compares correctly in drivers that store times as text.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
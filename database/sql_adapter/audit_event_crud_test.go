@@ -0,0 +1,131 @@
+package sqladapter_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditEventCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *AuditEventCRUDTestSuite) createAuditEvent(actorID uuid.UUID, action string, timestamp time.Time) *models.AuditEvent {
+	event := models.CreateNewAuditEvent(action, models.AuditOutcomeSuccess)
+	event.ActorID = actorID
+	event.IPAddress = "127.0.0.1"
+	event.UserAgent = "user agent"
+
+	//the database stores timestamps with microsecond precision
+	event.Timestamp = timestamp.Truncate(time.Microsecond)
+
+	return event
+}
+
+func (suite *AuditEventCRUDTestSuite) saveAuditEvent(event *models.AuditEvent) {
+	err := suite.Tx.SaveAuditEvent(context.Background(), event)
+	suite.Require().NoError(err)
+}
+
+func (suite *AuditEventCRUDTestSuite) TestSaveAuditEvent_WithInvalidAuditEvent_ReturnsError() {
+	//act
+	err := suite.Tx.SaveAuditEvent(context.Background(), models.CreateNewAuditEvent("", ""))
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error", "audit event model")
+}
+
+func (suite *AuditEventCRUDTestSuite) TestGetAuditEvents_WhereNoEventsMatch_ReturnsEmptySlice() {
+	//act
+	events, err := suite.Tx.GetAuditEvents(context.Background(), models.AuditEventFilter{ActorID: uuid.New()})
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(events)
+}
+
+func (suite *AuditEventCRUDTestSuite) TestGetAuditEvents_GetsTheSavedEventWithNilIds() {
+	//arrange
+	event := suite.createAuditEvent(uuid.New(), models.AuditActionLogin, time.Now().UTC())
+	event.Outcome = models.AuditOutcomeFailure
+	event.Details = "details"
+	suite.saveAuditEvent(event)
+
+	//act
+	events, err := suite.Tx.GetAuditEvents(context.Background(), models.AuditEventFilter{ActorID: event.ActorID})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Require().Len(events, 1)
+	suite.EqualValues(event, events[0])
+	suite.Equal(uuid.Nil, events[0].TargetID)
+	suite.Equal(uuid.Nil, events[0].ClientID)
+}
+
+func (suite *AuditEventCRUDTestSuite) TestGetAuditEvents_FiltersByFieldsAndTimeRange() {
+	//arrange
+	actorID := uuid.New()
+	now := time.Now().UTC()
+
+	old := suite.createAuditEvent(actorID, models.AuditActionLogin, now.Add(-time.Hour))
+	match := suite.createAuditEvent(actorID, models.AuditActionLogin, now)
+	otherAction := suite.createAuditEvent(actorID, models.AuditActionTokenIssue, now)
+	otherActor := suite.createAuditEvent(uuid.New(), models.AuditActionLogin, now)
+
+	suite.saveAuditEvent(old)
+	suite.saveAuditEvent(match)
+	suite.saveAuditEvent(otherAction)
+	suite.saveAuditEvent(otherActor)
+
+	filter := models.AuditEventFilter{
+		Action:  models.AuditActionLogin,
+		Outcome: models.AuditOutcomeSuccess,
+		ActorID: actorID,
+		Since:   now.Add(-time.Minute),
+		Until:   now.Add(time.Minute),
+	}
+
+	//act
+	events, err := suite.Tx.GetAuditEvents(context.Background(), filter)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Require().Len(events, 1)
+	suite.EqualValues(match, events[0])
+}
+
+func (suite *AuditEventCRUDTestSuite) TestGetAuditEvents_ReturnsNewestFirstAndPaginates() {
+	//arrange
+	actorID := uuid.New()
+	now := time.Now().UTC()
+
+	events := make([]*models.AuditEvent, 3)
+	for i := range events {
+		events[i] = suite.createAuditEvent(actorID, models.AuditActionLogin, now.Add(time.Duration(-i)*time.Minute))
+		suite.saveAuditEvent(events[i])
+	}
+
+	//act
+	page1, err1 := suite.Tx.GetAuditEvents(context.Background(), models.AuditEventFilter{ActorID: actorID, Limit: 2})
+	page2, err2 := suite.Tx.GetAuditEvents(context.Background(), models.AuditEventFilter{ActorID: actorID, Limit: 2, Offset: 2})
+
+	//assert
+	suite.Require().NoError(err1)
+	suite.Require().NoError(err2)
+
+	suite.Require().Len(page1, 2)
+	suite.EqualValues(events[0], page1[0])
+	suite.EqualValues(events[1], page1[1])
+
+	suite.Require().Len(page2, 1)
+	suite.EqualValues(events[2], page2[0])
+}
+
+func TestAuditEventCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &AuditEventCRUDTestSuite{})
+}
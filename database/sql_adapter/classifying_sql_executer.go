@@ -0,0 +1,62 @@
+package sqladapter
+
+import (
+	"authserver/database"
+	"context"
+	"database/sql"
+)
+
+// ClassifyingSQLExecuter is an implementation of SQLExecuter that wraps the errors returned by the driver in classified database errors.
+// Errors from QueryRowContext are only returned when the row is scanned, so they are not classified.
+type ClassifyingSQLExecuter struct {
+	// SQLExecuter is the executer that actually executes the scripts.
+	SQLExecuter SQLExecuter
+
+	// SQLDriver is used to classify the errors.
+	SQLDriver SQLDriver
+
+	// OnError is called with every classified error. Optional.
+	OnError func(err *database.Error)
+}
+
+// ExecContext executes the sql statement. Returns its result and any classified errors.
+func (e ClassifyingSQLExecuter) ExecContext(ctx context.Context, stmt string, args ...interface{}) (sql.Result, error) {
+	res, err := e.SQLExecuter.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, e.classify(err)
+	}
+
+	return res, nil
+}
+
+// QueryContext executes the sql query. Returns the resulting rows and any classified errors.
+func (e ClassifyingSQLExecuter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := e.SQLExecuter.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, e.classify(err)
+	}
+
+	return rows, nil
+}
+
+// QueryRowContext executes the sql query. Returns the resulting row.
+func (e ClassifyingSQLExecuter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return e.SQLExecuter.QueryRowContext(ctx, query, args...)
+}
+
+func (e ClassifyingSQLExecuter) classify(err error) error {
+	dbErr := classifyError(e.SQLDriver, err)
+	if e.OnError != nil {
+		e.OnError(dbErr)
+	}
+
+	return dbErr
+}
+
+// classifyError wraps the error returned by the driver in a database error with the class the driver gives it.
+func classifyError(driver SQLDriver, err error) *database.Error {
+	return &database.Error{
+		Class: driver.ClassifyError(err),
+		Err:   err,
+	}
+}
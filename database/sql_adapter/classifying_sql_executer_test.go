@@ -0,0 +1,82 @@
+package sqladapter_test
+
+import (
+	"authserver/database"
+	sqladapter "authserver/database/sql_adapter"
+	"authserver/database/sql_adapter/sqlite"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type ClassifyingSQLExecuterTestSuite struct {
+	suite.Suite
+	DB       *sql.DB
+	Errors   []*database.Error
+	Executer sqladapter.ClassifyingSQLExecuter
+}
+
+func (suite *ClassifyingSQLExecuterTestSuite) SetupTest() {
+	var err error
+	suite.DB, err = sql.Open(sqlite.DriverName, "file:"+uuid.New().String()+"?mode=memory&cache=shared")
+	suite.Require().NoError(err)
+
+	_, err = suite.DB.Exec("CREATE TABLE item (name TEXT PRIMARY KEY)")
+	suite.Require().NoError(err)
+
+	suite.Errors = nil
+	suite.Executer = sqladapter.ClassifyingSQLExecuter{
+		SQLExecuter: suite.DB,
+		SQLDriver:   sqlite.Driver{},
+		OnError: func(err *database.Error) {
+			suite.Errors = append(suite.Errors, err)
+		},
+	}
+}
+
+func (suite *ClassifyingSQLExecuterTestSuite) TearDownTest() {
+	suite.DB.Close()
+}
+
+func (suite *ClassifyingSQLExecuterTestSuite) TestExecContext_WithUniqueViolation_ReturnsClassifiedError() {
+	//arrange
+	_, err := suite.DB.Exec("INSERT INTO item (name) VALUES ('name')")
+	suite.Require().NoError(err)
+
+	//act
+	_, err = suite.Executer.ExecContext(context.Background(), "INSERT INTO item (name) VALUES ('name')")
+
+	//assert
+	suite.Require().Error(err)
+	suite.Equal(database.ErrorClassUniqueViolation, database.ClassOf(err))
+	suite.False(database.IsRetryable(err))
+
+	suite.Require().Len(suite.Errors, 1)
+	suite.Equal(database.ErrorClassUniqueViolation, suite.Errors[0].Class)
+}
+
+func (suite *ClassifyingSQLExecuterTestSuite) TestQueryContext_WithInvalidQuery_ReturnsUnknownError() {
+	//act
+	_, err := suite.Executer.QueryContext(context.Background(), "SELECT * FROM missing")
+
+	//assert
+	suite.Require().Error(err)
+	suite.Equal(database.ErrorClassUnknown, database.ClassOf(err))
+	suite.Len(suite.Errors, 1)
+}
+
+func (suite *ClassifyingSQLExecuterTestSuite) TestExecContext_WithNoError_DoesNotCallOnError() {
+	//act
+	_, err := suite.Executer.ExecContext(context.Background(), "INSERT INTO item (name) VALUES ('name')")
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(suite.Errors)
+}
+
+func TestClassifyingSQLExecuterTestSuite(t *testing.T) {
+	suite.Run(t, &ClassifyingSQLExecuterTestSuite{})
+}
@@ -3,6 +3,7 @@ package sqladapter
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -12,14 +13,15 @@ import (
 
 // SaveClient validates the client model is valid and inserts a new row into the client table.
 // Returns any errors.
-func (adapter *SQLAdapter) SaveClient(client *models.Client) error {
+func (adapter *SQLAdapter) SaveClient(ctx context.Context, client *models.Client) error {
 	verr := client.Validate()
 	if verr != models.ValidateClientValid {
 		return errors.New(fmt.Sprint("error validating client model:", verr))
 	}
 
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
-	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveClientScript(), client.ID)
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveClientScript(),
+		client.ID, client.TLSClientAuthSubjectDN)
 	cancel()
 
 	if err != nil {
@@ -31,8 +33,8 @@ func (adapter *SQLAdapter) SaveClient(client *models.Client) error {
 
 // GetClientByID gets the row in the client table with the matching id, and creates a new client model using its data.
 // Returns the model and any errors.
-func (adapter *SQLAdapter) GetClientByID(ID uuid.UUID) (*models.Client, error) {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) GetClientByID(ctx context.Context, ID uuid.UUID) (*models.Client, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetClientByIdScript(), ID)
 	defer cancel()
 
@@ -58,7 +60,7 @@ func readClientData(rows *sql.Rows) (*models.Client, error) {
 
 	//get the result
 	client := &models.Client{}
-	err := rows.Scan(&client.ID)
+	err := rows.Scan(&client.ID, &client.TLSClientAuthSubjectDN)
 	if err != nil {
 		return nil, common.ChainError("error reading row", err)
 	}
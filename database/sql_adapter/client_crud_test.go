@@ -3,6 +3,7 @@ package sqladapter_test
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -20,7 +21,7 @@ func (suite *ClientCRUDTestSuite) TestSaveClient_WithInvalidClient_ReturnsError(
 	}
 
 	//act
-	err := suite.Tx.SaveClient(client)
+	err := suite.Tx.SaveClient(context.Background(), client)
 
 	//assert
 	common.AssertError(&suite.Suite, err, "error", "client model")
@@ -28,7 +29,7 @@ func (suite *ClientCRUDTestSuite) TestSaveClient_WithInvalidClient_ReturnsError(
 
 func (suite *ClientCRUDTestSuite) TestGetClientById_WhereClientNotFound_ReturnsNilClient() {
 	//act
-	client, err := suite.Tx.GetClientByID(uuid.New())
+	client, err := suite.Tx.GetClientByID(context.Background(), uuid.New())
 
 	//assert
 	suite.NoError(err)
@@ -41,7 +42,21 @@ func (suite *ClientCRUDTestSuite) TestGetClientById_GetsTheClientWithId() {
 	suite.SaveClient(suite.Tx, client)
 
 	//act
-	resultClient, err := suite.Tx.GetClientByID(client.ID)
+	resultClient, err := suite.Tx.GetClientByID(context.Background(), client.ID)
+
+	//assert
+	suite.NoError(err)
+	suite.EqualValues(client, resultClient)
+}
+
+func (suite *ClientCRUDTestSuite) TestGetClientById_GetsTheClientWithTLSClientAuthSubjectDN() {
+	//arrange
+	client := models.CreateNewClient()
+	client.TLSClientAuthSubjectDN = "CN=client,O=authserver"
+	suite.SaveClient(suite.Tx, client)
+
+	//act
+	resultClient, err := suite.Tx.GetClientByID(context.Background(), client.ID)
 
 	//assert
 	suite.NoError(err)
@@ -5,6 +5,7 @@ import (
 	sqladapter "authserver/database/sql_adapter"
 	"authserver/dependencies"
 	"authserver/models"
+	"context"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -39,7 +40,7 @@ func (suite *CRUDTestSuite) TearDownSuite() {
 
 func (suite *CRUDTestSuite) SetupTest() {
 	//start a new transaction for every test
-	tx, err := suite.TransactionFactory.CreateTransaction()
+	tx, err := suite.TransactionFactory.CreateTransaction(context.Background())
 	suite.Require().NoError(err)
 
 	suite.Tx = tx.(*sqladapter.SQLTransaction)
@@ -51,22 +52,22 @@ func (suite *CRUDTestSuite) TearDownTest() {
 }
 
 func (suite *CRUDTestSuite) SaveUser(tx *sqladapter.SQLTransaction, user *models.User) {
-	err := tx.SaveUser(user)
+	err := tx.SaveUser(context.Background(), user)
 	suite.Require().NoError(err)
 }
 
 func (suite *CRUDTestSuite) SaveScope(tx *sqladapter.SQLTransaction, scope *models.Scope) {
-	err := tx.SaveScope(scope)
+	err := tx.SaveScope(context.Background(), scope)
 	suite.Require().NoError(err)
 }
 
 func (suite *CRUDTestSuite) SaveClient(tx *sqladapter.SQLTransaction, client *models.Client) {
-	err := tx.SaveClient(client)
+	err := tx.SaveClient(context.Background(), client)
 	suite.Require().NoError(err)
 }
 
 func (suite *CRUDTestSuite) SaveAccessToken(tx *sqladapter.SQLTransaction, token *models.AccessToken) {
-	err := tx.SaveAccessToken(token)
+	err := tx.SaveAccessToken(context.Background(), token)
 	suite.Require().NoError(err)
 }
 
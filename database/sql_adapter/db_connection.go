@@ -3,15 +3,19 @@ package sqladapter
 import (
 	"authserver/common"
 	"authserver/config"
+	"authserver/database/consistency"
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // OpenConnection opens the connection to SQL database server using the fields from the database config.
-// Initializes the adapter's context and cancel function, as well as its db instance.
+// Connections are also opened to any read replicas configured for the database key,
+// and read only queries made outside of a transaction are routed to them.
+// Initializes the adapter's context and cancel function, as well as its db instances.
 // Returns any errors.
 func (DB *SQLDB) OpenConnection() error {
 	//load the database config
@@ -23,22 +27,106 @@ func (DB *SQLDB) OpenConnection() error {
 		return errors.New("no connection string found for database key " + DB.DbKey)
 	}
 
-	DB.context, DB.cancelFunc = context.WithCancel(context.Background())
+	//parse the isolation level
+	isolationLevel, err := parseIsolationLevel(dbConfig.IsolationLevel)
+	if err != nil {
+		return err
+	}
+
+	//the adapter's own requests made with its context, such as pings and the queries of migrations using CreateStandardTimeoutContext, always use the primary
+	DB.context, DB.cancelFunc = context.WithCancel(consistency.WithPrimary(context.Background()))
 	DB.timeout = dbConfig.Timeout
+	DB.isolationLevel = isolationLevel
 
 	//connect to the db
-	db, err := sql.Open(DB.SQLDriver.GetDriverName(), connectionStr)
+	db, err := DB.openDB(connectionStr, dbConfig)
 	if err != nil {
 		return common.ChainError("error opening database connection", err)
 	}
 
+	//connect to the replicas
+	replicas := []*sql.DB{}
+	for _, replicaStr := range dbConfig.ReplicaConnectionStrings[DB.DbKey] {
+		replica, err := DB.openDB(replicaStr, dbConfig)
+		if err != nil {
+			closeDBs(append(replicas, db))
+			return common.ChainError("error opening replica database connection", err)
+		}
+
+		replicas = append(replicas, replica)
+	}
+
 	DB.DB = db
-	DB.SQLExecuter = db
+	DB.Replicas = replicas
+
+	var executer SQLExecuter = db
+	if len(replicas) > 0 {
+		replicaExecuter := &ReplicaSQLExecuter{
+			Primary:                db,
+			PinToPrimaryAfterWrite: dbConfig.PinToPrimaryAfterWrite,
+		}
+		for _, replica := range replicas {
+			replicaExecuter.Replicas = append(replicaExecuter.Replicas, replica)
+		}
 
+		executer = replicaExecuter
+	}
+
+	DB.SQLExecuter = DB.instrumentSQLExecuter(ClassifyingSQLExecuter{
+		SQLExecuter: executer,
+		SQLDriver:   DB.SQLDriver,
+	})
+
+	DB.log().With("replicas", len(replicas)).Info("database connection opened")
 	return nil
 }
 
-// CloseConnection closes the connection to the SQL database server and resets its db instance.
+// openDB opens a sql db with the connection string and configures its connection pool using the database config.
+func (DB *SQLDB) openDB(connectionStr string, dbConfig config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open(DB.SQLDriver.GetDriverName(), connectionStr)
+	if err != nil {
+		return nil, err
+	}
+
+	//configure the connection pool, leaving the database/sql defaults in place of zero values
+	if dbConfig.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	}
+	if dbConfig.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	}
+	if dbConfig.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(time.Duration(dbConfig.ConnMaxLifetime) * time.Millisecond)
+	}
+
+	return db, nil
+}
+
+// parseIsolationLevel parses the isolation level from the database config. An empty level uses the database's default.
+// Returns an error if the level is not valid.
+func parseIsolationLevel(level string) (sql.IsolationLevel, error) {
+	switch level {
+	case "":
+		return sql.LevelDefault, nil
+	case config.IsolationLevelReadCommitted:
+		return sql.LevelReadCommitted, nil
+	case config.IsolationLevelRepeatableRead:
+		return sql.LevelRepeatableRead, nil
+	case config.IsolationLevelSerializable:
+		return sql.LevelSerializable, nil
+	default:
+		return sql.LevelDefault, errors.New("invalid isolation level " + level)
+	}
+}
+
+// closeDBs closes the sql dbs, ignoring any errors.
+func closeDBs(dbs []*sql.DB) {
+	for _, db := range dbs {
+		db.Close()
+	}
+}
+
+// CloseConnection closes the connections to the SQL database server and its replicas, and resets its db instances.
 // The adapter also calls its cancel function to cancel any child requests that may still be running.
 // Niether the adapter's db instance or context should be used after calling this function.
 // Returns any errors.
@@ -48,12 +136,21 @@ func (DB *SQLDB) CloseConnection() error {
 		return common.ChainError("error closing database connection", err)
 	}
 
+	for _, replica := range DB.Replicas {
+		err = replica.Close()
+		if err != nil {
+			return common.ChainError("error closing replica database connection", err)
+		}
+	}
+
 	//cancel any remaining requests that may still be running
 	DB.cancelFunc()
 
 	//clean up resources
 	DB.DB = nil
+	DB.Replicas = nil
 
+	DB.log().Info("database connection closed")
 	return nil
 }
 
@@ -5,8 +5,11 @@ import (
 	"authserver/config"
 	sqladapter "authserver/database/sql_adapter"
 	"authserver/dependencies"
+	"context"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -34,6 +37,19 @@ func (suite *DbConnectionTestSuite) TestOpenConnection_WhereConnectionStringIsNo
 	common.AssertError(&suite.Suite, err, "no connection string", dbKey)
 }
 
+func (suite *DbConnectionTestSuite) TestOpenConnection_WithInvalidIsolationLevel_ReturnsError() {
+	//arrange
+	dbConfig := viper.Get("database").(config.DatabaseConfig)
+	dbConfig.IsolationLevel = "invalid"
+	viper.Set("database", dbConfig)
+
+	//act
+	err := suite.DB.OpenConnection()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "invalid isolation level")
+}
+
 func (suite *DbConnectionTestSuite) TestCloseConnection_WithValidConnection_ReturnsNoError() {
 	//arrange
 	err := suite.DB.OpenConnection()
@@ -47,6 +63,26 @@ func (suite *DbConnectionTestSuite) TestCloseConnection_WithValidConnection_Retu
 	suite.Nil(suite.DB.DB)
 }
 
+func (suite *DbConnectionTestSuite) TestCloseConnection_CancelsTimeoutContextsCreatedFromCallerContexts() {
+	//arrange
+	err := suite.DB.OpenConnection()
+	suite.Require().NoError(err)
+
+	ctx, cancel := suite.DB.CreateTimeoutContext(context.Background())
+	defer cancel()
+
+	//act
+	err = suite.DB.CloseConnection()
+	suite.Require().NoError(err)
+
+	//assert
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		suite.Fail("context was not canceled when the connection was closed")
+	}
+}
+
 func (suite *DbConnectionTestSuite) TestPing_WithValidConnection_ReturnsNoError() {
 	//arrange
 	err := suite.DB.OpenConnection()
@@ -0,0 +1,76 @@
+package sqladapter
+
+import (
+	"authserver/metrics"
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// InstrumentedSQLExecuter is an implementation of SQLExecuter that records how long each script takes to execute.
+type InstrumentedSQLExecuter struct {
+	// SQLExecuter is the executer that actually executes the scripts.
+	SQLExecuter SQLExecuter
+
+	// MetricsRecorder is used to record the script durations.
+	MetricsRecorder metrics.Recorder
+
+	// ScriptNames maps the text of each known script to its name.
+	ScriptNames map[string]string
+}
+
+// ExecContext executes the sql statement and records its duration. Returns its result and any errors.
+func (e InstrumentedSQLExecuter) ExecContext(ctx context.Context, stmt string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	defer e.observe(stmt, start)
+
+	return e.SQLExecuter.ExecContext(ctx, stmt, args...)
+}
+
+// QueryContext executes the sql query and records its duration. Returns the resulting rows and any errors.
+func (e InstrumentedSQLExecuter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	defer e.observe(query, start)
+
+	return e.SQLExecuter.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext executes the sql query and records its duration. Returns the resulting row.
+func (e InstrumentedSQLExecuter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	defer e.observe(query, start)
+
+	return e.SQLExecuter.QueryRowContext(ctx, query, args...)
+}
+
+func (e InstrumentedSQLExecuter) observe(script string, start time.Time) {
+	name, ok := e.ScriptNames[script]
+	if !ok {
+		name = "unknown"
+	}
+
+	e.MetricsRecorder.ObserveQuery(name, time.Since(start))
+}
+
+// CreateScriptNameMap creates a map of the text of each script in the repository to its name.
+// Every method on the repository that ends in "Script" and returns a string is treated as a script.
+func CreateScriptNameMap(repo SQLScriptRepository) map[string]string {
+	names := map[string]string{}
+
+	v := reflect.ValueOf(repo)
+	t := v.Type()
+
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		if !strings.HasSuffix(method.Name, "Script") || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.String {
+			continue
+		}
+
+		script := v.Method(i).Call(nil)[0].String()
+		names[script] = strings.TrimSuffix(method.Name, "Script")
+	}
+
+	return names
+}
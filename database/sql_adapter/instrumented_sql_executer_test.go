@@ -0,0 +1,31 @@
+package sqladapter_test
+
+import (
+	sqladapter "authserver/database/sql_adapter"
+	"authserver/database/sql_adapter/postgres"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InstrumentedSQLExecuterTestSuite struct {
+	suite.Suite
+}
+
+func (suite *InstrumentedSQLExecuterTestSuite) TestCreateScriptNameMap_MapsEveryScriptToItsName() {
+	//arrange
+	driver := postgres.Driver{}
+
+	//act
+	names := sqladapter.CreateScriptNameMap(driver)
+
+	//assert
+	suite.Equal("GetUserById", names[driver.GetUserByIdScript()])
+	suite.Equal("SaveAccessToken", names[driver.SaveAccessTokenScript()])
+	suite.Equal("GetLatestTimestamp", names[driver.GetLatestTimestampScript()])
+	suite.NotContains(names, driver.GetDriverName())
+}
+
+func TestInstrumentedSQLExecuterTestSuite(t *testing.T) {
+	suite.Run(t, &InstrumentedSQLExecuterTestSuite{})
+}
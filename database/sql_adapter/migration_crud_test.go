@@ -5,6 +5,7 @@ import (
 	"authserver/config"
 	sqladapter "authserver/database/sql_adapter"
 	"authserver/dependencies"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -40,7 +41,7 @@ func (suite *MigrationCRUDTestSuite) TearDownSuite() {
 
 func (suite *MigrationCRUDTestSuite) SetupTest() {
 	//start a new transaction for every test
-	tx, err := suite.TransactionFactory.CreateTransaction()
+	tx, err := suite.TransactionFactory.CreateTransaction(context.Background())
 	suite.Require().NoError(err)
 
 	suite.Tx = tx.(*sqladapter.SQLTransaction)
@@ -86,7 +87,7 @@ func (suite *MigrationCRUDTestSuite) TestGetMigrationByTimestamp_FindsMigration(
 func (suite *MigrationCRUDTestSuite) TestGetLatestTimestamp_WithNoLatestTimestamp_ReturnsHasLatestFalse() {
 	//arrange
 	ctx, cancel := suite.TransactionFactory.DB.CreateStandardTimeoutContext()
-	_, err := suite.Tx.SQLExecuter.ExecContext(ctx, `DELETE FROM "migration"`)
+	_, err := suite.Tx.SQLExecuter.ExecContext(ctx, `DELETE FROM migration`)
 	cancel()
 	suite.Require().NoError(err)
 
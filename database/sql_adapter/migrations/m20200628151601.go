@@ -5,6 +5,7 @@ import (
 	"authserver/config"
 	sqladapter "authserver/database/sql_adapter"
 	"authserver/models"
+	"context"
 )
 
 type m20200628151601 struct {
@@ -35,9 +36,10 @@ func (m m20200628151601) Up() error {
 	}
 
 	//add this app as a client
-	err = m.DB.SaveClient(&models.Client{
-		ID: config.GetAppId(),
-	})
+	ctx, cancel = m.DB.CreateStandardTimeoutContext()
+	_, err = m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.SaveAppClientScript(), config.GetAppId())
+	cancel()
+
 	if err != nil {
 		return common.ChainError("error saving app client", err)
 	}
@@ -52,7 +54,7 @@ func (m m20200628151601) Up() error {
 	}
 
 	//add the "all" scope
-	err = m.DB.SaveScope(models.CreateNewScope("all"))
+	err = m.DB.SaveScope(context.Background(), models.CreateNewScope("all"))
 	if err != nil {
 		return common.ChainError("error saving \"all\" scope", err)
 	}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"authserver/common"
+	sqladapter "authserver/database/sql_adapter"
+)
+
+type m20201019120000 struct {
+	DB *sqladapter.SQLDB
+}
+
+func (m m20201019120000) GetTimestamp() string {
+	return "20201019120000"
+}
+
+func (m m20201019120000) Up() error {
+	//add the tls client auth subject dn column to the client table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.AddClientTLSClientAuthSubjectDNColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add client tls client auth subject dn column script", err)
+	}
+
+	return nil
+}
+
+func (m m20201019120000) Down() error {
+	//drop the tls client auth subject dn column from the client table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.DropClientTLSClientAuthSubjectDNColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop client tls client auth subject dn column script", err)
+	}
+
+	return nil
+}
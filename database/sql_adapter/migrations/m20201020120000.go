@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"authserver/common"
+	sqladapter "authserver/database/sql_adapter"
+)
+
+type m20201020120000 struct {
+	DB *sqladapter.SQLDB
+}
+
+func (m m20201020120000) GetTimestamp() string {
+	return "20201020120000"
+}
+
+func (m m20201020120000) Up() error {
+	//add the is admin column to the user table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.AddUserIsAdminColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add user is admin column script", err)
+	}
+
+	//create the audit event table
+	ctx, cancel = m.DB.CreateStandardTimeoutContext()
+	_, err = m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.CreateAuditEventTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create audit event table script", err)
+	}
+
+	return nil
+}
+
+func (m m20201020120000) Down() error {
+	//drop the audit event table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.DropAuditEventTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop audit event table script", err)
+	}
+
+	//drop the is admin column from the user table
+	ctx, cancel = m.DB.CreateStandardTimeoutContext()
+	_, err = m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.DropUserIsAdminColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop user is admin column script", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"authserver/common"
+	sqladapter "authserver/database/sql_adapter"
+)
+
+type m20201021120000 struct {
+	DB *sqladapter.SQLDB
+}
+
+func (m m20201021120000) GetTimestamp() string {
+	return "20201021120000"
+}
+
+func (m m20201021120000) Up() error {
+	//create the outbox event table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.CreateOutboxEventTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create outbox event table script", err)
+	}
+
+	return nil
+}
+
+func (m m20201021120000) Down() error {
+	//drop the outbox event table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.DropOutboxEventTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop outbox event table script", err)
+	}
+
+	return nil
+}
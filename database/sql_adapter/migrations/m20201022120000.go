@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"authserver/common"
+	sqladapter "authserver/database/sql_adapter"
+)
+
+type m20201022120000 struct {
+	DB *sqladapter.SQLDB
+}
+
+func (m m20201022120000) GetTimestamp() string {
+	return "20201022120000"
+}
+
+func (m m20201022120000) Up() error {
+	//create the rate limit bucket table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.CreateRateLimitBucketTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing create rate limit bucket table script", err)
+	}
+
+	return nil
+}
+
+func (m m20201022120000) Down() error {
+	//drop the rate limit bucket table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.DropRateLimitBucketTableScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop rate limit bucket table script", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"authserver/common"
+	sqladapter "authserver/database/sql_adapter"
+)
+
+type m20201023120000 struct {
+	DB *sqladapter.SQLDB
+}
+
+func (m m20201023120000) GetTimestamp() string {
+	return "20201023120000"
+}
+
+func (m m20201023120000) Up() error {
+	//add the is disabled column to the user table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.AddUserIsDisabledColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing add user is disabled column script", err)
+	}
+
+	return nil
+}
+
+func (m m20201023120000) Down() error {
+	//drop the is disabled column from the user table
+	ctx, cancel := m.DB.CreateStandardTimeoutContext()
+	_, err := m.DB.SQLExecuter.ExecContext(ctx, m.DB.SQLDriver.DropUserIsDisabledColumnScript())
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing drop user is disabled column script", err)
+	}
+
+	return nil
+}
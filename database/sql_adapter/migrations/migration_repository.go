@@ -15,5 +15,10 @@ type MigrationRepository struct {
 func (repo MigrationRepository) GetMigrations() []migrationrunner.Migration {
 	return []migrationrunner.Migration{
 		m20200628151601{DB: repo.DB},
+		m20201019120000{DB: repo.DB},
+		m20201020120000{DB: repo.DB},
+		m20201021120000{DB: repo.DB},
+		m20201022120000{DB: repo.DB},
+		m20201023120000{DB: repo.DB},
 	}
 }
@@ -0,0 +1,82 @@
+package mysql
+
+import (
+	"authserver/database"
+	"authserver/database/sql_adapter/mysql/scripts"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+)
+
+// DriverName is the name the mysql driver is registered under.
+const DriverName = "mysql_authserver"
+
+// Mysql error numbers that are classified.
+const (
+	errorNumberLockWaitTimeout = 1205
+	errorNumberDeadlock        = 1213
+	errorNumberDuplicateEntry  = 1062
+)
+
+func init() {
+	sql.Register(DriverName, utcDriver{})
+}
+
+// Driver is an implementation of the SQL Driver interface for mysql 8.0+ and mariadb 10.6+.
+// Uuids are stored as char(36) and times as datetime(6) in UTC. The scripts use positional placeholders,
+// so each script consumes its arguments in the same order the postgres scripts number them.
+type Driver struct {
+	scripts.ScriptRepository
+}
+
+// GetDriverName returns the mysql driver name.
+func (Driver) GetDriverName() string {
+	return DriverName
+}
+
+// ClassifyError returns the database error class of the mysql error using its error number.
+func (Driver) ClassifyError(err error) string {
+	var mysqlErr *mysqldriver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return database.ErrorClassUnknown
+	}
+
+	switch mysqlErr.Number {
+	case errorNumberDeadlock:
+		return database.ErrorClassDeadlock
+	case errorNumberLockWaitTimeout:
+		return database.ErrorClassLockTimeout
+	case errorNumberDuplicateEntry:
+		return database.ErrorClassUniqueViolation
+	default:
+		return database.ErrorClassUnknown
+	}
+}
+
+// utcDriver wraps the mysql driver so datetimes are always parsed into UTC times, regardless of the connection string.
+type utcDriver struct{}
+
+func (d utcDriver) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return connector.Connect(context.Background())
+}
+
+func (utcDriver) OpenConnector(dsn string) (driver.Connector, error) {
+	cfg, err := mysqldriver.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+
+	return mysqldriver.MySQLDriver{}.OpenConnector(cfg.FormatDSN())
+}
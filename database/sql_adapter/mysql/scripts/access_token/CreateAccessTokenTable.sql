@@ -0,0 +1,10 @@
+CREATE TABLE `access_token` (
+	`id` char(36) NOT NULL,
+	`user_id` char(36) NOT NULL,
+	`client_id` char(36) NOT NULL,
+	`scope_id` char(36) NOT NULL,
+	CONSTRAINT `access_token_pk` PRIMARY KEY (`id`),
+	CONSTRAINT `access_token_user_fk` FOREIGN KEY (`user_id`) REFERENCES `user`(`id`) ON DELETE CASCADE,
+	CONSTRAINT `access_token_client_fk` FOREIGN KEY (`client_id`) REFERENCES `client`(`id`) ON DELETE CASCADE,
+	CONSTRAINT `access_token_scope_fk` FOREIGN KEY (`scope_id`) REFERENCES `scope`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB
\ No newline at end of file
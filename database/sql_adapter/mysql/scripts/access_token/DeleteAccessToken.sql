@@ -0,0 +1,2 @@
+DELETE FROM `access_token`
+    WHERE `id` = ?
\ No newline at end of file
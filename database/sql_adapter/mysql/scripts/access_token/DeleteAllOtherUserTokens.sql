@@ -0,0 +1,2 @@
+DELETE FROM `access_token`
+    WHERE `user_id` = ? AND `id` != ?
\ No newline at end of file
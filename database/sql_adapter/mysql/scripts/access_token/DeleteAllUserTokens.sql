@@ -0,0 +1,2 @@
+DELETE FROM `access_token`
+    WHERE `user_id` = ?
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO `access_token` (`id`, `user_id`, `client_id`, `scope_id`)
+	VALUES (?, ?, ?, ?)
\ No newline at end of file
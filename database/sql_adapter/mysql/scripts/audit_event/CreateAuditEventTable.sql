@@ -0,0 +1,14 @@
+CREATE TABLE `audit_event` (
+	`id` char(36) NOT NULL,
+	`timestamp` datetime(6) NOT NULL,
+	`action` varchar(30) NOT NULL,
+	`outcome` varchar(15) NOT NULL,
+	`actor_id` char(36) NULL,
+	`target_id` char(36) NULL,
+	`client_id` char(36) NULL,
+	`ip_address` varchar(45) NOT NULL,
+	`user_agent` varchar(255) NOT NULL,
+	`details` varchar(255) NOT NULL,
+	CONSTRAINT `audit_event_pk` PRIMARY KEY (`id`),
+	INDEX `audit_event_timestamp_idx` (`timestamp`)
+) ENGINE=InnoDB
\ No newline at end of file
@@ -0,0 +1,11 @@
+SELECT e.`id`, e.`timestamp`, e.`action`, e.`outcome`, e.`actor_id`, e.`target_id`, e.`client_id`, e.`ip_address`, e.`user_agent`, e.`details`
+	FROM `audit_event` e
+	WHERE e.`action` = COALESCE(?, e.`action`)
+		AND e.`outcome` = COALESCE(?, e.`outcome`)
+		AND e.`actor_id` <=> COALESCE(?, e.`actor_id`)
+		AND e.`target_id` <=> COALESCE(?, e.`target_id`)
+		AND e.`client_id` <=> COALESCE(?, e.`client_id`)
+		AND COALESCE(e.`timestamp` >= ?, TRUE)
+		AND COALESCE(e.`timestamp` < ?, TRUE)
+	ORDER BY e.`timestamp` DESC, e.`id`
+	LIMIT ? OFFSET ?
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO `audit_event` (`id`, `timestamp`, `action`, `outcome`, `actor_id`, `target_id`, `client_id`, `ip_address`, `user_agent`, `details`)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
\ No newline at end of file
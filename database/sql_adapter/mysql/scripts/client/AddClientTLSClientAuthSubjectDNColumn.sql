@@ -0,0 +1,2 @@
+ALTER TABLE `client`
+	ADD COLUMN `tls_client_auth_subject_dn` varchar(255) NOT NULL DEFAULT ''
\ No newline at end of file
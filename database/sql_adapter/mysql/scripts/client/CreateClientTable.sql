@@ -0,0 +1,4 @@
+CREATE TABLE `client` (
+	`id` char(36) NOT NULL,
+	CONSTRAINT `client_pk` PRIMARY KEY (`id`)
+) ENGINE=InnoDB
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE `client`
\ No newline at end of file
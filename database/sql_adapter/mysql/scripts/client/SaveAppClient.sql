@@ -0,0 +1,2 @@
+INSERT INTO `client` (`id`)
+	VALUES (?)
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO `client` (`id`, `tls_client_auth_subject_dn`)
+	VALUES (?, ?)
\ No newline at end of file
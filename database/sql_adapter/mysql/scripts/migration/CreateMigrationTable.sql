@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS `migration` (
+    `timestamp` char(14) NOT NULL,
+    CONSTRAINT `migration_pk` PRIMARY KEY (`timestamp`)
+) ENGINE=InnoDB
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT m.`timestamp`
+    FROM `migration` m
+    WHERE m.`timestamp` = ?
\ No newline at end of file
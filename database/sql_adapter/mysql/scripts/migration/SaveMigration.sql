@@ -0,0 +1,2 @@
+INSERT INTO `migration` (`timestamp`)
+    VALUES (?)
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE `outbox_event` (
+	`id` char(36) NOT NULL,
+	`type` varchar(50) NOT NULL,
+	`payload` text NOT NULL,
+	`created_at` datetime(6) NOT NULL,
+	`attempts` integer NOT NULL,
+	`next_attempt_at` datetime(6) NOT NULL,
+	`last_error` varchar(255) NOT NULL,
+	`delivered_at` datetime(6) NULL,
+	`dead_lettered` boolean NOT NULL,
+	CONSTRAINT `outbox_event_pk` PRIMARY KEY (`id`),
+	INDEX `outbox_event_pending_idx` (`delivered_at`, `dead_lettered`, `next_attempt_at`)
+) ENGINE=InnoDB
\ No newline at end of file
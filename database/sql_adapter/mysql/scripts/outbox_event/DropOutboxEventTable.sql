@@ -0,0 +1 @@
+DROP TABLE `outbox_event`
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO `outbox_event` (`id`, `type`, `payload`, `created_at`, `attempts`, `next_attempt_at`, `last_error`, `delivered_at`, `dead_lettered`)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
\ No newline at end of file
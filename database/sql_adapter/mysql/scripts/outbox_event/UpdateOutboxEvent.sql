@@ -0,0 +1,8 @@
+UPDATE `outbox_event` e
+    INNER JOIN (SELECT ? AS `id`, ? AS `attempts`, ? AS `next_attempt_at`, ? AS `last_error`, ? AS `delivered_at`, ? AS `dead_lettered`) v ON e.`id` = v.`id`
+SET
+    e.`attempts` = v.`attempts`,
+    e.`next_attempt_at` = v.`next_attempt_at`,
+    e.`last_error` = v.`last_error`,
+    e.`delivered_at` = v.`delivered_at`,
+    e.`dead_lettered` = v.`dead_lettered`
\ No newline at end of file
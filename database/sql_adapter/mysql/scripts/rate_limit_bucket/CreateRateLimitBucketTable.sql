@@ -0,0 +1,7 @@
+CREATE TABLE `rate_limit_bucket` (
+	`key` varchar(64) NOT NULL,
+	`tokens` double NOT NULL,
+	`updated_at` datetime(6) NOT NULL,
+	CONSTRAINT `rate_limit_bucket_pk` PRIMARY KEY (`key`),
+	INDEX `rate_limit_bucket_updated_at_idx` (`updated_at`)
+) ENGINE=InnoDB
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `rate_limit_bucket`
+	WHERE `updated_at` < ?
\ No newline at end of file
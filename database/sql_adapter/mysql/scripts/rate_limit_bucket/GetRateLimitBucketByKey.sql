@@ -0,0 +1,4 @@
+SELECT b.`key`, b.`tokens`, b.`updated_at`
+	FROM `rate_limit_bucket` b
+	WHERE b.`key` = ?
+	FOR UPDATE
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT INTO `rate_limit_bucket` (`key`, `tokens`, `updated_at`)
+	VALUES (?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		`tokens` = VALUES(`tokens`),
+		`updated_at` = VALUES(`updated_at`)
\ No newline at end of file
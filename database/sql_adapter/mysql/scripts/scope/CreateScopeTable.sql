@@ -0,0 +1,6 @@
+CREATE TABLE `scope` (
+	`id` char(36) NOT NULL,
+	`name` varchar(15) NOT NULL,
+	CONSTRAINT `scope_pk` PRIMARY KEY (`id`),
+	CONSTRAINT `scope_name_un` UNIQUE (`name`)
+) ENGINE=InnoDB
\ No newline at end of file
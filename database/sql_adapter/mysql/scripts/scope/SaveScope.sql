@@ -0,0 +1,2 @@
+INSERT INTO `scope` (`id`, `name`)
+	VALUES (?, ?)
\ No newline at end of file
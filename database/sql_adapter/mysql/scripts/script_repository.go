@@ -0,0 +1,506 @@
+// Auto generated. DO NOT EDIT.
+
+package scripts
+
+// ScriptRepository is an implementation of the sql script repository interface that fetches scripts laoded from sql files.
+type ScriptRepository struct {}
+
+// CreateAccessTokenTableScript gets the CreateAccessTokenTable script
+func (ScriptRepository) CreateAccessTokenTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `access_token` + "`" + ` (
+	` + "`" + `id` + "`" + ` char(36) NOT NULL,
+	` + "`" + `user_id` + "`" + ` char(36) NOT NULL,
+	` + "`" + `client_id` + "`" + ` char(36) NOT NULL,
+	` + "`" + `scope_id` + "`" + ` char(36) NOT NULL,
+	CONSTRAINT ` + "`" + `access_token_pk` + "`" + ` PRIMARY KEY (` + "`" + `id` + "`" + `),
+	CONSTRAINT ` + "`" + `access_token_user_fk` + "`" + ` FOREIGN KEY (` + "`" + `user_id` + "`" + `) REFERENCES ` + "`" + `user` + "`" + `(` + "`" + `id` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `access_token_client_fk` + "`" + ` FOREIGN KEY (` + "`" + `client_id` + "`" + `) REFERENCES ` + "`" + `client` + "`" + `(` + "`" + `id` + "`" + `) ON DELETE CASCADE,
+	CONSTRAINT ` + "`" + `access_token_scope_fk` + "`" + ` FOREIGN KEY (` + "`" + `scope_id` + "`" + `) REFERENCES ` + "`" + `scope` + "`" + `(` + "`" + `id` + "`" + `) ON DELETE CASCADE
+) ENGINE=InnoDB
+`
+}
+
+// DeleteAccessTokenScript gets the DeleteAccessToken script
+func (ScriptRepository) DeleteAccessTokenScript() string {
+	return `
+DELETE FROM ` + "`" + `access_token` + "`" + `
+    WHERE ` + "`" + `id` + "`" + ` = ?
+`
+}
+
+// DeleteAllOtherUserTokensScript gets the DeleteAllOtherUserTokens script
+func (ScriptRepository) DeleteAllOtherUserTokensScript() string {
+	return `
+DELETE FROM ` + "`" + `access_token` + "`" + `
+    WHERE ` + "`" + `user_id` + "`" + ` = ? AND ` + "`" + `id` + "`" + ` != ?
+`
+}
+
+// DeleteAllUserTokensScript gets the DeleteAllUserTokens script
+func (ScriptRepository) DeleteAllUserTokensScript() string {
+	return `
+DELETE FROM ` + "`" + `access_token` + "`" + `
+    WHERE ` + "`" + `user_id` + "`" + ` = ?
+`
+}
+
+// DropAccessTokenTableScript gets the DropAccessTokenTable script
+func (ScriptRepository) DropAccessTokenTableScript() string {
+	return `
+DROP TABLE ` + "`" + `access_token` + "`" + `
+`
+}
+
+// GetAccessTokenByIdScript gets the GetAccessTokenById script
+func (ScriptRepository) GetAccessTokenByIdScript() string {
+	return `
+SELECT
+    tk.` + "`" + `id` + "`" + `,
+    u.` + "`" + `id` + "`" + `, u.` + "`" + `username` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `is_admin` + "`" + `, u.` + "`" + `is_disabled` + "`" + `,
+    c.` + "`" + `id` + "`" + `,
+    s.` + "`" + `id` + "`" + `, s.` + "`" + `name` + "`" + `
+FROM ` + "`" + `access_token` + "`" + ` tk
+    INNER JOIN ` + "`" + `user` + "`" + ` u ON u.` + "`" + `id` + "`" + ` = tk.` + "`" + `user_id` + "`" + `
+    INNER JOIN ` + "`" + `client` + "`" + ` c ON c.` + "`" + `id` + "`" + ` = tk.` + "`" + `client_id` + "`" + `
+    INNER JOIN ` + "`" + `scope` + "`" + ` s ON s.` + "`" + `id` + "`" + ` = tk.` + "`" + `scope_id` + "`" + `
+WHERE tk.` + "`" + `id` + "`" + ` = ?
+`
+}
+
+// SaveAccessTokenScript gets the SaveAccessToken script
+func (ScriptRepository) SaveAccessTokenScript() string {
+	return `
+INSERT INTO ` + "`" + `access_token` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `user_id` + "`" + `, ` + "`" + `client_id` + "`" + `, ` + "`" + `scope_id` + "`" + `)
+	VALUES (?, ?, ?, ?)
+`
+}
+
+// CreateAuditEventTableScript gets the CreateAuditEventTable script
+func (ScriptRepository) CreateAuditEventTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `audit_event` + "`" + ` (
+	` + "`" + `id` + "`" + ` char(36) NOT NULL,
+	` + "`" + `timestamp` + "`" + ` datetime(6) NOT NULL,
+	` + "`" + `action` + "`" + ` varchar(30) NOT NULL,
+	` + "`" + `outcome` + "`" + ` varchar(15) NOT NULL,
+	` + "`" + `actor_id` + "`" + ` char(36) NULL,
+	` + "`" + `target_id` + "`" + ` char(36) NULL,
+	` + "`" + `client_id` + "`" + ` char(36) NULL,
+	` + "`" + `ip_address` + "`" + ` varchar(45) NOT NULL,
+	` + "`" + `user_agent` + "`" + ` varchar(255) NOT NULL,
+	` + "`" + `details` + "`" + ` varchar(255) NOT NULL,
+	CONSTRAINT ` + "`" + `audit_event_pk` + "`" + ` PRIMARY KEY (` + "`" + `id` + "`" + `),
+	INDEX ` + "`" + `audit_event_timestamp_idx` + "`" + ` (` + "`" + `timestamp` + "`" + `)
+) ENGINE=InnoDB
+`
+}
+
+// DropAuditEventTableScript gets the DropAuditEventTable script
+func (ScriptRepository) DropAuditEventTableScript() string {
+	return `
+DROP TABLE ` + "`" + `audit_event` + "`" + `
+`
+}
+
+// GetAuditEventsScript gets the GetAuditEvents script
+func (ScriptRepository) GetAuditEventsScript() string {
+	return `
+SELECT e.` + "`" + `id` + "`" + `, e.` + "`" + `timestamp` + "`" + `, e.` + "`" + `action` + "`" + `, e.` + "`" + `outcome` + "`" + `, e.` + "`" + `actor_id` + "`" + `, e.` + "`" + `target_id` + "`" + `, e.` + "`" + `client_id` + "`" + `, e.` + "`" + `ip_address` + "`" + `, e.` + "`" + `user_agent` + "`" + `, e.` + "`" + `details` + "`" + `
+	FROM ` + "`" + `audit_event` + "`" + ` e
+	WHERE e.` + "`" + `action` + "`" + ` = COALESCE(?, e.` + "`" + `action` + "`" + `)
+		AND e.` + "`" + `outcome` + "`" + ` = COALESCE(?, e.` + "`" + `outcome` + "`" + `)
+		AND e.` + "`" + `actor_id` + "`" + ` <=> COALESCE(?, e.` + "`" + `actor_id` + "`" + `)
+		AND e.` + "`" + `target_id` + "`" + ` <=> COALESCE(?, e.` + "`" + `target_id` + "`" + `)
+		AND e.` + "`" + `client_id` + "`" + ` <=> COALESCE(?, e.` + "`" + `client_id` + "`" + `)
+		AND COALESCE(e.` + "`" + `timestamp` + "`" + ` >= ?, TRUE)
+		AND COALESCE(e.` + "`" + `timestamp` + "`" + ` < ?, TRUE)
+	ORDER BY e.` + "`" + `timestamp` + "`" + ` DESC, e.` + "`" + `id` + "`" + `
+	LIMIT ? OFFSET ?
+`
+}
+
+// SaveAuditEventScript gets the SaveAuditEvent script
+func (ScriptRepository) SaveAuditEventScript() string {
+	return `
+INSERT INTO ` + "`" + `audit_event` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `timestamp` + "`" + `, ` + "`" + `action` + "`" + `, ` + "`" + `outcome` + "`" + `, ` + "`" + `actor_id` + "`" + `, ` + "`" + `target_id` + "`" + `, ` + "`" + `client_id` + "`" + `, ` + "`" + `ip_address` + "`" + `, ` + "`" + `user_agent` + "`" + `, ` + "`" + `details` + "`" + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+}
+
+// AddClientTLSClientAuthSubjectDNColumnScript gets the AddClientTLSClientAuthSubjectDNColumn script
+func (ScriptRepository) AddClientTLSClientAuthSubjectDNColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `client` + "`" + `
+	ADD COLUMN ` + "`" + `tls_client_auth_subject_dn` + "`" + ` varchar(255) NOT NULL DEFAULT ''
+`
+}
+
+// CreateClientTableScript gets the CreateClientTable script
+func (ScriptRepository) CreateClientTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `client` + "`" + ` (
+	` + "`" + `id` + "`" + ` char(36) NOT NULL,
+	CONSTRAINT ` + "`" + `client_pk` + "`" + ` PRIMARY KEY (` + "`" + `id` + "`" + `)
+) ENGINE=InnoDB
+`
+}
+
+// DropClientTLSClientAuthSubjectDNColumnScript gets the DropClientTLSClientAuthSubjectDNColumn script
+func (ScriptRepository) DropClientTLSClientAuthSubjectDNColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `client` + "`" + `
+	DROP COLUMN ` + "`" + `tls_client_auth_subject_dn` + "`" + `
+`
+}
+
+// DropClientTableScript gets the DropClientTable script
+func (ScriptRepository) DropClientTableScript() string {
+	return `
+DROP TABLE ` + "`" + `client` + "`" + `
+`
+}
+
+// GetClientByIdScript gets the GetClientById script
+func (ScriptRepository) GetClientByIdScript() string {
+	return `
+SELECT c.` + "`" + `id` + "`" + `, c.` + "`" + `tls_client_auth_subject_dn` + "`" + `
+	FROM ` + "`" + `client` + "`" + ` c
+	WHERE c.` + "`" + `id` + "`" + ` = ?
+`
+}
+
+// SaveAppClientScript gets the SaveAppClient script
+func (ScriptRepository) SaveAppClientScript() string {
+	return `
+INSERT INTO ` + "`" + `client` + "`" + ` (` + "`" + `id` + "`" + `)
+	VALUES (?)
+`
+}
+
+// SaveClientScript gets the SaveClient script
+func (ScriptRepository) SaveClientScript() string {
+	return `
+INSERT INTO ` + "`" + `client` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `tls_client_auth_subject_dn` + "`" + `)
+	VALUES (?, ?)
+`
+}
+
+// CreateMigrationTableScript gets the CreateMigrationTable script
+func (ScriptRepository) CreateMigrationTableScript() string {
+	return `
+CREATE TABLE IF NOT EXISTS ` + "`" + `migration` + "`" + ` (
+    ` + "`" + `timestamp` + "`" + ` char(14) NOT NULL,
+    CONSTRAINT ` + "`" + `migration_pk` + "`" + ` PRIMARY KEY (` + "`" + `timestamp` + "`" + `)
+) ENGINE=InnoDB
+`
+}
+
+// DeleteMigrationByTimestampScript gets the DeleteMigrationByTimestamp script
+func (ScriptRepository) DeleteMigrationByTimestampScript() string {
+	return `
+DELETE FROM ` + "`" + `migration` + "`" + `
+   WHERE ` + "`" + `timestamp` + "`" + ` = ?
+`
+}
+
+// GetLatestTimestampScript gets the GetLatestTimestamp script
+func (ScriptRepository) GetLatestTimestampScript() string {
+	return `
+SELECT m.` + "`" + `timestamp` + "`" + ` FROM ` + "`" + `migration` + "`" + ` m
+    ORDER BY m.` + "`" + `timestamp` + "`" + ` DESC
+    LIMIT 1
+`
+}
+
+// GetMigrationByTimestampScript gets the GetMigrationByTimestamp script
+func (ScriptRepository) GetMigrationByTimestampScript() string {
+	return `
+SELECT m.` + "`" + `timestamp` + "`" + `
+    FROM ` + "`" + `migration` + "`" + ` m
+    WHERE m.` + "`" + `timestamp` + "`" + ` = ?
+`
+}
+
+// SaveMigrationScript gets the SaveMigration script
+func (ScriptRepository) SaveMigrationScript() string {
+	return `
+INSERT INTO ` + "`" + `migration` + "`" + ` (` + "`" + `timestamp` + "`" + `)
+    VALUES (?)
+`
+}
+
+// CreateOutboxEventTableScript gets the CreateOutboxEventTable script
+func (ScriptRepository) CreateOutboxEventTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `outbox_event` + "`" + ` (
+	` + "`" + `id` + "`" + ` char(36) NOT NULL,
+	` + "`" + `type` + "`" + ` varchar(50) NOT NULL,
+	` + "`" + `payload` + "`" + ` text NOT NULL,
+	` + "`" + `created_at` + "`" + ` datetime(6) NOT NULL,
+	` + "`" + `attempts` + "`" + ` integer NOT NULL,
+	` + "`" + `next_attempt_at` + "`" + ` datetime(6) NOT NULL,
+	` + "`" + `last_error` + "`" + ` varchar(255) NOT NULL,
+	` + "`" + `delivered_at` + "`" + ` datetime(6) NULL,
+	` + "`" + `dead_lettered` + "`" + ` boolean NOT NULL,
+	CONSTRAINT ` + "`" + `outbox_event_pk` + "`" + ` PRIMARY KEY (` + "`" + `id` + "`" + `),
+	INDEX ` + "`" + `outbox_event_pending_idx` + "`" + ` (` + "`" + `delivered_at` + "`" + `, ` + "`" + `dead_lettered` + "`" + `, ` + "`" + `next_attempt_at` + "`" + `)
+) ENGINE=InnoDB
+`
+}
+
+// DropOutboxEventTableScript gets the DropOutboxEventTable script
+func (ScriptRepository) DropOutboxEventTableScript() string {
+	return `
+DROP TABLE ` + "`" + `outbox_event` + "`" + `
+`
+}
+
+// GetDeadLetteredOutboxEventsScript gets the GetDeadLetteredOutboxEvents script
+func (ScriptRepository) GetDeadLetteredOutboxEventsScript() string {
+	return `
+SELECT e.` + "`" + `id` + "`" + `, e.` + "`" + `type` + "`" + `, e.` + "`" + `payload` + "`" + `, e.` + "`" + `created_at` + "`" + `, e.` + "`" + `attempts` + "`" + `, e.` + "`" + `next_attempt_at` + "`" + `, e.` + "`" + `last_error` + "`" + `, e.` + "`" + `delivered_at` + "`" + `, e.` + "`" + `dead_lettered` + "`" + `
+	FROM ` + "`" + `outbox_event` + "`" + ` e
+	WHERE e.` + "`" + `dead_lettered` + "`" + `
+	ORDER BY e.` + "`" + `created_at` + "`" + ` DESC, e.` + "`" + `id` + "`" + `
+	LIMIT ? OFFSET ?
+`
+}
+
+// GetPendingOutboxEventsScript gets the GetPendingOutboxEvents script
+func (ScriptRepository) GetPendingOutboxEventsScript() string {
+	return `
+SELECT e.` + "`" + `id` + "`" + `, e.` + "`" + `type` + "`" + `, e.` + "`" + `payload` + "`" + `, e.` + "`" + `created_at` + "`" + `, e.` + "`" + `attempts` + "`" + `, e.` + "`" + `next_attempt_at` + "`" + `, e.` + "`" + `last_error` + "`" + `, e.` + "`" + `delivered_at` + "`" + `, e.` + "`" + `dead_lettered` + "`" + `
+	FROM ` + "`" + `outbox_event` + "`" + ` e
+	WHERE e.` + "`" + `delivered_at` + "`" + ` IS NULL AND NOT e.` + "`" + `dead_lettered` + "`" + ` AND e.` + "`" + `next_attempt_at` + "`" + ` <= ?
+	ORDER BY e.` + "`" + `next_attempt_at` + "`" + `, e.` + "`" + `id` + "`" + `
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+`
+}
+
+// SaveOutboxEventScript gets the SaveOutboxEvent script
+func (ScriptRepository) SaveOutboxEventScript() string {
+	return `
+INSERT INTO ` + "`" + `outbox_event` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `type` + "`" + `, ` + "`" + `payload` + "`" + `, ` + "`" + `created_at` + "`" + `, ` + "`" + `attempts` + "`" + `, ` + "`" + `next_attempt_at` + "`" + `, ` + "`" + `last_error` + "`" + `, ` + "`" + `delivered_at` + "`" + `, ` + "`" + `dead_lettered` + "`" + `)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+}
+
+// UpdateOutboxEventScript gets the UpdateOutboxEvent script
+func (ScriptRepository) UpdateOutboxEventScript() string {
+	return `
+UPDATE ` + "`" + `outbox_event` + "`" + ` e
+    INNER JOIN (SELECT ? AS ` + "`" + `id` + "`" + `, ? AS ` + "`" + `attempts` + "`" + `, ? AS ` + "`" + `next_attempt_at` + "`" + `, ? AS ` + "`" + `last_error` + "`" + `, ? AS ` + "`" + `delivered_at` + "`" + `, ? AS ` + "`" + `dead_lettered` + "`" + `) v ON e.` + "`" + `id` + "`" + ` = v.` + "`" + `id` + "`" + `
+SET
+    e.` + "`" + `attempts` + "`" + ` = v.` + "`" + `attempts` + "`" + `,
+    e.` + "`" + `next_attempt_at` + "`" + ` = v.` + "`" + `next_attempt_at` + "`" + `,
+    e.` + "`" + `last_error` + "`" + ` = v.` + "`" + `last_error` + "`" + `,
+    e.` + "`" + `delivered_at` + "`" + ` = v.` + "`" + `delivered_at` + "`" + `,
+    e.` + "`" + `dead_lettered` + "`" + ` = v.` + "`" + `dead_lettered` + "`" + `
+`
+}
+
+// CreateRateLimitBucketTableScript gets the CreateRateLimitBucketTable script
+func (ScriptRepository) CreateRateLimitBucketTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `rate_limit_bucket` + "`" + ` (
+	` + "`" + `key` + "`" + ` varchar(64) NOT NULL,
+	` + "`" + `tokens` + "`" + ` double NOT NULL,
+	` + "`" + `updated_at` + "`" + ` datetime(6) NOT NULL,
+	CONSTRAINT ` + "`" + `rate_limit_bucket_pk` + "`" + ` PRIMARY KEY (` + "`" + `key` + "`" + `),
+	INDEX ` + "`" + `rate_limit_bucket_updated_at_idx` + "`" + ` (` + "`" + `updated_at` + "`" + `)
+) ENGINE=InnoDB
+`
+}
+
+// DeleteRateLimitBucketsUpdatedBeforeScript gets the DeleteRateLimitBucketsUpdatedBefore script
+func (ScriptRepository) DeleteRateLimitBucketsUpdatedBeforeScript() string {
+	return `
+DELETE FROM ` + "`" + `rate_limit_bucket` + "`" + `
+	WHERE ` + "`" + `updated_at` + "`" + ` < ?
+`
+}
+
+// DropRateLimitBucketTableScript gets the DropRateLimitBucketTable script
+func (ScriptRepository) DropRateLimitBucketTableScript() string {
+	return `
+DROP TABLE ` + "`" + `rate_limit_bucket` + "`" + `
+`
+}
+
+// GetRateLimitBucketByKeyScript gets the GetRateLimitBucketByKey script
+func (ScriptRepository) GetRateLimitBucketByKeyScript() string {
+	return `
+SELECT b.` + "`" + `key` + "`" + `, b.` + "`" + `tokens` + "`" + `, b.` + "`" + `updated_at` + "`" + `
+	FROM ` + "`" + `rate_limit_bucket` + "`" + ` b
+	WHERE b.` + "`" + `key` + "`" + ` = ?
+	FOR UPDATE
+`
+}
+
+// SaveRateLimitBucketScript gets the SaveRateLimitBucket script
+func (ScriptRepository) SaveRateLimitBucketScript() string {
+	return `
+INSERT INTO ` + "`" + `rate_limit_bucket` + "`" + ` (` + "`" + `key` + "`" + `, ` + "`" + `tokens` + "`" + `, ` + "`" + `updated_at` + "`" + `)
+	VALUES (?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		` + "`" + `tokens` + "`" + ` = VALUES(` + "`" + `tokens` + "`" + `),
+		` + "`" + `updated_at` + "`" + ` = VALUES(` + "`" + `updated_at` + "`" + `)
+`
+}
+
+// CreateScopeTableScript gets the CreateScopeTable script
+func (ScriptRepository) CreateScopeTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `scope` + "`" + ` (
+	` + "`" + `id` + "`" + ` char(36) NOT NULL,
+	` + "`" + `name` + "`" + ` varchar(15) NOT NULL,
+	CONSTRAINT ` + "`" + `scope_pk` + "`" + ` PRIMARY KEY (` + "`" + `id` + "`" + `),
+	CONSTRAINT ` + "`" + `scope_name_un` + "`" + ` UNIQUE (` + "`" + `name` + "`" + `)
+) ENGINE=InnoDB
+`
+}
+
+// DropScopeTableScript gets the DropScopeTable script
+func (ScriptRepository) DropScopeTableScript() string {
+	return `
+DROP TABLE ` + "`" + `scope` + "`" + `
+`
+}
+
+// GetScopeByNameScript gets the GetScopeByName script
+func (ScriptRepository) GetScopeByNameScript() string {
+	return `
+SELECT s.` + "`" + `id` + "`" + `, s.` + "`" + `name` + "`" + `
+	FROM ` + "`" + `scope` + "`" + ` s
+	WHERE s.` + "`" + `name` + "`" + ` = ?
+`
+}
+
+// SaveScopeScript gets the SaveScope script
+func (ScriptRepository) SaveScopeScript() string {
+	return `
+INSERT INTO ` + "`" + `scope` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `name` + "`" + `)
+	VALUES (?, ?)
+`
+}
+
+// AddUserIsAdminColumnScript gets the AddUserIsAdminColumn script
+func (ScriptRepository) AddUserIsAdminColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	ADD COLUMN ` + "`" + `is_admin` + "`" + ` boolean NOT NULL DEFAULT false
+`
+}
+
+// AddUserIsDisabledColumnScript gets the AddUserIsDisabledColumn script
+func (ScriptRepository) AddUserIsDisabledColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	ADD COLUMN ` + "`" + `is_disabled` + "`" + ` boolean NOT NULL DEFAULT false
+`
+}
+
+// CountUsersScript gets the CountUsers script
+func (ScriptRepository) CountUsersScript() string {
+	return `
+SELECT COUNT(*)
+	FROM ` + "`" + `user` + "`" + ` u
+	WHERE COALESCE(LOCATE(LOWER(?), LOWER(u.` + "`" + `username` + "`" + `)) > 0, TRUE)
+`
+}
+
+// CreateUserTableScript gets the CreateUserTable script
+func (ScriptRepository) CreateUserTableScript() string {
+	return `
+CREATE TABLE ` + "`" + `user` + "`" + ` (
+	` + "`" + `id` + "`" + ` char(36) NOT NULL,
+	` + "`" + `username` + "`" + ` varchar(30) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+	` + "`" + `password_hash` + "`" + ` blob NOT NULL,
+	CONSTRAINT ` + "`" + `user_pk` + "`" + ` PRIMARY KEY (` + "`" + `id` + "`" + `),
+	CONSTRAINT ` + "`" + `user_username_un` + "`" + ` UNIQUE (` + "`" + `username` + "`" + `)
+) ENGINE=InnoDB
+`
+}
+
+// DeleteUserScript gets the DeleteUser script
+func (ScriptRepository) DeleteUserScript() string {
+	return `
+DELETE FROM ` + "`" + `user` + "`" + `
+    WHERE ` + "`" + `id` + "`" + ` = ?
+`
+}
+
+// DropUserIsAdminColumnScript gets the DropUserIsAdminColumn script
+func (ScriptRepository) DropUserIsAdminColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	DROP COLUMN ` + "`" + `is_admin` + "`" + `
+`
+}
+
+// DropUserIsDisabledColumnScript gets the DropUserIsDisabledColumn script
+func (ScriptRepository) DropUserIsDisabledColumnScript() string {
+	return `
+ALTER TABLE ` + "`" + `user` + "`" + `
+	DROP COLUMN ` + "`" + `is_disabled` + "`" + `
+`
+}
+
+// DropUserTableScript gets the DropUserTable script
+func (ScriptRepository) DropUserTableScript() string {
+	return `
+DROP TABLE ` + "`" + `user` + "`" + `
+`
+}
+
+// GetUserByIdScript gets the GetUserById script
+func (ScriptRepository) GetUserByIdScript() string {
+	return `
+SELECT u.` + "`" + `id` + "`" + `, u.` + "`" + `username` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `is_admin` + "`" + `, u.` + "`" + `is_disabled` + "`" + `
+	FROM ` + "`" + `user` + "`" + ` u
+	WHERE u.` + "`" + `id` + "`" + ` = ?
+`
+}
+
+// GetUserByUsernameScript gets the GetUserByUsername script
+func (ScriptRepository) GetUserByUsernameScript() string {
+	return `
+SELECT u.` + "`" + `id` + "`" + `, u.` + "`" + `username` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `is_admin` + "`" + `, u.` + "`" + `is_disabled` + "`" + `
+	FROM ` + "`" + `user` + "`" + ` u
+	WHERE u.` + "`" + `username` + "`" + ` = ?
+`
+}
+
+// GetUsersScript gets the GetUsers script
+func (ScriptRepository) GetUsersScript() string {
+	return `
+SELECT u.` + "`" + `id` + "`" + `, u.` + "`" + `username` + "`" + `, u.` + "`" + `password_hash` + "`" + `, u.` + "`" + `is_admin` + "`" + `, u.` + "`" + `is_disabled` + "`" + `
+	FROM ` + "`" + `user` + "`" + ` u
+	WHERE COALESCE(LOCATE(LOWER(?), LOWER(u.` + "`" + `username` + "`" + `)) > 0, TRUE)
+	ORDER BY u.` + "`" + `username` + "`" + `, u.` + "`" + `id` + "`" + `
+	LIMIT ? OFFSET ?
+`
+}
+
+// SaveUserScript gets the SaveUser script
+func (ScriptRepository) SaveUserScript() string {
+	return `
+INSERT INTO ` + "`" + `user` + "`" + ` (` + "`" + `id` + "`" + `, ` + "`" + `username` + "`" + `, ` + "`" + `password_hash` + "`" + `, ` + "`" + `is_admin` + "`" + `, ` + "`" + `is_disabled` + "`" + `)
+	VALUES (?, ?, ?, ?, ?)
+`
+}
+
+// UpdateUserScript gets the UpdateUser script
+func (ScriptRepository) UpdateUserScript() string {
+	return `
+UPDATE ` + "`" + `user` + "`" + ` u
+    INNER JOIN (SELECT ? AS ` + "`" + `id` + "`" + `, ? AS ` + "`" + `username` + "`" + `, ? AS ` + "`" + `password_hash` + "`" + `, ? AS ` + "`" + `is_admin` + "`" + `, ? AS ` + "`" + `is_disabled` + "`" + `) v ON u.` + "`" + `id` + "`" + ` = v.` + "`" + `id` + "`" + `
+SET
+    u.` + "`" + `username` + "`" + ` = v.` + "`" + `username` + "`" + `,
+    u.` + "`" + `password_hash` + "`" + ` = v.` + "`" + `password_hash` + "`" + `,
+    u.` + "`" + `is_admin` + "`" + ` = v.` + "`" + `is_admin` + "`" + `,
+    u.` + "`" + `is_disabled` + "`" + ` = v.` + "`" + `is_disabled` + "`" + `
+`
+}
@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+	FROM `user` u
+	WHERE COALESCE(LOCATE(LOWER(?), LOWER(u.`username`)) > 0, TRUE)
\ No newline at end of file
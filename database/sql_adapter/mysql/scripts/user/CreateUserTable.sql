@@ -0,0 +1,7 @@
+CREATE TABLE `user` (
+	`id` char(36) NOT NULL,
+	`username` varchar(30) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL,
+	`password_hash` blob NOT NULL,
+	CONSTRAINT `user_pk` PRIMARY KEY (`id`),
+	CONSTRAINT `user_username_un` UNIQUE (`username`)
+) ENGINE=InnoDB
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM `user`
+    WHERE `id` = ?
\ No newline at end of file
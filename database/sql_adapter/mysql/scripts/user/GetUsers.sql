@@ -0,0 +1,5 @@
+SELECT u.`id`, u.`username`, u.`password_hash`, u.`is_admin`, u.`is_disabled`
+	FROM `user` u
+	WHERE COALESCE(LOCATE(LOWER(?), LOWER(u.`username`)) > 0, TRUE)
+	ORDER BY u.`username`, u.`id`
+	LIMIT ? OFFSET ?
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO `user` (`id`, `username`, `password_hash`, `is_admin`, `is_disabled`)
+	VALUES (?, ?, ?, ?, ?)
\ No newline at end of file
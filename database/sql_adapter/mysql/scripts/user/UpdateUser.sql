@@ -0,0 +1,7 @@
+UPDATE `user` u
+    INNER JOIN (SELECT ? AS `id`, ? AS `username`, ? AS `password_hash`, ? AS `is_admin`, ? AS `is_disabled`) v ON u.`id` = v.`id`
+SET
+    u.`username` = v.`username`,
+    u.`password_hash` = v.`password_hash`,
+    u.`is_admin` = v.`is_admin`,
+    u.`is_disabled` = v.`is_disabled`
\ No newline at end of file
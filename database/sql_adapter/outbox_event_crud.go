@@ -0,0 +1,118 @@
+package sqladapter
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SaveOutboxEvent validates the outbox event model is valid and inserts a new row into the outbox_event table.
+// Returns any errors.
+func (adapter *SQLAdapter) SaveOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	verr := event.Validate()
+	if verr != models.ValidateOutboxEventValid {
+		return errors.New(fmt.Sprint("error validating outbox event model:", verr))
+	}
+
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveOutboxEventScript(),
+		event.ID, event.Type, event.Payload, event.CreatedAt, event.Attempts, event.NextAttemptAt,
+		event.LastError, nullTime(event.DeliveredAt), event.DeadLettered)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save outbox event statement", err)
+	}
+
+	return nil
+}
+
+// GetPendingOutboxEvents gets up to limit rows in the outbox_event table that are undelivered and due for an attempt at the given time,
+// and creates new outbox event models using their data. The rows are locked until the transaction ends.
+// Returns the models and any errors.
+func (adapter *SQLAdapter) GetPendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*models.OutboxEvent, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetPendingOutboxEventsScript(), now, limit)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get pending outbox events query", err)
+	}
+	defer rows.Close()
+
+	return readOutboxEventsData(rows)
+}
+
+// GetDeadLetteredOutboxEvents gets the dead lettered rows in the outbox_event table, newest first, and creates new outbox event models using their data.
+// The filter's limit and offset are normalized before being used.
+// Returns the models and any errors.
+func (adapter *SQLAdapter) GetDeadLetteredOutboxEvents(ctx context.Context, filter models.OutboxEventFilter) ([]*models.OutboxEvent, error) {
+	filter.Normalize()
+
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetDeadLetteredOutboxEventsScript(), filter.Limit, filter.Offset)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get dead lettered outbox events query", err)
+	}
+	defer rows.Close()
+
+	return readOutboxEventsData(rows)
+}
+
+// UpdateOutboxEvent validates the outbox event model is valid and updates the delivery state of the row in the outbox_event table with the matching id.
+// Returns any errors.
+func (adapter *SQLAdapter) UpdateOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	verr := event.Validate()
+	if verr != models.ValidateOutboxEventValid {
+		return errors.New(fmt.Sprint("error validating outbox event model:", verr))
+	}
+
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.UpdateOutboxEventScript(),
+		event.ID, event.Attempts, event.NextAttemptAt, event.LastError, nullTime(event.DeliveredAt), event.DeadLettered)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing update outbox event statement", err)
+	}
+
+	return nil
+}
+
+func readOutboxEventsData(rows *sql.Rows) ([]*models.OutboxEvent, error) {
+	events := []*models.OutboxEvent{}
+
+	for rows.Next() {
+		event := &models.OutboxEvent{}
+		var deliveredAt sql.NullTime
+
+		err := rows.Scan(
+			&event.ID, &event.Type, &event.Payload, &event.CreatedAt, &event.Attempts,
+			&event.NextAttemptAt, &event.LastError, &deliveredAt, &event.DeadLettered,
+		)
+		if err != nil {
+			return nil, common.ChainError("error reading row", err)
+		}
+
+		event.CreatedAt = event.CreatedAt.UTC()
+		event.NextAttemptAt = event.NextAttemptAt.UTC()
+		if deliveredAt.Valid {
+			event.DeliveredAt = deliveredAt.Time.UTC()
+		}
+
+		events = append(events, event)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, common.ChainError("error preparing next row", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,143 @@
+package sqladapter_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxEventCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *OutboxEventCRUDTestSuite) createOutboxEvent(nextAttemptAt time.Time) *models.OutboxEvent {
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, `{"key":"value"}`)
+
+	//the database stores timestamps with microsecond precision
+	event.CreatedAt = event.CreatedAt.Truncate(time.Microsecond)
+	event.NextAttemptAt = nextAttemptAt.Truncate(time.Microsecond)
+
+	return event
+}
+
+func (suite *OutboxEventCRUDTestSuite) saveOutboxEvent(event *models.OutboxEvent) {
+	err := suite.Tx.SaveOutboxEvent(context.Background(), event)
+	suite.Require().NoError(err)
+}
+
+func (suite *OutboxEventCRUDTestSuite) TestSaveOutboxEvent_WithInvalidOutboxEvent_ReturnsError() {
+	//act
+	err := suite.Tx.SaveOutboxEvent(context.Background(), models.CreateNewOutboxEvent("", ""))
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error", "outbox event model")
+}
+
+func (suite *OutboxEventCRUDTestSuite) TestUpdateOutboxEvent_WithInvalidOutboxEvent_ReturnsError() {
+	//act
+	err := suite.Tx.UpdateOutboxEvent(context.Background(), models.CreateNewOutboxEvent("", ""))
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error", "outbox event model")
+}
+
+func (suite *OutboxEventCRUDTestSuite) TestGetPendingOutboxEvents_GetsOnlyUndeliveredEventsThatAreDue() {
+	//arrange
+	now := time.Now().UTC()
+
+	due := suite.createOutboxEvent(now.Add(-time.Minute))
+	notDue := suite.createOutboxEvent(now.Add(time.Minute))
+	delivered := suite.createOutboxEvent(now.Add(-time.Minute))
+	delivered.DeliveredAt = now.Truncate(time.Microsecond)
+	deadLettered := suite.createOutboxEvent(now.Add(-time.Minute))
+	deadLettered.DeadLettered = true
+
+	suite.saveOutboxEvent(due)
+	suite.saveOutboxEvent(notDue)
+	suite.saveOutboxEvent(delivered)
+	suite.saveOutboxEvent(deadLettered)
+
+	//act
+	events, err := suite.Tx.GetPendingOutboxEvents(context.Background(), now, 100)
+
+	//assert
+	suite.Require().NoError(err)
+
+	found := false
+	for _, event := range events {
+		suite.NotEqual(notDue.ID, event.ID)
+		suite.NotEqual(delivered.ID, event.ID)
+		suite.NotEqual(deadLettered.ID, event.ID)
+
+		if event.ID == due.ID {
+			found = true
+			suite.EqualValues(due, event)
+		}
+	}
+	suite.True(found)
+}
+
+func (suite *OutboxEventCRUDTestSuite) TestUpdateOutboxEvent_UpdatesDeliveryState() {
+	//arrange
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	event := suite.createOutboxEvent(now)
+	suite.saveOutboxEvent(event)
+
+	//act
+	event.Attempts = 3
+	event.LastError = "last error"
+	event.NextAttemptAt = now.Add(time.Hour)
+	event.DeadLettered = true
+	err := suite.Tx.UpdateOutboxEvent(context.Background(), event)
+
+	//assert
+	suite.Require().NoError(err)
+
+	events, err := suite.Tx.GetDeadLetteredOutboxEvents(context.Background(), models.OutboxEventFilter{Limit: models.OutboxEventFilterMaxLimit})
+	suite.Require().NoError(err)
+
+	found := false
+	for _, result := range events {
+		if result.ID == event.ID {
+			found = true
+			suite.EqualValues(event, result)
+		}
+	}
+	suite.True(found)
+}
+
+func (suite *OutboxEventCRUDTestSuite) TestGetDeadLetteredOutboxEvents_ReturnsNewestFirstAndPaginates() {
+	//arrange
+	now := time.Now().UTC()
+
+	events := make([]*models.OutboxEvent, 2)
+	for i := range events {
+		events[i] = suite.createOutboxEvent(now)
+		events[i].CreatedAt = now.Add(time.Hour - time.Duration(i)*time.Minute).Truncate(time.Microsecond)
+		events[i].DeadLettered = true
+		suite.saveOutboxEvent(events[i])
+	}
+
+	//act
+	page1, err1 := suite.Tx.GetDeadLetteredOutboxEvents(context.Background(), models.OutboxEventFilter{Limit: 1})
+	page2, err2 := suite.Tx.GetDeadLetteredOutboxEvents(context.Background(), models.OutboxEventFilter{Limit: 1, Offset: 1})
+
+	//assert
+	suite.Require().NoError(err1)
+	suite.Require().NoError(err2)
+
+	suite.Require().Len(page1, 1)
+	suite.EqualValues(events[0], page1[0])
+
+	suite.Require().Len(page2, 1)
+	suite.EqualValues(events[1], page2[0])
+}
+
+func TestOutboxEventCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &OutboxEventCRUDTestSuite{})
+}
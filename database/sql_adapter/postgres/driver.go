@@ -1,10 +1,19 @@
 package postgres
 
 import (
+	"authserver/database"
 	"authserver/database/sql_adapter/postgres/scripts"
+	"errors"
 
-	//import the postgres driver
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+)
+
+// Postgres error codes that are classified.
+const (
+	errorCodeSerializationFailure = "40001"
+	errorCodeDeadlockDetected     = "40P01"
+	errorCodeLockNotAvailable     = "55P03"
+	errorCodeUniqueViolation      = "23505"
 )
 
 // Driver is an implementation of the SQL Driver interface for postgres.
@@ -16,3 +25,24 @@ type Driver struct {
 func (Driver) GetDriverName() string {
 	return "postgres"
 }
+
+// ClassifyError returns the database error class of the postgres error using its sqlstate code.
+func (Driver) ClassifyError(err error) string {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return database.ErrorClassUnknown
+	}
+
+	switch pqErr.Code {
+	case errorCodeSerializationFailure:
+		return database.ErrorClassSerializationFailure
+	case errorCodeDeadlockDetected:
+		return database.ErrorClassDeadlock
+	case errorCodeLockNotAvailable:
+		return database.ErrorClassLockTimeout
+	case errorCodeUniqueViolation:
+		return database.ErrorClassUniqueViolation
+	default:
+		return database.ErrorClassUnknown
+	}
+}
@@ -0,0 +1,2 @@
+DELETE FROM "access_token" tk
+    WHERE tk."user_id" = $1
\ No newline at end of file
@@ -1,6 +1,6 @@
 SELECT
     tk."id",
-    u."id", u."username", u."password_hash",
+    u."id", u."username", u."password_hash", u."is_admin", u."is_disabled",
     c."id",
     s."id", s."name"
 FROM "access_token" tk
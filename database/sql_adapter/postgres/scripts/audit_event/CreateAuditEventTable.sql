@@ -0,0 +1,14 @@
+CREATE TABLE "public"."audit_event" (
+	"id" uuid NOT NULL,
+	"timestamp" timestamptz NOT NULL,
+	"action" varchar(30) NOT NULL,
+	"outcome" varchar(15) NOT NULL,
+	"actor_id" uuid NULL,
+	"target_id" uuid NULL,
+	"client_id" uuid NULL,
+	"ip_address" varchar(45) NOT NULL,
+	"user_agent" varchar(255) NOT NULL,
+	"details" varchar(255) NOT NULL,
+	CONSTRAINT "audit_event_pk" PRIMARY KEY ("id")
+);
+CREATE INDEX "audit_event_timestamp_idx" ON "public"."audit_event" ("timestamp");
\ No newline at end of file
@@ -0,0 +1,11 @@
+SELECT e."id", e."timestamp", e."action", e."outcome", e."actor_id", e."target_id", e."client_id", e."ip_address", e."user_agent", e."details"
+	FROM "audit_event" e
+	WHERE ($1::varchar IS NULL OR e."action" = $1)
+		AND ($2::varchar IS NULL OR e."outcome" = $2)
+		AND ($3::uuid IS NULL OR e."actor_id" = $3)
+		AND ($4::uuid IS NULL OR e."target_id" = $4)
+		AND ($5::uuid IS NULL OR e."client_id" = $5)
+		AND ($6::timestamptz IS NULL OR e."timestamp" >= $6)
+		AND ($7::timestamptz IS NULL OR e."timestamp" < $7)
+	ORDER BY e."timestamp" DESC, e."id"
+	LIMIT $8 OFFSET $9
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO "audit_event" ("id", "timestamp", "action", "outcome", "actor_id", "target_id", "client_id", "ip_address", "user_agent", "details")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
\ No newline at end of file
@@ -1,3 +1,3 @@
-SELECT c."id"
+SELECT c."id", c."tls_client_auth_subject_dn"
 	FROM "client" c
 	WHERE c."id" = $1
\ No newline at end of file
@@ -1,2 +1,2 @@
-INSERT INTO "client" ("id")
-	VALUES ($1)
\ No newline at end of file
+INSERT INTO "client" ("id", "tls_client_auth_subject_dn")
+	VALUES ($1, $2)
\ No newline at end of file
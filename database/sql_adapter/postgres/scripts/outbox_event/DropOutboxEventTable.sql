@@ -0,0 +1 @@
+DROP TABLE "public"."outbox_event"
\ No newline at end of file
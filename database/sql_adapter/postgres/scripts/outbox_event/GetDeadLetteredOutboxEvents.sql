@@ -0,0 +1,5 @@
+SELECT e."id", e."type", e."payload", e."created_at", e."attempts", e."next_attempt_at", e."last_error", e."delivered_at", e."dead_lettered"
+	FROM "outbox_event" e
+	WHERE e."dead_lettered"
+	ORDER BY e."created_at" DESC, e."id"
+	LIMIT $1 OFFSET $2
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE "public"."rate_limit_bucket" (
+	"key" varchar(64) NOT NULL,
+	"tokens" double precision NOT NULL,
+	"updated_at" timestamptz NOT NULL,
+	CONSTRAINT "rate_limit_bucket_pk" PRIMARY KEY ("key")
+);
+CREATE INDEX "rate_limit_bucket_updated_at_idx" ON "public"."rate_limit_bucket" ("updated_at");
\ No newline at end of file
@@ -0,0 +1,5 @@
+INSERT INTO "rate_limit_bucket" ("key", "tokens", "updated_at")
+	VALUES ($1, $2, $3)
+	ON CONFLICT ("key") DO UPDATE SET
+		"tokens" = EXCLUDED."tokens",
+		"updated_at" = EXCLUDED."updated_at"
\ No newline at end of file
@@ -37,6 +37,14 @@ DELETE FROM "access_token" tk
 `
 }
 
+// DeleteAllUserTokensScript gets the DeleteAllUserTokens script
+func (ScriptRepository) DeleteAllUserTokensScript() string {
+	return `
+DELETE FROM "access_token" tk
+    WHERE tk."user_id" = $1
+`
+}
+
 // DropAccessTokenTableScript gets the DropAccessTokenTable script
 func (ScriptRepository) DropAccessTokenTableScript() string {
 	return `
@@ -49,7 +57,7 @@ func (ScriptRepository) GetAccessTokenByIdScript() string {
 	return `
 SELECT
     tk."id",
-    u."id", u."username", u."password_hash",
+    u."id", u."username", u."password_hash", u."is_admin", u."is_disabled",
     c."id",
     s."id", s."name"
 FROM "access_token" tk
@@ -68,6 +76,66 @@ INSERT INTO "access_token" ("id", "user_id", "client_id", "scope_id")
 `
 }
 
+// CreateAuditEventTableScript gets the CreateAuditEventTable script
+func (ScriptRepository) CreateAuditEventTableScript() string {
+	return `
+CREATE TABLE "public"."audit_event" (
+	"id" uuid NOT NULL,
+	"timestamp" timestamptz NOT NULL,
+	"action" varchar(30) NOT NULL,
+	"outcome" varchar(15) NOT NULL,
+	"actor_id" uuid NULL,
+	"target_id" uuid NULL,
+	"client_id" uuid NULL,
+	"ip_address" varchar(45) NOT NULL,
+	"user_agent" varchar(255) NOT NULL,
+	"details" varchar(255) NOT NULL,
+	CONSTRAINT "audit_event_pk" PRIMARY KEY ("id")
+);
+CREATE INDEX "audit_event_timestamp_idx" ON "public"."audit_event" ("timestamp");
+`
+}
+
+// DropAuditEventTableScript gets the DropAuditEventTable script
+func (ScriptRepository) DropAuditEventTableScript() string {
+	return `
+DROP TABLE "public"."audit_event"
+`
+}
+
+// GetAuditEventsScript gets the GetAuditEvents script
+func (ScriptRepository) GetAuditEventsScript() string {
+	return `
+SELECT e."id", e."timestamp", e."action", e."outcome", e."actor_id", e."target_id", e."client_id", e."ip_address", e."user_agent", e."details"
+	FROM "audit_event" e
+	WHERE ($1::varchar IS NULL OR e."action" = $1)
+		AND ($2::varchar IS NULL OR e."outcome" = $2)
+		AND ($3::uuid IS NULL OR e."actor_id" = $3)
+		AND ($4::uuid IS NULL OR e."target_id" = $4)
+		AND ($5::uuid IS NULL OR e."client_id" = $5)
+		AND ($6::timestamptz IS NULL OR e."timestamp" >= $6)
+		AND ($7::timestamptz IS NULL OR e."timestamp" < $7)
+	ORDER BY e."timestamp" DESC, e."id"
+	LIMIT $8 OFFSET $9
+`
+}
+
+// SaveAuditEventScript gets the SaveAuditEvent script
+func (ScriptRepository) SaveAuditEventScript() string {
+	return `
+INSERT INTO "audit_event" ("id", "timestamp", "action", "outcome", "actor_id", "target_id", "client_id", "ip_address", "user_agent", "details")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+}
+
+// AddClientTLSClientAuthSubjectDNColumnScript gets the AddClientTLSClientAuthSubjectDNColumn script
+func (ScriptRepository) AddClientTLSClientAuthSubjectDNColumnScript() string {
+	return `
+ALTER TABLE "public"."client"
+	ADD COLUMN "tls_client_auth_subject_dn" varchar(255) NOT NULL DEFAULT ''
+`
+}
+
 // CreateClientTableScript gets the CreateClientTable script
 func (ScriptRepository) CreateClientTableScript() string {
 	return `
@@ -78,6 +146,14 @@ CREATE TABLE "public"."client" (
 `
 }
 
+// DropClientTLSClientAuthSubjectDNColumnScript gets the DropClientTLSClientAuthSubjectDNColumn script
+func (ScriptRepository) DropClientTLSClientAuthSubjectDNColumnScript() string {
+	return `
+ALTER TABLE "public"."client"
+	DROP COLUMN "tls_client_auth_subject_dn"
+`
+}
+
 // DropClientTableScript gets the DropClientTable script
 func (ScriptRepository) DropClientTableScript() string {
 	return `
@@ -88,20 +164,28 @@ DROP TABLE "public"."client"
 // GetClientByIdScript gets the GetClientById script
 func (ScriptRepository) GetClientByIdScript() string {
 	return `
-SELECT c."id"
+SELECT c."id", c."tls_client_auth_subject_dn"
 	FROM "client" c
 	WHERE c."id" = $1
 `
 }
 
-// SaveClientScript gets the SaveClient script
-func (ScriptRepository) SaveClientScript() string {
+// SaveAppClientScript gets the SaveAppClient script
+func (ScriptRepository) SaveAppClientScript() string {
 	return `
 INSERT INTO "client" ("id")
 	VALUES ($1)
 `
 }
 
+// SaveClientScript gets the SaveClient script
+func (ScriptRepository) SaveClientScript() string {
+	return `
+INSERT INTO "client" ("id", "tls_client_auth_subject_dn")
+	VALUES ($1, $2)
+`
+}
+
 // CreateMigrationTableScript gets the CreateMigrationTable script
 func (ScriptRepository) CreateMigrationTableScript() string {
 	return `
@@ -146,6 +230,125 @@ INSERT INTO "migration" ("timestamp")
 `
 }
 
+// CreateOutboxEventTableScript gets the CreateOutboxEventTable script
+func (ScriptRepository) CreateOutboxEventTableScript() string {
+	return `
+CREATE TABLE "public"."outbox_event" (
+	"id" uuid NOT NULL,
+	"type" varchar(50) NOT NULL,
+	"payload" text NOT NULL,
+	"created_at" timestamptz NOT NULL,
+	"attempts" integer NOT NULL,
+	"next_attempt_at" timestamptz NOT NULL,
+	"last_error" varchar(255) NOT NULL,
+	"delivered_at" timestamptz NULL,
+	"dead_lettered" boolean NOT NULL,
+	CONSTRAINT "outbox_event_pk" PRIMARY KEY ("id")
+);
+CREATE INDEX "outbox_event_pending_idx" ON "public"."outbox_event" ("next_attempt_at") WHERE "delivered_at" IS NULL AND NOT "dead_lettered";
+`
+}
+
+// DropOutboxEventTableScript gets the DropOutboxEventTable script
+func (ScriptRepository) DropOutboxEventTableScript() string {
+	return `
+DROP TABLE "public"."outbox_event"
+`
+}
+
+// GetDeadLetteredOutboxEventsScript gets the GetDeadLetteredOutboxEvents script
+func (ScriptRepository) GetDeadLetteredOutboxEventsScript() string {
+	return `
+SELECT e."id", e."type", e."payload", e."created_at", e."attempts", e."next_attempt_at", e."last_error", e."delivered_at", e."dead_lettered"
+	FROM "outbox_event" e
+	WHERE e."dead_lettered"
+	ORDER BY e."created_at" DESC, e."id"
+	LIMIT $1 OFFSET $2
+`
+}
+
+// GetPendingOutboxEventsScript gets the GetPendingOutboxEvents script
+func (ScriptRepository) GetPendingOutboxEventsScript() string {
+	return `
+SELECT e."id", e."type", e."payload", e."created_at", e."attempts", e."next_attempt_at", e."last_error", e."delivered_at", e."dead_lettered"
+	FROM "outbox_event" e
+	WHERE e."delivered_at" IS NULL AND NOT e."dead_lettered" AND e."next_attempt_at" <= $1
+	ORDER BY e."next_attempt_at", e."id"
+	LIMIT $2
+	FOR UPDATE SKIP LOCKED
+`
+}
+
+// SaveOutboxEventScript gets the SaveOutboxEvent script
+func (ScriptRepository) SaveOutboxEventScript() string {
+	return `
+INSERT INTO "outbox_event" ("id", "type", "payload", "created_at", "attempts", "next_attempt_at", "last_error", "delivered_at", "dead_lettered")
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+}
+
+// UpdateOutboxEventScript gets the UpdateOutboxEvent script
+func (ScriptRepository) UpdateOutboxEventScript() string {
+	return `
+UPDATE "outbox_event" SET
+    "attempts" = $2,
+    "next_attempt_at" = $3,
+    "last_error" = $4,
+    "delivered_at" = $5,
+    "dead_lettered" = $6
+WHERE "id" = $1
+`
+}
+
+// CreateRateLimitBucketTableScript gets the CreateRateLimitBucketTable script
+func (ScriptRepository) CreateRateLimitBucketTableScript() string {
+	return `
+CREATE TABLE "public"."rate_limit_bucket" (
+	"key" varchar(64) NOT NULL,
+	"tokens" double precision NOT NULL,
+	"updated_at" timestamptz NOT NULL,
+	CONSTRAINT "rate_limit_bucket_pk" PRIMARY KEY ("key")
+);
+CREATE INDEX "rate_limit_bucket_updated_at_idx" ON "public"."rate_limit_bucket" ("updated_at");
+`
+}
+
+// DeleteRateLimitBucketsUpdatedBeforeScript gets the DeleteRateLimitBucketsUpdatedBefore script
+func (ScriptRepository) DeleteRateLimitBucketsUpdatedBeforeScript() string {
+	return `
+DELETE FROM "rate_limit_bucket"
+	WHERE "updated_at" < $1
+`
+}
+
+// DropRateLimitBucketTableScript gets the DropRateLimitBucketTable script
+func (ScriptRepository) DropRateLimitBucketTableScript() string {
+	return `
+DROP TABLE "public"."rate_limit_bucket"
+`
+}
+
+// GetRateLimitBucketByKeyScript gets the GetRateLimitBucketByKey script
+func (ScriptRepository) GetRateLimitBucketByKeyScript() string {
+	return `
+SELECT b."key", b."tokens", b."updated_at"
+	FROM "rate_limit_bucket" b
+	WHERE b."key" = $1
+	FOR UPDATE
+`
+}
+
+// SaveRateLimitBucketScript gets the SaveRateLimitBucket script
+func (ScriptRepository) SaveRateLimitBucketScript() string {
+	return `
+INSERT INTO "rate_limit_bucket" ("key", "tokens", "updated_at")
+	VALUES ($1, $2, $3)
+	ON CONFLICT ("key") DO UPDATE SET
+		"tokens" = EXCLUDED."tokens",
+		"updated_at" = EXCLUDED."updated_at"
+`
+}
+
 // CreateScopeTableScript gets the CreateScopeTable script
 func (ScriptRepository) CreateScopeTableScript() string {
 	return `
@@ -182,6 +385,31 @@ INSERT INTO "scope" ("id", "name")
 `
 }
 
+// AddUserIsAdminColumnScript gets the AddUserIsAdminColumn script
+func (ScriptRepository) AddUserIsAdminColumnScript() string {
+	return `
+ALTER TABLE "public"."user"
+	ADD COLUMN "is_admin" boolean NOT NULL DEFAULT false
+`
+}
+
+// AddUserIsDisabledColumnScript gets the AddUserIsDisabledColumn script
+func (ScriptRepository) AddUserIsDisabledColumnScript() string {
+	return `
+ALTER TABLE "public"."user"
+	ADD COLUMN "is_disabled" boolean NOT NULL DEFAULT false
+`
+}
+
+// CountUsersScript gets the CountUsers script
+func (ScriptRepository) CountUsersScript() string {
+	return `
+SELECT COUNT(*)
+	FROM "user" u
+	WHERE ($1::varchar IS NULL OR strpos(lower(u."username"), lower($1)) > 0)
+`
+}
+
 // CreateUserTableScript gets the CreateUserTable script
 func (ScriptRepository) CreateUserTableScript() string {
 	return `
@@ -203,6 +431,22 @@ DELETE FROM "user" u
 `
 }
 
+// DropUserIsAdminColumnScript gets the DropUserIsAdminColumn script
+func (ScriptRepository) DropUserIsAdminColumnScript() string {
+	return `
+ALTER TABLE "public"."user"
+	DROP COLUMN "is_admin"
+`
+}
+
+// DropUserIsDisabledColumnScript gets the DropUserIsDisabledColumn script
+func (ScriptRepository) DropUserIsDisabledColumnScript() string {
+	return `
+ALTER TABLE "public"."user"
+	DROP COLUMN "is_disabled"
+`
+}
+
 // DropUserTableScript gets the DropUserTable script
 func (ScriptRepository) DropUserTableScript() string {
 	return `
@@ -213,7 +457,7 @@ DROP TABLE "public"."user"
 // GetUserByIdScript gets the GetUserById script
 func (ScriptRepository) GetUserByIdScript() string {
 	return `
-SELECT u."id", u."username", u."password_hash"
+SELECT u."id", u."username", u."password_hash", u."is_admin", u."is_disabled"
 	FROM "user" u
 	WHERE u."id" = $1
 `
@@ -222,17 +466,28 @@ SELECT u."id", u."username", u."password_hash"
 // GetUserByUsernameScript gets the GetUserByUsername script
 func (ScriptRepository) GetUserByUsernameScript() string {
 	return `
-SELECT u."id", u."username", u."password_hash"
+SELECT u."id", u."username", u."password_hash", u."is_admin", u."is_disabled"
 	FROM "user" u
 	WHERE u."username" = $1
 `
 }
 
+// GetUsersScript gets the GetUsers script
+func (ScriptRepository) GetUsersScript() string {
+	return `
+SELECT u."id", u."username", u."password_hash", u."is_admin", u."is_disabled"
+	FROM "user" u
+	WHERE ($1::varchar IS NULL OR strpos(lower(u."username"), lower($1)) > 0)
+	ORDER BY u."username", u."id"
+	LIMIT $2 OFFSET $3
+`
+}
+
 // SaveUserScript gets the SaveUser script
 func (ScriptRepository) SaveUserScript() string {
 	return `
-INSERT INTO "user" ("id", "username", "password_hash")
-	VALUES ($1, $2, $3)
+INSERT INTO "user" ("id", "username", "password_hash", "is_admin", "is_disabled")
+	VALUES ($1, $2, $3, $4, $5)
 `
 }
 
@@ -241,7 +496,9 @@ func (ScriptRepository) UpdateUserScript() string {
 	return `
 UPDATE "user" SET
     "username" = $2,
-    "password_hash" = $3
+    "password_hash" = $3,
+    "is_admin" = $4,
+    "is_disabled" = $5
 WHERE "id" = $1
 `
 }
@@ -0,0 +1,3 @@
+SELECT COUNT(*)
+	FROM "user" u
+	WHERE ($1::varchar IS NULL OR strpos(lower(u."username"), lower($1)) > 0)
\ No newline at end of file
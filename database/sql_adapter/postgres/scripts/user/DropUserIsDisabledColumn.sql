@@ -0,0 +1,2 @@
+ALTER TABLE "public"."user"
+	DROP COLUMN "is_disabled"
\ No newline at end of file
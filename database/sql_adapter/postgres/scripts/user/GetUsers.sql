@@ -0,0 +1,5 @@
+SELECT u."id", u."username", u."password_hash", u."is_admin", u."is_disabled"
+	FROM "user" u
+	WHERE ($1::varchar IS NULL OR strpos(lower(u."username"), lower($1)) > 0)
+	ORDER BY u."username", u."id"
+	LIMIT $2 OFFSET $3
\ No newline at end of file
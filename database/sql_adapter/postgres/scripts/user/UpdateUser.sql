@@ -1,4 +1,6 @@
 UPDATE "user" SET
     "username" = $2,
-    "password_hash" = $3
+    "password_hash" = $3,
+    "is_admin" = $4,
+    "is_disabled" = $5
 WHERE "id" = $1
\ No newline at end of file
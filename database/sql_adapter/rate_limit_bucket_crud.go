@@ -0,0 +1,82 @@
+package sqladapter
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetRateLimitBucketByKey gets the row in the rate_limit_bucket table with the matching key, and creates a new rate limit bucket model using its data.
+// The row is locked until the transaction ends, if the driver supports it. Returns the bucket and any errors.
+func (adapter *SQLAdapter) GetRateLimitBucketByKey(ctx context.Context, key string) (*models.RateLimitBucket, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetRateLimitBucketByKeyScript(), key)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get rate limit bucket by key query", err)
+	}
+	defer rows.Close()
+
+	return readRateLimitBucketData(rows)
+}
+
+// SaveRateLimitBucket validates the rate limit bucket model is valid and upserts the row in the rate_limit_bucket table with the matching key.
+// Returns any errors.
+func (adapter *SQLAdapter) SaveRateLimitBucket(ctx context.Context, bucket *models.RateLimitBucket) error {
+	verr := bucket.Validate()
+	if verr != models.ValidateRateLimitBucketValid {
+		return errors.New(fmt.Sprint("error validating rate limit bucket model:", verr))
+	}
+
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveRateLimitBucketScript(), bucket.Key, bucket.Tokens, bucket.UpdatedAt)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing save rate limit bucket statement", err)
+	}
+
+	return nil
+}
+
+// DeleteRateLimitBucketsUpdatedBefore deletes the rows in the rate_limit_bucket table last updated before the given time.
+// Returns any errors.
+func (adapter *SQLAdapter) DeleteRateLimitBucketsUpdatedBefore(ctx context.Context, before time.Time) error {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.DeleteRateLimitBucketsUpdatedBeforeScript(), before)
+	cancel()
+
+	if err != nil {
+		return common.ChainError("error executing delete rate limit buckets updated before statement", err)
+	}
+
+	return nil
+}
+
+func readRateLimitBucketData(rows *sql.Rows) (*models.RateLimitBucket, error) {
+	//check if there was a result
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return nil, common.ChainError("error preparing next row", err)
+		}
+
+		//return no results
+		return nil, nil
+	}
+
+	//get the result
+	bucket := &models.RateLimitBucket{}
+	err := rows.Scan(&bucket.Key, &bucket.Tokens, &bucket.UpdatedAt)
+	if err != nil {
+		return nil, common.ChainError("error reading row", err)
+	}
+
+	bucket.UpdatedAt = bucket.UpdatedAt.UTC()
+	return bucket, nil
+}
@@ -0,0 +1,109 @@
+package sqladapter_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitBucketCRUDTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *RateLimitBucketCRUDTestSuite) createBucket(key string, updatedAt time.Time) *models.RateLimitBucket {
+	return &models.RateLimitBucket{
+		Key:       key,
+		Tokens:    2.5,
+		UpdatedAt: updatedAt.UTC().Truncate(time.Microsecond),
+	}
+}
+
+func (suite *RateLimitBucketCRUDTestSuite) TestSaveRateLimitBucket_WithInvalidRateLimitBucket_ReturnsError() {
+	//arrange
+	bucket := suite.createBucket("", time.Now())
+
+	//act
+	err := suite.Tx.SaveRateLimitBucket(context.Background(), bucket)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error", "rate limit bucket model")
+}
+
+func (suite *RateLimitBucketCRUDTestSuite) TestGetRateLimitBucketByKey_WhereBucketNotFound_ReturnsNilBucket() {
+	//act
+	bucket, err := suite.Tx.GetRateLimitBucketByKey(context.Background(), "not a real key")
+
+	//assert
+	suite.NoError(err)
+	suite.Nil(bucket)
+}
+
+func (suite *RateLimitBucketCRUDTestSuite) TestGetRateLimitBucketByKey_GetsTheBucketWithKey() {
+	//arrange
+	bucket := suite.createBucket("key", time.Now())
+
+	err := suite.Tx.SaveRateLimitBucket(context.Background(), bucket)
+	suite.Require().NoError(err)
+
+	//act
+	resultBucket, err := suite.Tx.GetRateLimitBucketByKey(context.Background(), bucket.Key)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(bucket, resultBucket)
+}
+
+func (suite *RateLimitBucketCRUDTestSuite) TestSaveRateLimitBucket_WithExistingKey_ReplacesBucket() {
+	//arrange
+	bucket := suite.createBucket("key", time.Now())
+
+	err := suite.Tx.SaveRateLimitBucket(context.Background(), bucket)
+	suite.Require().NoError(err)
+
+	bucket.Tokens = 0.5
+	bucket.UpdatedAt = bucket.UpdatedAt.Add(time.Second)
+
+	//act
+	err = suite.Tx.SaveRateLimitBucket(context.Background(), bucket)
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultBucket, err := suite.Tx.GetRateLimitBucketByKey(context.Background(), bucket.Key)
+	suite.Require().NoError(err)
+	suite.Equal(bucket, resultBucket)
+}
+
+func (suite *RateLimitBucketCRUDTestSuite) TestDeleteRateLimitBucketsUpdatedBefore_OnlyDeletesBucketsUpdatedBeforeTime() {
+	//arrange
+	now := time.Now()
+	oldBucket := suite.createBucket("old", now.Add(-time.Hour))
+	newBucket := suite.createBucket("new", now)
+
+	for _, bucket := range []*models.RateLimitBucket{oldBucket, newBucket} {
+		err := suite.Tx.SaveRateLimitBucket(context.Background(), bucket)
+		suite.Require().NoError(err)
+	}
+
+	//act
+	err := suite.Tx.DeleteRateLimitBucketsUpdatedBefore(context.Background(), now.Add(-time.Minute))
+
+	//assert
+	suite.Require().NoError(err)
+
+	resultBucket, err := suite.Tx.GetRateLimitBucketByKey(context.Background(), oldBucket.Key)
+	suite.Require().NoError(err)
+	suite.Nil(resultBucket)
+
+	resultBucket, err = suite.Tx.GetRateLimitBucketByKey(context.Background(), newBucket.Key)
+	suite.Require().NoError(err)
+	suite.Equal(newBucket, resultBucket)
+}
+
+func TestRateLimitBucketCRUDTestSuite(t *testing.T) {
+	suite.Run(t, &RateLimitBucketCRUDTestSuite{})
+}
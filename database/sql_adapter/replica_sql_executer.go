@@ -0,0 +1,85 @@
+package sqladapter
+
+import (
+	"authserver/database/consistency"
+	"authserver/logger"
+	"context"
+	"database/sql"
+	"sync/atomic"
+)
+
+// ReplicaSQLExecuter is an implementation of SQLExecuter that routes read only queries to read replicas of the database.
+// Statements are always executed on the primary, as are queries that must read the latest data.
+// If a replica fails, the query is retried on the primary.
+type ReplicaSQLExecuter struct {
+	// Primary is the executer for the primary database.
+	Primary SQLExecuter
+
+	// Replicas are the executers for the read replicas. Queries are spread across them in turn.
+	Replicas []SQLExecuter
+
+	// PinToPrimaryAfterWrite routes a request's queries to the primary once it has written, so it reads its own writes.
+	PinToPrimaryAfterWrite bool
+
+	next uint32
+}
+
+// ExecContext executes the sql statement on the primary and marks the context's request as having written.
+// Returns its result and any errors.
+func (e *ReplicaSQLExecuter) ExecContext(ctx context.Context, stmt string, args ...interface{}) (sql.Result, error) {
+	consistency.FromContext(ctx).MarkWritten()
+	return e.Primary.ExecContext(ctx, stmt, args...)
+}
+
+// QueryContext executes the sql query on a replica, falling back to the primary if the replica fails.
+// Returns the resulting rows and any errors.
+func (e *ReplicaSQLExecuter) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	replica := e.replica(ctx)
+	if replica == nil {
+		return e.Primary.QueryContext(ctx, query, args...)
+	}
+
+	rows, err := replica.QueryContext(ctx, query, args...)
+	if err == nil || ctx.Err() != nil {
+		return rows, err
+	}
+
+	logReplicaFallback(ctx, err)
+	return e.Primary.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext executes the sql query on a replica, falling back to the primary if the replica fails.
+// Returns the resulting row.
+func (e *ReplicaSQLExecuter) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	replica := e.replica(ctx)
+	if replica == nil {
+		return e.Primary.QueryRowContext(ctx, query, args...)
+	}
+
+	row := replica.QueryRowContext(ctx, query, args...)
+	err := row.Err()
+	if err == nil || ctx.Err() != nil {
+		return row
+	}
+
+	logReplicaFallback(ctx, err)
+	return e.Primary.QueryRowContext(ctx, query, args...)
+}
+
+// replica returns the next replica to query, or nil if the query should be made on the primary.
+func (e *ReplicaSQLExecuter) replica(ctx context.Context) SQLExecuter {
+	if len(e.Replicas) == 0 || consistency.RequiresPrimary(ctx) {
+		return nil
+	}
+
+	if e.PinToPrimaryAfterWrite && consistency.FromContext(ctx).HasWritten() {
+		return nil
+	}
+
+	i := atomic.AddUint32(&e.next, 1) - 1
+	return e.Replicas[i%uint32(len(e.Replicas))]
+}
+
+func logReplicaFallback(ctx context.Context, err error) {
+	logger.FromContext(ctx).With("error", err.Error()).Warn("error querying read replica, falling back to primary")
+}
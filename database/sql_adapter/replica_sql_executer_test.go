@@ -0,0 +1,187 @@
+package sqladapter_test
+
+import (
+	"authserver/common"
+	"authserver/database/consistency"
+	sqladapter "authserver/database/sql_adapter"
+	"authserver/database/sql_adapter/sqlite"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+const replicaSourceQuery = "SELECT name FROM source"
+
+type ReplicaSQLExecuterTestSuite struct {
+	suite.Suite
+	Primary  *sql.DB
+	Replica1 *sql.DB
+	Replica2 *sql.DB
+	Executer *sqladapter.ReplicaSQLExecuter
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) SetupTest() {
+	suite.Primary = suite.openSourceDB("primary")
+	suite.Replica1 = suite.openSourceDB("replica1")
+	suite.Replica2 = suite.openSourceDB("replica2")
+
+	suite.Executer = &sqladapter.ReplicaSQLExecuter{
+		Primary:                suite.Primary,
+		Replicas:               []sqladapter.SQLExecuter{suite.Replica1, suite.Replica2},
+		PinToPrimaryAfterWrite: true,
+	}
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TearDownTest() {
+	suite.Primary.Close()
+	suite.Replica1.Close()
+	suite.Replica2.Close()
+}
+
+// openSourceDB opens an in-memory sqlite db with a source table that holds the name of the db.
+func (suite *ReplicaSQLExecuterTestSuite) openSourceDB(name string) *sql.DB {
+	db, err := sql.Open(sqlite.DriverName, "file:"+uuid.New().String()+"?mode=memory&cache=shared")
+	suite.Require().NoError(err)
+
+	_, err = db.Exec("CREATE TABLE source (name TEXT)")
+	suite.Require().NoError(err)
+
+	_, err = db.Exec("INSERT INTO source (name) VALUES (?)", name)
+	suite.Require().NoError(err)
+
+	return db
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) queryRowSource(ctx context.Context) string {
+	var name string
+	err := suite.Executer.QueryRowContext(ctx, replicaSourceQuery).Scan(&name)
+	suite.Require().NoError(err)
+
+	return name
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryRowContext_SpreadsQueriesAcrossReplicas() {
+	//act
+	first := suite.queryRowSource(context.Background())
+	second := suite.queryRowSource(context.Background())
+	third := suite.queryRowSource(context.Background())
+
+	//assert
+	suite.Equal("replica1", first)
+	suite.Equal("replica2", second)
+	suite.Equal("replica1", third)
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryRowContext_WithNoReplicas_QueriesPrimary() {
+	//arrange
+	suite.Executer.Replicas = nil
+
+	//act
+	name := suite.queryRowSource(context.Background())
+
+	//assert
+	suite.Equal("primary", name)
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryRowContext_WherePrimaryIsRequired_QueriesPrimary() {
+	//arrange
+	ctx := consistency.WithPrimary(context.Background())
+
+	//act
+	name := suite.queryRowSource(ctx)
+
+	//assert
+	suite.Equal("primary", name)
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryRowContext_WhereRequestHasWritten_QueriesPrimary() {
+	//arrange
+	tracker := consistency.CreateTracker()
+	tracker.MarkWritten()
+	ctx := consistency.NewContext(context.Background(), tracker)
+
+	//act
+	name := suite.queryRowSource(ctx)
+
+	//assert
+	suite.Equal("primary", name)
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryRowContext_WhereRequestHasWrittenAndPinningIsDisabled_QueriesReplica() {
+	//arrange
+	suite.Executer.PinToPrimaryAfterWrite = false
+
+	tracker := consistency.CreateTracker()
+	tracker.MarkWritten()
+	ctx := consistency.NewContext(context.Background(), tracker)
+
+	//act
+	name := suite.queryRowSource(ctx)
+
+	//assert
+	suite.Equal("replica1", name)
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryRowContext_WhereReplicaFails_FallsBackToPrimary() {
+	//arrange
+	suite.Replica1.Close()
+
+	//act
+	name := suite.queryRowSource(context.Background())
+
+	//assert
+	suite.Equal("primary", name)
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryContext_WhereReplicaFails_FallsBackToPrimary() {
+	//arrange
+	suite.Replica1.Close()
+
+	//act
+	rows, err := suite.Executer.QueryContext(context.Background(), replicaSourceQuery)
+
+	//assert
+	suite.Require().NoError(err)
+	defer rows.Close()
+
+	var name string
+	suite.Require().True(rows.Next())
+	suite.Require().NoError(rows.Scan(&name))
+	suite.Equal("primary", name)
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestQueryContext_WithCanceledContext_ReturnsErrorWithoutFallingBack() {
+	//arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//act
+	_, err := suite.Executer.QueryContext(ctx, replicaSourceQuery)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "context canceled")
+}
+
+func (suite *ReplicaSQLExecuterTestSuite) TestExecContext_ExecutesOnPrimaryAndMarksRequestAsWritten() {
+	//arrange
+	tracker := consistency.CreateTracker()
+	ctx := consistency.NewContext(context.Background(), tracker)
+
+	//act
+	_, err := suite.Executer.ExecContext(ctx, "UPDATE source SET name = ?", "updated")
+
+	//assert
+	suite.Require().NoError(err)
+	suite.True(tracker.HasWritten())
+
+	var name string
+	suite.Require().NoError(suite.Primary.QueryRow(replicaSourceQuery).Scan(&name))
+	suite.Equal("updated", name)
+}
+
+func TestReplicaSQLExecuterTestSuite(t *testing.T) {
+	suite.Run(t, &ReplicaSQLExecuterTestSuite{})
+}
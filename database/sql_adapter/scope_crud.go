@@ -3,6 +3,7 @@ package sqladapter
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -10,13 +11,13 @@ import (
 
 // SaveScope validates the scope model is valid and inserts a new row into the scope table.
 // Returns any errors.
-func (adapter *SQLAdapter) SaveScope(scope *models.Scope) error {
+func (adapter *SQLAdapter) SaveScope(ctx context.Context, scope *models.Scope) error {
 	verr := scope.Validate()
 	if verr != models.ValidateScopeValid {
 		return errors.New(fmt.Sprint("error validating scope model:", verr))
 	}
 
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveScopeScript(), scope.ID, scope.Name)
 	cancel()
 
@@ -29,8 +30,8 @@ func (adapter *SQLAdapter) SaveScope(scope *models.Scope) error {
 
 // GetScopeByName gets the row in the scope table with the matching name, and creates a new scope model using its data.
 // Returns the scope and any errors.
-func (adapter *SQLAdapter) GetScopeByName(name string) (*models.Scope, error) {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) GetScopeByName(ctx context.Context, name string) (*models.Scope, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetScopeByNameScript(), name)
 	defer cancel()
 
@@ -3,6 +3,7 @@ package sqladapter_test
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -17,7 +18,7 @@ func (suite *ScopeCRUDTestSuite) TestSaveScope_WithInvalidScope_ReturnsError() {
 	scope := models.CreateNewScope("")
 
 	//act
-	err := suite.Tx.SaveScope(scope)
+	err := suite.Tx.SaveScope(context.Background(), scope)
 
 	//assert
 	common.AssertError(&suite.Suite, err, "error", "scope model")
@@ -25,7 +26,7 @@ func (suite *ScopeCRUDTestSuite) TestSaveScope_WithInvalidScope_ReturnsError() {
 
 func (suite *ScopeCRUDTestSuite) TestGetScopeByName_WhereScopeNotFound_ReturnsNilScope() {
 	//act
-	scope, err := suite.Tx.GetScopeByName("not a real name")
+	scope, err := suite.Tx.GetScopeByName(context.Background(), "not a real name")
 
 	//assert
 	suite.NoError(err)
@@ -38,7 +39,7 @@ func (suite *ScopeCRUDTestSuite) TestGetScopeByName_GetsTheScopeWithName() {
 	suite.SaveScope(suite.Tx, scope)
 
 	//act
-	resultScope, err := suite.Tx.GetScopeByName(scope.Name)
+	resultScope, err := suite.Tx.GetScopeByName(context.Background(), scope.Name)
 
 	//assert
 	suite.NoError(err)
@@ -1,73 +1,292 @@
 package main
 
 import (
+	"authserver/common"
 	"authserver/config"
+	sqladapter "authserver/database/sql_adapter"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"path"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
 	"github.com/spf13/viper"
 )
 
-type tmplData struct {
+// Script is a sql script loaded from a file, named after the file without its extension.
+type Script struct {
 	Name   string
 	Script string
 }
 
+// outputFileName is the name of the repository file generated in each scripts directory.
+const outputFileName = "script_repository.go"
+
 func main() {
 	err := config.InitConfig(".")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	inDir := path.Join(viper.GetString("root_dir"), "database/sql_adapter/postgres/scripts")
+	//parse flags
+	driver := flag.String("driver", "", "The driver to compile the scripts for. Every driver is compiled if empty")
+	dir := flag.String("dir", "", "The scripts directory to compile. Takes precedence over the driver flag")
+	check := flag.Bool("check", false, "Report stale repositories instead of writing them")
+	flag.Parse()
 
-	//load the template
-	tmpl := template.Must(template.ParseFiles(path.Join(viper.GetString("root_dir"), "database/sql_adapter/script_compiler/script_repository.go.tmpl")))
+	rootDir := viper.GetString("root_dir")
 
-	var data []tmplData
-	createDataObjects(inDir, &data)
+	//load the template
+	tmpl := template.Must(template.New("script_repository.go.tmpl").Funcs(template.FuncMap{
+		"escapeBackticks": escapeBackticks,
+	}).ParseFiles(path.Join(rootDir, "database/sql_adapter/script_compiler/script_repository.go.tmpl")))
 
-	//create the output file
-	f, err := os.Create(path.Join(inDir, "script_repository.go"))
+	dirs, err := ResolveScriptDirs(path.Join(rootDir, "database/sql_adapter"), *driver, *dir)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer f.Close()
+
+	//compile every directory so all problems are reported at once
+	failed := false
+	for _, scriptsDir := range dirs {
+		err = Compile(tmpl, scriptsDir, *check)
+		if err != nil {
+			log.Println(err)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// ResolveScriptDirs returns the scripts directories to compile.
+// If dir is set only it is returned, else if driver is set only the driver's scripts directory is returned.
+// Otherwise the scripts directories of every driver in the adapter directory are returned.
+func ResolveScriptDirs(adapterDir string, driver string, dir string) ([]string, error) {
+	if dir != "" {
+		return []string{dir}, nil
+	}
+
+	if driver != "" {
+		scriptsDir := path.Join(adapterDir, driver, "scripts")
+		if !isDir(scriptsDir) {
+			return nil, errors.New("no scripts directory found for driver " + driver)
+		}
+
+		return []string{scriptsDir}, nil
+	}
+
+	files, err := ioutil.ReadDir(adapterDir)
+	if err != nil {
+		return nil, common.ChainError("error reading adapter directory", err)
+	}
+
+	//every driver package has a scripts directory
+	var dirs []string
+	for _, file := range files {
+		scriptsDir := path.Join(adapterDir, file.Name(), "scripts")
+		if file.IsDir() && isDir(scriptsDir) {
+			dirs = append(dirs, scriptsDir)
+		}
+	}
+
+	return dirs, nil
+}
+
+// Compile loads and validates the scripts in the directory, then writes them to its script repository file.
+// If check is true, the file is compared with the repository it would have been written with instead.
+// Returns any errors, including if the file is stale.
+func Compile(tmpl *template.Template, dir string, check bool) error {
+	scripts, err := LoadScripts(dir)
+	if err != nil {
+		return common.ChainError("error loading scripts in "+dir, err)
+	}
+
+	errs := ValidateScripts(scripts)
+	if len(errs) > 0 {
+		messages := make([]string, len(errs))
+		for i, err := range errs {
+			messages[i] = err.Error()
+		}
+
+		return errors.New(fmt.Sprint(dir, " has invalid scripts:\n\t", strings.Join(messages, "\n\t")))
+	}
 
 	//execute the template
-	tmpl.Execute(f, data)
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, scripts)
+	if err != nil {
+		return common.ChainError("error executing template for "+dir, err)
+	}
+
+	outFile := path.Join(dir, outputFileName)
+
+	if check {
+		current, err := ioutil.ReadFile(outFile)
+		if err != nil && !os.IsNotExist(err) {
+			return common.ChainError("error reading "+outFile, err)
+		}
+
+		if !bytes.Equal(current, buf.Bytes()) {
+			return errors.New(outFile + " is stale, run the script compiler to regenerate it")
+		}
+
+		return nil
+	}
+
+	err = ioutil.WriteFile(outFile, buf.Bytes(), 0644)
+	if err != nil {
+		return common.ChainError("error writing "+outFile, err)
+	}
+
+	return nil
 }
 
-func createDataObjects(dir string, data *[]tmplData) {
+// LoadScripts reads every sql file in the directory and its subdirectories, in directory order.
+// Returns the scripts and any errors.
+func LoadScripts(dir string) ([]Script, error) {
+	var scripts []Script
+
+	err := loadScripts(dir, &scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	return scripts, nil
+}
+
+func loadScripts(dir string, scripts *[]Script) error {
 	//get all files in input dir
 	files, err := ioutil.ReadDir(dir)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
-	//read all sql files and create data objects
+	//read all sql files and create scripts
 	for _, file := range files {
 		//if another directory, recurse
 		if file.IsDir() {
-			createDataObjects(path.Join(dir, file.Name()), data)
+			err = loadScripts(path.Join(dir, file.Name()), scripts)
+			if err != nil {
+				return err
+			}
 			continue
 		}
 
-		//if sql file, create data object and add to slice
+		//if sql file, create script and add to slice
 		if path.Ext(file.Name()) == ".sql" {
 			script, err := ioutil.ReadFile(path.Join(dir, file.Name()))
 			if err != nil {
-				log.Fatal(err)
+				return err
 			}
 
-			*data = append(*data, tmplData{
+			*scripts = append(*scripts, Script{
 				Name:   strings.SplitN(path.Base(file.Name()), ".", 2)[0],
 				Script: string(script),
 			})
 		}
 	}
+
+	return nil
+}
+
+// RequiredScripts returns the names of the scripts the SQLScriptRepository interface requires.
+func RequiredScripts() []string {
+	t := reflect.TypeOf((*sqladapter.SQLScriptRepository)(nil)).Elem()
+
+	names := make([]string, t.NumMethod())
+	for i := range names {
+		names[i] = strings.TrimSuffix(t.Method(i).Name, "Script")
+	}
+
+	return names
+}
+
+// ValidateScripts checks every required script is present and its placeholders match its declared arity.
+// Returns an error for each problem found.
+func ValidateScripts(scripts []Script) []error {
+	byName := map[string]Script{}
+	for _, script := range scripts {
+		byName[script.Name] = script
+	}
+
+	var errs []error
+	for _, name := range RequiredScripts() {
+		script, ok := byName[name]
+		if !ok {
+			errs = append(errs, errors.New("missing script "+name))
+			continue
+		}
+
+		arity, ok := ScriptArities[name]
+		if !ok {
+			continue
+		}
+
+		count, err := CountPlaceholders(script.Script)
+		if err != nil {
+			errs = append(errs, common.ChainError("error counting placeholders in "+name, err))
+		} else if count != arity {
+			errs = append(errs, errors.New(fmt.Sprint(name, " has ", count, " placeholders but declares ", arity, " arguments")))
+		}
+	}
+
+	return errs
+}
+
+var (
+	placeholderRegex = regexp.MustCompile(`[$?](\d*)`)
+	literalRegex     = regexp.MustCompile(`'[^']*'|--[^\n]*`)
+)
+
+// CountPlaceholders returns the number of arguments the script's placeholders consume.
+// Numbered placeholders, such as $1 or ?1, consume as many arguments as the highest number used,
+// while each positional ? placeholder consumes its own argument. String literals and comments are ignored.
+// Returns an error if the script mixes numbered and positional placeholders.
+func CountPlaceholders(script string) (int, error) {
+	script = literalRegex.ReplaceAllString(script, "")
+
+	numbered := 0
+	positional := 0
+
+	for _, match := range placeholderRegex.FindAllStringSubmatch(script, -1) {
+		if match[1] == "" {
+			//a lone $ is not a placeholder
+			if match[0] == "?" {
+				positional++
+			}
+			continue
+		}
+
+		n, _ := strconv.Atoi(match[1])
+		if n > numbered {
+			numbered = n
+		}
+	}
+
+	if numbered > 0 && positional > 0 {
+		return 0, errors.New("script mixes numbered and positional placeholders")
+	}
+
+	return numbered + positional, nil
+}
+
+func isDir(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && info.IsDir()
+}
+
+// escapeBackticks splices any backticks in the script into the raw string literal it is written to, such as those used to quote mysql identifiers.
+func escapeBackticks(script string) string {
+	return strings.ReplaceAll(script, "`", "` + \"`\" + `")
 }
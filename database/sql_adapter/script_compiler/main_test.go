@@ -0,0 +1,106 @@
+package main_test
+
+import (
+	scriptcompiler "authserver/database/sql_adapter/script_compiler"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ScriptCompilerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScriptCompilerTestSuite) TestCountPlaceholders_WithNumberedPlaceholders_ReturnsHighestNumber() {
+	//arrange
+	scripts := []string{
+		`UPDATE "user" SET "username" = $2 WHERE "id" = $1 OR "id" = $2`,
+		`UPDATE "user" SET "username" = ?2 WHERE "id" = ?1 OR "id" = ?2`,
+	}
+
+	for _, script := range scripts {
+		//act
+		count, err := scriptcompiler.CountPlaceholders(script)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal(2, count, script)
+	}
+}
+
+func (suite *ScriptCompilerTestSuite) TestCountPlaceholders_WithPositionalPlaceholders_ReturnsNumberOfPlaceholders() {
+	//act
+	count, err := scriptcompiler.CountPlaceholders("DELETE FROM `user` WHERE `id` = ? AND `username` = ?")
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
+func (suite *ScriptCompilerTestSuite) TestCountPlaceholders_IgnoresLiteralsAndComments() {
+	//act
+	count, err := scriptcompiler.CountPlaceholders("-- where is $9?\nSELECT '?', '$3' FROM \"user\" WHERE \"id\" = $1")
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *ScriptCompilerTestSuite) TestCountPlaceholders_WithMixedPlaceholders_ReturnsError() {
+	//act
+	_, err := scriptcompiler.CountPlaceholders("SELECT * FROM scope WHERE id = ?1 AND name = ?")
+
+	//assert
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "mixes")
+}
+
+func (suite *ScriptCompilerTestSuite) TestScriptArities_DeclaresEveryRequiredScript() {
+	for _, name := range scriptcompiler.RequiredScripts() {
+		_, ok := scriptcompiler.ScriptArities[name]
+		suite.True(ok, name)
+	}
+}
+
+func (suite *ScriptCompilerTestSuite) TestValidateScripts_WithMissingScriptsAndWrongArity_ReturnsErrors() {
+	//arrange
+	scripts := []scriptcompiler.Script{
+		{Name: "SaveScope", Script: `INSERT INTO "scope" ("id", "name") VALUES ($1, $2, $3)`},
+	}
+
+	//act
+	errs := scriptcompiler.ValidateScripts(scripts)
+
+	//assert
+	suite.Len(errs, len(scriptcompiler.RequiredScripts()))
+
+	messages := ""
+	for _, err := range errs {
+		messages += err.Error() + "\n"
+	}
+	suite.Contains(messages, "missing script GetScopeByName")
+	suite.Contains(messages, "SaveScope has 3 placeholders but declares 2 arguments")
+	suite.NotContains(messages, "missing script SaveScope")
+}
+
+func (suite *ScriptCompilerTestSuite) TestValidateScripts_WithEachDriversScripts_ReturnsNoErrors() {
+	//arrange
+	dirs, err := scriptcompiler.ResolveScriptDirs("..", "", "")
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(dirs)
+
+	for _, dir := range dirs {
+		scripts, err := scriptcompiler.LoadScripts(dir)
+		suite.Require().NoError(err)
+
+		//act
+		errs := scriptcompiler.ValidateScripts(scripts)
+
+		//assert
+		suite.Empty(errs, dir)
+	}
+}
+
+func TestScriptCompilerTestSuite(t *testing.T) {
+	suite.Run(t, &ScriptCompilerTestSuite{})
+}
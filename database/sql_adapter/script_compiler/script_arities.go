@@ -0,0 +1,72 @@
+package main
+
+// ScriptArities declares the number of arguments the sql adapter passes to each script.
+// Every driver's version of a script must consume exactly this many arguments, in the same order.
+var ScriptArities = map[string]int{
+	//access token
+	"CreateAccessTokenTable":   0,
+	"DropAccessTokenTable":     0,
+	"SaveAccessToken":          4,
+	"GetAccessTokenById":       1,
+	"DeleteAccessToken":        1,
+	"DeleteAllOtherUserTokens": 2,
+	"DeleteAllUserTokens":      1,
+
+	//audit event
+	"CreateAuditEventTable": 0,
+	"DropAuditEventTable":   0,
+	"SaveAuditEvent":        10,
+	"GetAuditEvents":        9,
+
+	//client
+	"CreateClientTable":                      0,
+	"DropClientTable":                        0,
+	"AddClientTLSClientAuthSubjectDNColumn":  0,
+	"DropClientTLSClientAuthSubjectDNColumn": 0,
+	"SaveClient":                             2,
+	"SaveAppClient":                          1,
+	"GetClientById":                          1,
+
+	//migration
+	"CreateMigrationTable":       0,
+	"SaveMigration":              1,
+	"GetMigrationByTimestamp":    1,
+	"GetLatestTimestamp":         0,
+	"DeleteMigrationByTimestamp": 1,
+
+	//outbox event
+	"CreateOutboxEventTable":      0,
+	"DropOutboxEventTable":        0,
+	"SaveOutboxEvent":             9,
+	"GetPendingOutboxEvents":      2,
+	"GetDeadLetteredOutboxEvents": 2,
+	"UpdateOutboxEvent":           6,
+
+	//rate limit bucket
+	"CreateRateLimitBucketTable":          0,
+	"DropRateLimitBucketTable":            0,
+	"GetRateLimitBucketByKey":             1,
+	"SaveRateLimitBucket":                 3,
+	"DeleteRateLimitBucketsUpdatedBefore": 1,
+
+	//scope
+	"CreateScopeTable": 0,
+	"DropScopeTable":   0,
+	"SaveScope":        2,
+	"GetScopeByName":   1,
+
+	//user
+	"CreateUserTable":          0,
+	"DropUserTable":            0,
+	"AddUserIsAdminColumn":     0,
+	"DropUserIsAdminColumn":    0,
+	"AddUserIsDisabledColumn":  0,
+	"DropUserIsDisabledColumn": 0,
+	"SaveUser":                 5,
+	"GetUserById":              1,
+	"GetUserByUsername":        1,
+	"GetUsers":                 3,
+	"CountUsers":               1,
+	"UpdateUser":               5,
+	"DeleteUser":               1,
+}
@@ -1,6 +1,8 @@
 package sqladapter
 
 import (
+	"authserver/logger"
+	"authserver/metrics"
 	"context"
 	"time"
 )
@@ -19,11 +21,77 @@ type SQLAdapter struct {
 
 	// SQLDriver is a dependency for fetching the sql scripts and resolving the driver name.
 	SQLDriver SQLDriver
+
+	// MetricsRecorder is a dependency for recording query durations and transaction outcomes. Optional.
+	MetricsRecorder metrics.Recorder
+
+	// Logger is a dependency for logging. Optional, the default logger is used if not set.
+	Logger logger.Logger
+
+	scriptNames map[string]string
 }
 
 // CreateStandardTimeoutContext creates a context with the timeout loaded from the database config.
 // It is a child of the adapter's context and can be canceled by the adapter's cancel function.
 // Returns the created context and cancel function.
 func (adapter *SQLAdapter) CreateStandardTimeoutContext() (context.Context, context.CancelFunc) {
-	return context.WithTimeout(adapter.context, time.Duration(adapter.timeout)*time.Millisecond)
+	return adapter.CreateTimeoutContext(adapter.context)
+}
+
+// CreateTimeoutContext creates a child of the provided context with the timeout loaded from the database config,
+// so a query is canceled when either the timeout passes or the caller's context is done, such as when a client disconnects.
+// It is also canceled by the adapter's cancel function, so closing the connection cancels any queries still running.
+// Returns the created context and cancel function.
+func (adapter *SQLAdapter) CreateTimeoutContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	parent := ctx
+	ctx, cancel := context.WithTimeout(parent, time.Duration(adapter.timeout)*time.Millisecond)
+
+	if adapter.context == nil || parent == adapter.context {
+		return ctx, cancel
+	}
+
+	//cancel the context if the adapter's context is canceled first
+	go func() {
+		select {
+		case <-adapter.context.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// instrumentSQLExecuter wraps the executer so the duration of every script is recorded.
+// If the adapter has no metrics recorder, the executer is returned unchanged.
+func (adapter *SQLAdapter) instrumentSQLExecuter(executer SQLExecuter) SQLExecuter {
+	if adapter.MetricsRecorder == nil {
+		return executer
+	}
+
+	if adapter.scriptNames == nil {
+		adapter.scriptNames = CreateScriptNameMap(adapter.SQLDriver)
+	}
+
+	return InstrumentedSQLExecuter{
+		SQLExecuter:     executer,
+		MetricsRecorder: adapter.MetricsRecorder,
+		ScriptNames:     adapter.scriptNames,
+	}
+}
+
+// observeTransaction records the outcome of a transaction if the adapter has a metrics recorder.
+func (adapter *SQLAdapter) observeTransaction(outcome string) {
+	if adapter.MetricsRecorder != nil {
+		adapter.MetricsRecorder.ObserveTransaction(outcome)
+	}
+}
+
+// log returns the adapter's logger, or the default logger if it has none.
+func (adapter *SQLAdapter) log() logger.Logger {
+	if adapter.Logger == nil {
+		return logger.Default()
+	}
+
+	return adapter.Logger
 }
@@ -10,6 +10,11 @@ type SQLDB struct {
 
 	// DB is the sql database instance.
 	DB *sql.DB
+
+	// Replicas are the sql database instances of the read replicas. Empty if no replicas are configured.
+	Replicas []*sql.DB
+
+	isolationLevel sql.IsolationLevel
 }
 
 // CreateSQLDB creates a SQLDB with the supplied database key
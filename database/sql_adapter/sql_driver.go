@@ -6,4 +6,8 @@ type SQLDriver interface {
 
 	// GetDriverName returns the name for the driver.
 	GetDriverName() string
+
+	// ClassifyError returns the database error class of an error returned by the driver.
+	// Returns ErrorClassUnknown if the error is not one the driver recognizes.
+	ClassifyError(err error) string
 }
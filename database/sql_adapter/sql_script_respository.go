@@ -3,8 +3,11 @@ package sqladapter
 // SQLScriptRepository is an interface for encapsulating other sql script repository.
 type SQLScriptRepository interface {
 	AccessTokenScriptRepository
+	AuditEventScriptRepository
 	ClientScriptRepository
 	MigrationScriptRepository
+	OutboxEventScriptRepository
+	RateLimitBucketScriptRepository
 	ScopeScriptRepository
 	UserScriptRepository
 }
@@ -17,13 +20,25 @@ type AccessTokenScriptRepository interface {
 	GetAccessTokenByIdScript() string
 	DeleteAccessTokenScript() string
 	DeleteAllOtherUserTokensScript() string
+	DeleteAllUserTokensScript() string
+}
+
+// AuditEventScriptRepository is an interface for fetching audit event sql scripts.
+type AuditEventScriptRepository interface {
+	CreateAuditEventTableScript() string
+	DropAuditEventTableScript() string
+	SaveAuditEventScript() string
+	GetAuditEventsScript() string
 }
 
 // ClientScriptRepository is an interface for fetching client sql scripts.
 type ClientScriptRepository interface {
 	CreateClientTableScript() string
 	DropClientTableScript() string
+	AddClientTLSClientAuthSubjectDNColumnScript() string
+	DropClientTLSClientAuthSubjectDNColumnScript() string
 	SaveClientScript() string
+	SaveAppClientScript() string
 	GetClientByIdScript() string
 }
 
@@ -36,6 +51,25 @@ type MigrationScriptRepository interface {
 	DeleteMigrationByTimestampScript() string
 }
 
+// OutboxEventScriptRepository is an interface for fetching outbox event sql scripts.
+type OutboxEventScriptRepository interface {
+	CreateOutboxEventTableScript() string
+	DropOutboxEventTableScript() string
+	SaveOutboxEventScript() string
+	GetPendingOutboxEventsScript() string
+	GetDeadLetteredOutboxEventsScript() string
+	UpdateOutboxEventScript() string
+}
+
+// RateLimitBucketScriptRepository is an interface for fetching rate limit bucket sql scripts.
+type RateLimitBucketScriptRepository interface {
+	CreateRateLimitBucketTableScript() string
+	DropRateLimitBucketTableScript() string
+	GetRateLimitBucketByKeyScript() string
+	SaveRateLimitBucketScript() string
+	DeleteRateLimitBucketsUpdatedBeforeScript() string
+}
+
 // ScopeScriptRepository is an interface for fetching scope sql scripts.
 type ScopeScriptRepository interface {
 	CreateScopeTableScript() string
@@ -48,9 +82,15 @@ type ScopeScriptRepository interface {
 type UserScriptRepository interface {
 	CreateUserTableScript() string
 	DropUserTableScript() string
+	AddUserIsAdminColumnScript() string
+	DropUserIsAdminColumnScript() string
+	AddUserIsDisabledColumnScript() string
+	DropUserIsDisabledColumnScript() string
 	SaveUserScript() string
 	GetUserByIdScript() string
 	GetUserByUsernameScript() string
+	GetUsersScript() string
+	CountUsersScript() string
 	UpdateUserScript() string
 	DeleteUserScript() string
 }
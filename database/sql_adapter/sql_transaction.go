@@ -3,7 +3,12 @@ package sqladapter
 import (
 	"authserver/common"
 	"authserver/database"
+	"authserver/database/consistency"
+	"authserver/logger"
+	"authserver/metrics"
+	"context"
 	"database/sql"
+	"errors"
 )
 
 // SQLTransaction is a SQL implementation of the Transaction interface.
@@ -12,6 +17,9 @@ type SQLTransaction struct {
 
 	// TX is the sql transaction instance.
 	Tx *sql.Tx
+
+	tracker      *consistency.Tracker
+	retryableErr error
 }
 
 // SQLTransactionFactory is a SQL implementation of the TransactionFactory interface.
@@ -20,36 +28,74 @@ type SQLTransactionFactory struct {
 	DB *SQLDB
 }
 
-// CommitTransaction commits the sql transaction's transaction instance.
-// Returns any errors.
+// CommitTransaction commits the sql transaction's transaction instance,
+// and marks the transaction's request as having written so its later reads can be pinned to the primary.
+// Returns any errors, classified so failures caused by contention with other transactions can be retried.
 func (tx *SQLTransaction) CommitTransaction() error {
-	return tx.Tx.Commit()
+	err := tx.Tx.Commit()
+	if err != nil {
+		tx.observeTransaction(metrics.TransactionCommitError)
+		return classifyError(tx.SQLDriver, err)
+	}
+
+	tx.tracker.MarkWritten()
+
+	tx.log().Debug("transaction committed")
+	tx.observeTransaction(metrics.TransactionCommit)
+	return nil
 }
 
 // RollbackTransaction rollbacks the sql transaction's transaction instance.
-// Returns any errors.
+// Does nothing if the transaction was already rolled back because its context is done, such as when the client disconnected.
 func (tx *SQLTransaction) RollbackTransaction() {
 	err := tx.Tx.Rollback()
+	if errors.Is(err, sql.ErrTxDone) {
+		tx.log().Debug("transaction already rolled back")
+		return
+	}
 	if err != nil {
 		panic(err) //panic if can't rollback
 	}
+
+	tx.log().Debug("transaction rolled back")
+	tx.observeTransaction(metrics.TransactionRollback)
+}
+
+// RetryableError returns the first error the transaction's scripts returned that can be resolved by retrying the transaction.
+// Returns nil if there were none.
+func (tx *SQLTransaction) RetryableError() error {
+	return tx.retryableErr
+}
+
+// recordError records the error if it is the first retryable error returned by the transaction's scripts.
+func (tx *SQLTransaction) recordError(err *database.Error) {
+	if tx.retryableErr == nil && err.Retryable() {
+		tx.retryableErr = err
+	}
 }
 
-// CreateTransaction creates a new sql transaction. Returns any errors.
-func (f SQLTransactionFactory) CreateTransaction() (database.Transaction, error) {
-	tx, err := f.DB.DB.Begin()
+// CreateTransaction creates a new sql transaction using the isolation level from the database config. Returns any errors.
+// The transaction logs using the logger carried by the context, and is rolled back if the context is done before it is committed.
+func (f SQLTransactionFactory) CreateTransaction(ctx context.Context) (database.Transaction, error) {
+	tx, err := f.DB.DB.BeginTx(ctx, &sql.TxOptions{Isolation: f.DB.isolationLevel})
 	if err != nil {
-		return nil, common.ChainError("error beginning transaction", err)
+		return nil, common.ChainError("error beginning transaction", classifyError(f.DB.SQLDriver, err))
 	}
 
-	//copy the adapter and set its executor to the transaction
-	adapter := f.DB.SQLAdapter
-	adapter.SQLExecuter = tx
-
 	transaction := &SQLTransaction{
-		SQLAdapter: adapter,
+		SQLAdapter: f.DB.SQLAdapter,
 		Tx:         tx,
+		tracker:    consistency.FromContext(ctx),
 	}
 
+	//set the copied adapter's executor to the transaction
+	transaction.SQLExecuter = transaction.instrumentSQLExecuter(ClassifyingSQLExecuter{
+		SQLExecuter: tx,
+		SQLDriver:   transaction.SQLDriver,
+		OnError:     transaction.recordError,
+	})
+	transaction.Logger = logger.FromContext(ctx)
+
+	transaction.log().Debug("transaction started")
 	return transaction, nil
 }
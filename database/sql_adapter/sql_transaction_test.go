@@ -0,0 +1,39 @@
+package sqladapter_test
+
+import (
+	sqladapter "authserver/database/sql_adapter"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SQLTransactionTestSuite struct {
+	CRUDTestSuite
+}
+
+func (suite *SQLTransactionTestSuite) TestRollbackTransaction_WhereContextIsDone_DoesNotPanic() {
+	//arrange
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tx, err := suite.TransactionFactory.CreateTransaction(ctx)
+	suite.Require().NoError(err)
+	sqlTx := tx.(*sqladapter.SQLTransaction)
+
+	cancel()
+
+	//wait for database/sql to roll the transaction back on its own
+	suite.Require().Eventually(func() bool {
+		return errors.Is(sqlTx.Tx.Commit(), sql.ErrTxDone)
+	}, time.Second, time.Millisecond)
+
+	//act & assert
+	suite.NotPanics(tx.RollbackTransaction)
+}
+
+func TestSQLTransactionTestSuite(t *testing.T) {
+	suite.Run(t, &SQLTransactionTestSuite{})
+}
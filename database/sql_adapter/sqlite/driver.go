@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"authserver/database"
+	"authserver/database/sql_adapter/sqlite/scripts"
+	"database/sql"
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// DriverName is the name the sqlite driver is registered under.
+const DriverName = "sqlite3_authserver"
+
+func init() {
+	//register a sqlite driver that enforces foreign keys on every connection, since sqlite disables them by default
+	sql.Register(DriverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			_, err := conn.Exec("PRAGMA foreign_keys = ON", nil)
+			return err
+		},
+	})
+}
+
+// Driver is an implementation of the SQL Driver interface for sqlite.
+// Uuids are stored as text and times as text in UTC, so they compare and sort correctly.
+type Driver struct {
+	scripts.ScriptRepository
+}
+
+// GetDriverName returns the sqlite driver name.
+func (Driver) GetDriverName() string {
+	return DriverName
+}
+
+// ClassifyError returns the database error class of the sqlite error using its result code.
+// Sqlite serializes writes, so contention shows up as the database being busy or locked.
+func (Driver) ClassifyError(err error) string {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return database.ErrorClassUnknown
+	}
+
+	switch {
+	case sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked:
+		return database.ErrorClassLockTimeout
+	case sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey:
+		return database.ErrorClassUniqueViolation
+	default:
+		return database.ErrorClassUnknown
+	}
+}
@@ -0,0 +1,10 @@
+CREATE TABLE "access_token" (
+	"id" text NOT NULL,
+	"user_id" text NOT NULL,
+	"client_id" text NOT NULL,
+	"scope_id" text NOT NULL,
+	CONSTRAINT "access_token_pk" PRIMARY KEY ("id"),
+	CONSTRAINT "access_token_user_fk" FOREIGN KEY ("user_id") REFERENCES "user"("id") ON DELETE CASCADE,
+	CONSTRAINT "access_token_client_fk" FOREIGN KEY ("client_id") REFERENCES "client"("id") ON DELETE CASCADE,
+	CONSTRAINT "access_token_scope_fk" FOREIGN KEY ("scope_id") REFERENCES "scope"("id") ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "access_token"
+    WHERE "user_id" = ?1 AND "id" != ?2
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "access_token"
+    WHERE "user_id" = ?1
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "access_token"
\ No newline at end of file
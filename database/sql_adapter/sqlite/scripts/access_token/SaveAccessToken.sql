@@ -0,0 +1,2 @@
+INSERT INTO "access_token" ("id", "user_id", "client_id", "scope_id")
+	VALUES (?1, ?2, ?3, ?4)
\ No newline at end of file
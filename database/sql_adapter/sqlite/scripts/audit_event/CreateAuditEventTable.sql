@@ -0,0 +1,14 @@
+CREATE TABLE "audit_event" (
+	"id" text NOT NULL,
+	"timestamp" timestamp NOT NULL,
+	"action" varchar(30) NOT NULL,
+	"outcome" varchar(15) NOT NULL,
+	"actor_id" text NULL,
+	"target_id" text NULL,
+	"client_id" text NULL,
+	"ip_address" varchar(45) NOT NULL,
+	"user_agent" varchar(255) NOT NULL,
+	"details" varchar(255) NOT NULL,
+	CONSTRAINT "audit_event_pk" PRIMARY KEY ("id")
+);
+CREATE INDEX "audit_event_timestamp_idx" ON "audit_event" ("timestamp");
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "audit_event"
\ No newline at end of file
@@ -0,0 +1,4 @@
+CREATE TABLE "client" (
+	"id" text NOT NULL,
+	CONSTRAINT "client_pk" PRIMARY KEY ("id")
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "client"
+	DROP COLUMN "tls_client_auth_subject_dn"
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO "client" ("id")
+	VALUES (?1)
\ No newline at end of file
@@ -0,0 +1,2 @@
+DELETE FROM "migration"
+   WHERE "timestamp" = ?1
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE "outbox_event" (
+	"id" text NOT NULL,
+	"type" varchar(50) NOT NULL,
+	"payload" text NOT NULL,
+	"created_at" timestamp NOT NULL,
+	"attempts" integer NOT NULL,
+	"next_attempt_at" timestamp NOT NULL,
+	"last_error" varchar(255) NOT NULL,
+	"delivered_at" timestamp NULL,
+	"dead_lettered" boolean NOT NULL,
+	CONSTRAINT "outbox_event_pk" PRIMARY KEY ("id")
+);
+CREATE INDEX "outbox_event_pending_idx" ON "outbox_event" ("next_attempt_at") WHERE "delivered_at" IS NULL AND NOT "dead_lettered";
\ No newline at end of file
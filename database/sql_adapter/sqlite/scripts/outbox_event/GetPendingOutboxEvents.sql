@@ -0,0 +1,5 @@
+SELECT e."id", e."type", e."payload", e."created_at", e."attempts", e."next_attempt_at", e."last_error", e."delivered_at", e."dead_lettered"
+	FROM "outbox_event" e
+	WHERE e."delivered_at" IS NULL AND NOT e."dead_lettered" AND e."next_attempt_at" <= ?1
+	ORDER BY e."next_attempt_at", e."id"
+	LIMIT ?2
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO "outbox_event" ("id", "type", "payload", "created_at", "attempts", "next_attempt_at", "last_error", "delivered_at", "dead_lettered")
+	VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
\ No newline at end of file
@@ -0,0 +1,7 @@
+UPDATE "outbox_event" SET
+    "attempts" = ?2,
+    "next_attempt_at" = ?3,
+    "last_error" = ?4,
+    "delivered_at" = ?5,
+    "dead_lettered" = ?6
+WHERE "id" = ?1
\ No newline at end of file
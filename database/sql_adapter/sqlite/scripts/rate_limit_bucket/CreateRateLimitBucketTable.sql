@@ -0,0 +1,7 @@
+CREATE TABLE "rate_limit_bucket" (
+	"key" varchar(64) NOT NULL,
+	"tokens" real NOT NULL,
+	"updated_at" timestamp NOT NULL,
+	CONSTRAINT "rate_limit_bucket_pk" PRIMARY KEY ("key")
+);
+CREATE INDEX "rate_limit_bucket_updated_at_idx" ON "rate_limit_bucket" ("updated_at");
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "rate_limit_bucket"
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT b."key", b."tokens", b."updated_at"
+	FROM "rate_limit_bucket" b
+	WHERE b."key" = ?1
\ No newline at end of file
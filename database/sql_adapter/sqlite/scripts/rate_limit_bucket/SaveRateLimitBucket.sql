@@ -0,0 +1,5 @@
+INSERT INTO "rate_limit_bucket" ("key", "tokens", "updated_at")
+	VALUES (?1, ?2, ?3)
+	ON CONFLICT ("key") DO UPDATE SET
+		"tokens" = excluded."tokens",
+		"updated_at" = excluded."updated_at"
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE "scope"
\ No newline at end of file
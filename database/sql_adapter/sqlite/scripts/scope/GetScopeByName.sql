@@ -0,0 +1,3 @@
+SELECT s."id", s."name"
+	FROM "scope" s
+	WHERE s."name" = ?1
\ No newline at end of file
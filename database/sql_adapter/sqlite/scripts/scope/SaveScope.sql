@@ -0,0 +1,2 @@
+INSERT INTO "scope" ("id", "name")
+	VALUES (?1, ?2)
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE "user"
+	ADD COLUMN "is_admin" boolean NOT NULL DEFAULT false
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE "user" (
+	"id" text NOT NULL,
+	"username" varchar(30) NOT NULL,
+	"password_hash" blob NOT NULL,
+	CONSTRAINT "user_pk" PRIMARY KEY ("id"),
+	CONSTRAINT "user_username_un" UNIQUE ("username")
+);
\ No newline at end of file
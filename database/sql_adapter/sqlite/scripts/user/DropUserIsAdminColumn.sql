@@ -0,0 +1,2 @@
+ALTER TABLE "user"
+	DROP COLUMN "is_admin"
\ No newline at end of file
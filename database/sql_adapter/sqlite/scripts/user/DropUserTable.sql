@@ -0,0 +1 @@
+DROP TABLE "user"
\ No newline at end of file
@@ -0,0 +1,3 @@
+SELECT u."id", u."username", u."password_hash", u."is_admin", u."is_disabled"
+	FROM "user" u
+	WHERE u."username" = ?1
\ No newline at end of file
@@ -0,0 +1,2 @@
+INSERT INTO "user" ("id", "username", "password_hash", "is_admin", "is_disabled")
+	VALUES (?1, ?2, ?3, ?4, ?5)
\ No newline at end of file
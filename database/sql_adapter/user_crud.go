@@ -3,6 +3,7 @@ package sqladapter
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -12,15 +13,15 @@ import (
 
 // SaveUser validates the user model is valid and inserts a new row into the user table.
 // Returns any errors.
-func (adapter *SQLAdapter) SaveUser(user *models.User) error {
+func (adapter *SQLAdapter) SaveUser(ctx context.Context, user *models.User) error {
 	verr := user.Validate()
 	if verr != models.ValidateUserValid {
 		return errors.New(fmt.Sprint("error validating user model:", verr))
 	}
 
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.SaveUserScript(),
-		user.ID, user.Username, user.PasswordHash)
+		user.ID, user.Username, user.PasswordHash, user.IsAdmin, user.IsDisabled)
 	cancel()
 
 	if err != nil {
@@ -32,8 +33,8 @@ func (adapter *SQLAdapter) SaveUser(user *models.User) error {
 
 // GetUserByID gets the row in the user table with the matching id, and creates a new user model using its data.
 // Returns the model and any errors.
-func (adapter *SQLAdapter) GetUserByID(ID uuid.UUID) (*models.User, error) {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) GetUserByID(ctx context.Context, ID uuid.UUID) (*models.User, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetUserByIdScript(), ID)
 	defer cancel()
 
@@ -47,8 +48,8 @@ func (adapter *SQLAdapter) GetUserByID(ID uuid.UUID) (*models.User, error) {
 
 // GetUserByUsername gets the row in the user table with the matching username, and creates a new user model using its data.
 // Returns the model and any errors.
-func (adapter *SQLAdapter) GetUserByUsername(username string) (*models.User, error) {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetUserByUsernameScript(), username)
 	defer cancel()
 
@@ -60,17 +61,80 @@ func (adapter *SQLAdapter) GetUserByUsername(username string) (*models.User, err
 	return readUserData(rows)
 }
 
+// GetUsers gets the rows in the user table that match the filter, ordered by username, and creates new user models using their data.
+// The filter's limit and offset are normalized before being used.
+// Returns the models and any errors.
+func (adapter *SQLAdapter) GetUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	filter.Normalize()
+
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.GetUsersScript(),
+		nullString(filter.Username), filter.Limit, filter.Offset)
+	defer cancel()
+
+	if err != nil {
+		return nil, common.ChainError("error executing get users query", err)
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, common.ChainError("error preparing next row", err)
+	}
+
+	return users, nil
+}
+
+// CountUsers counts the rows in the user table that match the filter, ignoring its limit and offset.
+// Returns the count and any errors.
+func (adapter *SQLAdapter) CountUsers(ctx context.Context, filter models.UserFilter) (int, error) {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
+	rows, err := adapter.SQLExecuter.QueryContext(ctx, adapter.SQLDriver.CountUsersScript(), nullString(filter.Username))
+	defer cancel()
+
+	if err != nil {
+		return 0, common.ChainError("error executing count users query", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return 0, common.ChainError("error preparing next row", err)
+		}
+		return 0, errors.New("count users query returned no rows")
+	}
+
+	var count int
+	err = rows.Scan(&count)
+	if err != nil {
+		return 0, common.ChainError("error reading row", err)
+	}
+
+	return count, nil
+}
+
 // UpdateUser validates the user model is valid and updates the row in the user table with the matching id.
 // Returns any errors.
-func (adapter *SQLAdapter) UpdateUser(user *models.User) error {
+func (adapter *SQLAdapter) UpdateUser(ctx context.Context, user *models.User) error {
 	verr := user.Validate()
 	if verr != models.ValidateUserValid {
 		return errors.New(fmt.Sprint("error validating user model:", verr))
 	}
 
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.UpdateUserScript(),
-		user.ID, user.Username, user.PasswordHash)
+		user.ID, user.Username, user.PasswordHash, user.IsAdmin, user.IsDisabled)
 	cancel()
 
 	if err != nil {
@@ -82,8 +146,8 @@ func (adapter *SQLAdapter) UpdateUser(user *models.User) error {
 
 // DeleteUser deletes the row in the user table with the matching id.
 // Returns any errors.
-func (adapter *SQLAdapter) DeleteUser(user *models.User) error {
-	ctx, cancel := adapter.CreateStandardTimeoutContext()
+func (adapter *SQLAdapter) DeleteUser(ctx context.Context, user *models.User) error {
+	ctx, cancel := adapter.CreateTimeoutContext(ctx)
 	_, err := adapter.SQLExecuter.ExecContext(ctx, adapter.SQLDriver.DeleteUserScript(), user.ID)
 	cancel()
 
@@ -107,8 +171,12 @@ func readUserData(rows *sql.Rows) (*models.User, error) {
 	}
 
 	//get the result
+	return scanUser(rows)
+}
+
+func scanUser(rows *sql.Rows) (*models.User, error) {
 	user := &models.User{}
-	err := rows.Scan(&user.ID, &user.Username, &user.PasswordHash)
+	err := rows.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.IsAdmin, &user.IsDisabled)
 	if err != nil {
 		return nil, common.ChainError("error reading row", err)
 	}
@@ -3,6 +3,8 @@ package sqladapter_test
 import (
 	"authserver/common"
 	"authserver/models"
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -15,7 +17,7 @@ type UserCRUDTestSuite struct {
 
 func (suite *UserCRUDTestSuite) TestSaveUser_WithInvalidUser_ReturnsError() {
 	//act
-	err := suite.Tx.SaveUser(models.CreateNewUser("", nil))
+	err := suite.Tx.SaveUser(context.Background(), models.CreateNewUser("", nil))
 
 	//assert
 	common.AssertError(&suite.Suite, err, "error", "user model")
@@ -23,20 +25,32 @@ func (suite *UserCRUDTestSuite) TestSaveUser_WithInvalidUser_ReturnsError() {
 
 func (suite *UserCRUDTestSuite) TestGetUserById_WhereUserNotFound_ReturnsNilUser() {
 	//act
-	user, err := suite.Tx.GetUserByID(uuid.New())
+	user, err := suite.Tx.GetUserByID(context.Background(), uuid.New())
 
 	//assert
 	suite.NoError(err)
 	suite.Nil(user)
 }
 
+func (suite *UserCRUDTestSuite) TestGetUserById_WithCanceledContext_ReturnsError() {
+	//arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//act
+	_, err := suite.Tx.GetUserByID(ctx, uuid.New())
+
+	//assert
+	common.AssertError(&suite.Suite, err, "context canceled")
+}
+
 func (suite *UserCRUDTestSuite) TestGetUserById_GetsTheUserWithId() {
 	//arrange
 	user := models.CreateNewUser("username", []byte("password"))
 	suite.SaveUser(suite.Tx, user)
 
 	//act
-	resultUser, err := suite.Tx.GetUserByID(user.ID)
+	resultUser, err := suite.Tx.GetUserByID(context.Background(), user.ID)
 
 	//assert
 	suite.NoError(err)
@@ -45,7 +59,7 @@ func (suite *UserCRUDTestSuite) TestGetUserById_GetsTheUserWithId() {
 
 func (suite *UserCRUDTestSuite) TestGetUserByUsername_WhereUserNotFound_ReturnsNilUser() {
 	//act
-	user, err := suite.Tx.GetUserByUsername("DNE")
+	user, err := suite.Tx.GetUserByUsername(context.Background(), "DNE")
 
 	//assert
 	suite.NoError(err)
@@ -58,16 +72,71 @@ func (suite *UserCRUDTestSuite) TestGetUserByUsernameGetsTheUserWithUsername() {
 	suite.SaveUser(suite.Tx, user)
 
 	//act
-	resultUser, err := suite.Tx.GetUserByUsername(user.Username)
+	resultUser, err := suite.Tx.GetUserByUsername(context.Background(), user.Username)
 
 	//assert
 	suite.NoError(err)
 	suite.EqualValues(user, resultUser)
 }
 
+func (suite *UserCRUDTestSuite) TestGetUsers_GetsMatchingUsersOrderedByUsername() {
+	//arrange
+	prefix := uuid.New().String()[:8]
+	user1 := models.CreateNewUser(prefix+"-bob", []byte("password"))
+	user2 := models.CreateNewUser(prefix+"-alice", []byte("password"))
+	user2.IsDisabled = true
+	other := models.CreateNewUser("other-"+prefix[:4], []byte("password"))
+
+	for _, user := range []*models.User{user1, user2, other} {
+		suite.SaveUser(suite.Tx, user)
+	}
+
+	//act
+	users, err := suite.Tx.GetUsers(context.Background(), models.UserFilter{Username: strings.ToUpper(prefix + "-")})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]*models.User{user2, user1}, users)
+}
+
+func (suite *UserCRUDTestSuite) TestGetUsers_WithLimitAndOffset_GetsPageOfUsers() {
+	//arrange
+	prefix := uuid.New().String()[:8]
+	users := []*models.User{
+		models.CreateNewUser(prefix+"-a", []byte("password")),
+		models.CreateNewUser(prefix+"-b", []byte("password")),
+		models.CreateNewUser(prefix+"-c", []byte("password")),
+	}
+	for _, user := range users {
+		suite.SaveUser(suite.Tx, user)
+	}
+
+	//act
+	result, err := suite.Tx.GetUsers(context.Background(), models.UserFilter{Username: prefix, Limit: 1, Offset: 1})
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal([]*models.User{users[1]}, result)
+}
+
+func (suite *UserCRUDTestSuite) TestCountUsers_CountsAllMatchingUsers() {
+	//arrange
+	prefix := uuid.New().String()[:8]
+	for _, username := range []string{prefix + "-a", prefix + "-b", "other-" + prefix[:4]} {
+		suite.SaveUser(suite.Tx, models.CreateNewUser(username, []byte("password")))
+	}
+
+	//act
+	count, err := suite.Tx.CountUsers(context.Background(), models.UserFilter{Username: prefix, Limit: 1})
+
+	//assert
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
 func (suite *UserCRUDTestSuite) TestUpdateUser_WithInvalidUser_ReturnsError() {
 	//act
-	err := suite.Tx.UpdateUser(models.CreateNewUser("", nil))
+	err := suite.Tx.UpdateUser(context.Background(), models.CreateNewUser("", nil))
 
 	//assert
 	common.AssertError(&suite.Suite, err, "error", "user model")
@@ -75,7 +144,7 @@ func (suite *UserCRUDTestSuite) TestUpdateUser_WithInvalidUser_ReturnsError() {
 
 func (suite *UserCRUDTestSuite) TestUpdateUser_WithNoUserToUpdate_ReturnsNilError() {
 	//act
-	err := suite.Tx.UpdateUser(models.CreateNewUser("username", []byte("password")))
+	err := suite.Tx.UpdateUser(context.Background(), models.CreateNewUser("username", []byte("password")))
 
 	//assert
 	suite.NoError(err)
@@ -88,19 +157,21 @@ func (suite *UserCRUDTestSuite) TestUpdateUser_UpdatesUserWithId() {
 
 	//act
 	user.Username = "username2"
-	err := suite.Tx.UpdateUser(user)
+	user.IsAdmin = true
+	user.IsDisabled = true
+	err := suite.Tx.UpdateUser(context.Background(), user)
 
 	//assert
 	suite.Require().NoError(err)
 
-	resultUser, err := suite.Tx.GetUserByID(user.ID)
+	resultUser, err := suite.Tx.GetUserByID(context.Background(), user.ID)
 	suite.NoError(err)
 	suite.EqualValues(user, resultUser)
 }
 
 func (suite *UserCRUDTestSuite) TestDeleteUser_WithNoUserToDelete_ReturnsNilError() {
 	//act
-	err := suite.Tx.DeleteUser(models.CreateNewUser("", nil))
+	err := suite.Tx.DeleteUser(context.Background(), models.CreateNewUser("", nil))
 
 	//assert
 	suite.NoError(err)
@@ -112,12 +183,12 @@ func (suite *UserCRUDTestSuite) TestDeleteUser_DeletesUserWithId() {
 	suite.SaveUser(suite.Tx, user)
 
 	//act
-	err := suite.Tx.DeleteUser(user)
+	err := suite.Tx.DeleteUser(context.Background(), user)
 
 	//assert
 	suite.Require().NoError(err)
 
-	resultUser, err := suite.Tx.GetUserByID(user.ID)
+	resultUser, err := suite.Tx.GetUserByID(context.Background(), user.ID)
 	suite.NoError(err)
 	suite.Nil(resultUser)
 }
@@ -133,12 +204,12 @@ func (suite *UserCRUDTestSuite) TestDeleteUser_AlsoDeletesAllUserTokens() {
 	suite.SaveAccessTokenAndFields(suite.Tx, token)
 
 	//act
-	err := suite.Tx.DeleteUser(user)
+	err := suite.Tx.DeleteUser(context.Background(), user)
 
 	//assert
 	suite.Require().NoError(err)
 
-	resultAccessToken, err := suite.Tx.GetAccessTokenByID(token.ID)
+	resultAccessToken, err := suite.Tx.GetAccessTokenByID(context.Background(), token.ID)
 	suite.NoError(err)
 	suite.Nil(resultAccessToken)
 }
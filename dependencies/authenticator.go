@@ -1,8 +1,11 @@
 package dependencies
 
 import (
+	"authserver/config"
 	"authserver/router"
 	"sync"
+
+	"github.com/spf13/viper"
 )
 
 var createAuthenticatorOnce sync.Once
@@ -10,10 +13,23 @@ var authenticator router.Authenticator
 
 // ResolveAuthenticator resolves the Authenticator dependency.
 // Only the first call to this function will create a new Authenticator, after which it will be retrieved from memory.
+// Authenticated tokens are cached if the token cache is enabled.
 func ResolveAuthenticator() router.Authenticator {
 	createAuthenticatorOnce.Do(func() {
+		serverConfig, _ := viper.Get("server").(config.ServerConfig)
+
 		authenticator = &router.OAuthAuthenticator{
-			CRUD: ResolveDatabase(),
+			CRUD:               ResolveDatabase(),
+			AllowFormParameter: serverConfig.AllowAccessTokenFormParameter,
+		}
+
+		if cache := ResolveTokenCache(); cache != nil {
+			authenticator = &router.CachingAuthenticator{
+				Authenticator:      authenticator,
+				Cache:              cache,
+				MetricsRecorder:    ResolveMetricsRecorder(),
+				AllowFormParameter: serverConfig.AllowAccessTokenFormParameter,
+			}
 		}
 	})
 	return authenticator
@@ -17,8 +17,13 @@ func ResolveControllers() controllerspkg.Controllers {
 				PasswordHasher:            ResolvePasswordHasher(),
 				PasswordCriteriaValidator: ResolvePasswordCriteriaValidator(),
 			},
+			AdminUserControl: controllerspkg.AdminUserControl{
+				PasswordHasher:            ResolvePasswordHasher(),
+				PasswordCriteriaValidator: ResolvePasswordCriteriaValidator(),
+			},
 			TokenControl: controllerspkg.TokenControl{
-				PasswordHasher: ResolvePasswordHasher(),
+				PasswordHasher:  ResolvePasswordHasher(),
+				MetricsRecorder: ResolveMetricsRecorder(),
 			},
 		}
 	})
@@ -0,0 +1,42 @@
+package dependencies
+
+import (
+	"authserver/config"
+	"authserver/router"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var createCORSPolicyOnce sync.Once
+var corsPolicy *router.CORSPolicy
+
+// ResolveCORSPolicy resolves the CORSPolicy dependency.
+// Only the first call to this function will create a new CORSPolicy, after which it will be retrieved from memory.
+// Returns nil if no origins are allowed in the cors config.
+func ResolveCORSPolicy() *router.CORSPolicy {
+	createCORSPolicyOnce.Do(func() {
+		cfg, _ := viper.Get("cors").(config.CORSConfig)
+
+		clientOrigins := make(map[string][]string)
+		for _, client := range cfg.Clients {
+			clientOrigins[client.ClientID] = append(clientOrigins[client.ClientID], client.Origins...)
+		}
+
+		if len(cfg.AllowedOrigins) == 0 && len(clientOrigins) == 0 {
+			return
+		}
+
+		corsPolicy = &router.CORSPolicy{
+			AllowedOrigins:   cfg.AllowedOrigins,
+			ClientOrigins:    clientOrigins,
+			AllowedMethods:   cfg.AllowedMethods,
+			AllowedHeaders:   cfg.AllowedHeaders,
+			ExposedHeaders:   cfg.ExposedHeaders,
+			AllowCredentials: cfg.AllowCredentials,
+			MaxAge:           time.Duration(cfg.MaxAge) * time.Millisecond,
+		}
+	})
+	return corsPolicy
+}
@@ -1,7 +1,9 @@
 package dependencies
 
 import (
+	"authserver/config"
 	databasepkg "authserver/database"
+	"authserver/database/inmemory"
 	sqladapter "authserver/database/sql_adapter"
 	"sync"
 
@@ -13,9 +15,24 @@ var database databasepkg.Database
 
 // ResolveDatabase resolves the Database dependency.
 // Only the first call to this function will create a new Database, after which it will be retrieved from memory.
+// The database's implementation is chosen using the adapter in the database config.
 func ResolveDatabase() databasepkg.Database {
 	createDatabaseOnce.Do(func() {
-		database = sqladapter.CreateSQLDB(viper.GetString("db_key"), ResolveSQLDriver())
+		dbConfig, _ := viper.Get("database").(config.DatabaseConfig)
+
+		if dbConfig.Adapter == config.DatabaseAdapterInMemory {
+			db := inmemory.CreateDB()
+			db.Logger = ResolveLogger()
+
+			database = db
+			return
+		}
+
+		db := sqladapter.CreateSQLDB(viper.GetString("db_key"), ResolveSQLDriver())
+		db.MetricsRecorder = ResolveMetricsRecorder()
+		db.Logger = ResolveLogger()
+
+		database = db
 	})
 	return database
 }
@@ -0,0 +1,62 @@
+package dependencies
+
+import (
+	sqladapter "authserver/database/sql_adapter"
+	"authserver/health"
+	"sync"
+)
+
+var createShutdownCheckOnce sync.Once
+var shutdownCheck *health.ShutdownCheck
+
+// ResolveShutdownCheck resolves the ShutdownCheck dependency.
+// Only the first call to this function will create a new ShutdownCheck, after which it will be retrieved from memory.
+func ResolveShutdownCheck() *health.ShutdownCheck {
+	createShutdownCheckOnce.Do(func() {
+		shutdownCheck = &health.ShutdownCheck{}
+	})
+	return shutdownCheck
+}
+
+var createLivenessCheckerOnce sync.Once
+var livenessChecker health.Checker
+
+// ResolveLivenessChecker resolves the liveness Checker dependency.
+// Only the first call to this function will create a new Checker, after which it will be retrieved from memory.
+func ResolveLivenessChecker() health.Checker {
+	createLivenessCheckerOnce.Do(func() {
+		livenessChecker = health.Checker{
+			Checks: map[string]health.Check{
+				"process": health.ProcessCheck{},
+			},
+		}
+	})
+	return livenessChecker
+}
+
+var createReadinessCheckerOnce sync.Once
+var readinessChecker health.Checker
+
+// ResolveReadinessChecker resolves the readiness Checker dependency.
+// Only the first call to this function will create a new Checker, after which it will be retrieved from memory.
+func ResolveReadinessChecker() health.Checker {
+	createReadinessCheckerOnce.Do(func() {
+		readinessChecker = health.Checker{
+			Checks: map[string]health.Check{
+				"database": health.DatabaseCheck{
+					DBConnection: ResolveDatabase(),
+				},
+				"shutdown": ResolveShutdownCheck(),
+			},
+		}
+
+		//only sql databases are migrated
+		if _, ok := ResolveDatabase().(*sqladapter.SQLDB); ok {
+			readinessChecker.Checks["migrations"] = health.MigrationCheck{
+				MigrationCRUD:       ResolveDatabase(),
+				MigrationRepository: ResolveMigrationRepository(),
+			}
+		}
+	})
+	return readinessChecker
+}
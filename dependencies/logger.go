@@ -0,0 +1,31 @@
+package dependencies
+
+import (
+	"authserver/config"
+	"authserver/logger"
+	"os"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+var createLoggerOnce sync.Once
+var loggerInstance logger.Logger
+
+// ResolveLogger resolves the Logger dependency.
+// Only the first call to this function will create a new Logger, after which it will be retrieved from memory.
+// The created logger also becomes the default logger.
+func ResolveLogger() logger.Logger {
+	createLoggerOnce.Do(func() {
+		cfg, _ := viper.Get("logging").(config.LoggingConfig)
+
+		level, err := logger.ParseLevel(cfg.Level)
+		if err != nil {
+			logger.Default().Warn(err.Error())
+		}
+
+		loggerInstance = logger.CreateStandardLogger(os.Stdout, cfg.Format, level)
+		logger.SetDefault(loggerInstance)
+	})
+	return loggerInstance
+}
@@ -0,0 +1,18 @@
+package dependencies
+
+import (
+	"authserver/metrics"
+	"sync"
+)
+
+var createMetricsRecorderOnce sync.Once
+var metricsRecorder metrics.Recorder
+
+// ResolveMetricsRecorder resolves the MetricsRecorder dependency.
+// Only the first call to this function will create a new MetricsRecorder, after which it will be retrieved from memory.
+func ResolveMetricsRecorder() metrics.Recorder {
+	createMetricsRecorderOnce.Do(func() {
+		metricsRecorder = metrics.CreatePrometheusRecorder()
+	})
+	return metricsRecorder
+}
@@ -12,7 +12,9 @@ var passwordHasher passwordhelpers.PasswordHasher
 // Only the first call to this function will create a new PasswordHasher, after which it will be retrieved from memory.
 func ResolvePasswordHasher() passwordhelpers.PasswordHasher {
 	createPasswordHasherOnce.Do(func() {
-		passwordHasher = passwordhelpers.BCryptPasswordHasher{}
+		passwordHasher = passwordhelpers.BCryptPasswordHasher{
+			MetricsRecorder: ResolveMetricsRecorder(),
+		}
 	})
 	return passwordHasher
 }
@@ -0,0 +1,74 @@
+package dependencies
+
+import (
+	"authserver/config"
+	"authserver/ratelimit"
+	"authserver/router"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var createRateLimiterOnce sync.Once
+var rateLimiter *ratelimit.Limiter
+
+// ResolveRateLimiter resolves the rate Limiter dependency.
+// Only the first call to this function will create a new Limiter, after which it will be retrieved from memory.
+// Returns nil if no rate limits are enabled in the rate limit config.
+func ResolveRateLimiter() *ratelimit.Limiter {
+	createRateLimiterOnce.Do(func() {
+		cfg, _ := viper.Get("rate_limit").(config.RateLimitConfig)
+
+		routes := map[string]ratelimit.Rules{
+			router.RateLimitRouteToken:      createRateLimitRules(cfg.Token),
+			router.RateLimitRouteCreateUser: createRateLimitRules(cfg.CreateUser),
+		}
+
+		//find the longest period so the database store keeps buckets until they are full
+		var maxPeriod time.Duration
+		for _, rules := range routes {
+			for _, limit := range []ratelimit.Limit{rules.IP, rules.ClientID, rules.Username} {
+				if limit.Enabled() && limit.Period > maxPeriod {
+					maxPeriod = limit.Period
+				}
+			}
+		}
+
+		if maxPeriod == 0 {
+			return
+		}
+
+		rateLimiter = &ratelimit.Limiter{
+			Store:  createRateLimitStore(cfg.Store, maxPeriod),
+			Routes: routes,
+		}
+	})
+	return rateLimiter
+}
+
+func createRateLimitStore(store string, retention time.Duration) ratelimit.Store {
+	if store == config.RateLimitStoreDatabase {
+		return &ratelimit.DatabaseStore{
+			TransactionFactory: ResolveTransactionFactory(),
+			Retention:          retention,
+		}
+	}
+
+	return ratelimit.CreateMemoryStore()
+}
+
+func createRateLimitRules(cfg config.RateLimitRulesConfig) ratelimit.Rules {
+	return ratelimit.Rules{
+		IP:       createRateLimit(cfg.IP),
+		ClientID: createRateLimit(cfg.ClientID),
+		Username: createRateLimit(cfg.Username),
+	}
+}
+
+func createRateLimit(cfg config.RateLimitRuleConfig) ratelimit.Limit {
+	return ratelimit.Limit{
+		Requests: cfg.Requests,
+		Period:   time.Duration(cfg.Period) * time.Millisecond,
+	}
+}
@@ -1,8 +1,13 @@
 package dependencies
 
 import (
+	"authserver/config"
+	"authserver/metrics"
 	"authserver/router"
 	"sync"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 var createRouterFactoryOnce sync.Once
@@ -12,10 +17,27 @@ var routerFactory router.RouterFactory
 // Only the first call to this function will create a new RouterFactory, after which it will be retrieved from memory.
 func ResolveRouterFactory() router.IRouterFactory {
 	createRouterFactoryOnce.Do(func() {
+		serverConfig, _ := viper.Get("server").(config.ServerConfig)
+		dbConfig, _ := viper.Get("database").(config.DatabaseConfig)
+
 		routerFactory = router.RouterFactory{
 			Controllers:        ResolveControllers(),
 			Authenticator:      ResolveAuthenticator(),
 			TransactionFactory: ResolveTransactionFactory(),
+			LivenessChecker:    ResolveLivenessChecker(),
+			ReadinessChecker:   ResolveReadinessChecker(),
+			MetricsRecorder:    ResolveMetricsRecorder(),
+			MetricsHandler:     ResolveMetricsRecorder().(*metrics.PrometheusRecorder).Handler(),
+			Logger:             ResolveLogger(),
+			RateLimiter:        ResolveRateLimiter(),
+			CORS:               ResolveCORSPolicy(),
+			MaxBodyBytes:       serverConfig.MaxBodyBytes,
+			RouteMaxBodyBytes:  serverConfig.RouteMaxBodyBytes,
+			TransactionRetryPolicy: router.RetryPolicy{
+				MaxRetries: dbConfig.TransactionRetries,
+				BaseDelay:  time.Duration(dbConfig.TransactionRetryBaseDelay) * time.Millisecond,
+				MaxDelay:   time.Duration(dbConfig.TransactionRetryMaxDelay) * time.Millisecond,
+			},
 		}
 	})
 	return routerFactory
@@ -1,9 +1,14 @@
 package dependencies
 
 import (
+	"authserver/config"
 	sqladapter "authserver/database/sql_adapter"
+	"authserver/database/sql_adapter/mysql"
 	"authserver/database/sql_adapter/postgres"
+	"authserver/database/sql_adapter/sqlite"
 	"sync"
+
+	"github.com/spf13/viper"
 )
 
 var createSQLDriverOnce sync.Once
@@ -11,9 +16,19 @@ var sqlDriver sqladapter.SQLDriver
 
 // ResolveSQLDriver resolves the SQLDriver dependency.
 // Only the first call to this function will create a new SQLDriver, after which it will be retrieved from memory.
+// The driver is chosen using the driver in the database config.
 func ResolveSQLDriver() sqladapter.SQLDriver {
 	createSQLDriverOnce.Do(func() {
-		sqlDriver = postgres.Driver{}
+		dbConfig, _ := viper.Get("database").(config.DatabaseConfig)
+
+		switch dbConfig.Driver {
+		case config.DatabaseDriverSQLite:
+			sqlDriver = sqlite.Driver{}
+		case config.DatabaseDriverMySQL:
+			sqlDriver = mysql.Driver{}
+		default:
+			sqlDriver = postgres.Driver{}
+		}
 	})
 	return sqlDriver
 }
@@ -0,0 +1,52 @@
+package dependencies
+
+import (
+	"authserver/config"
+	"authserver/tokencache"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var createTokenCacheOnce sync.Once
+var tokenCache *tokencache.Cache
+
+// ResolveTokenCache resolves the token Cache dependency.
+// Only the first call to this function will create a new Cache, after which it will be retrieved from memory.
+// Returns nil if the cache is disabled in the token cache config.
+func ResolveTokenCache() *tokencache.Cache {
+	createTokenCacheOnce.Do(func() {
+		cfg, _ := viper.Get("token_cache").(config.TokenCacheConfig)
+		if cfg.Size <= 0 {
+			return
+		}
+
+		tokenCache = tokencache.CreateCache(cfg.Size, time.Duration(cfg.TTL)*time.Millisecond)
+		ResolveTokenCacheBackplane().Subscribe(tokenCache.Invalidate)
+	})
+	return tokenCache
+}
+
+var createTokenCacheBackplaneOnce sync.Once
+var tokenCacheBackplane tokencache.Backplane
+
+// ResolveTokenCacheBackplane resolves the token cache Backplane dependency.
+// Only the first call to this function will create a new Backplane, after which it will be retrieved from memory.
+// The backplane is chosen using the backplane in the token cache config, which is validated to only choose postgres with a postgres database.
+func ResolveTokenCacheBackplane() tokencache.Backplane {
+	createTokenCacheBackplaneOnce.Do(func() {
+		cfg, _ := viper.Get("token_cache").(config.TokenCacheConfig)
+		dbConfig, _ := viper.Get("database").(config.DatabaseConfig)
+
+		if cfg.Backplane != config.TokenCacheBackplanePostgres {
+			tokenCacheBackplane = &tokencache.LocalBackplane{}
+			return
+		}
+
+		tokenCacheBackplane = &tokencache.PostgresBackplane{
+			ConnectionString: dbConfig.ConnectionStrings[viper.GetString("db_key")],
+		}
+	})
+	return tokenCacheBackplane
+}
@@ -2,7 +2,9 @@ package dependencies
 
 import (
 	databasepkg "authserver/database"
+	"authserver/database/inmemory"
 	sqladapter "authserver/database/sql_adapter"
+	"authserver/tokencache"
 	"sync"
 )
 
@@ -11,10 +13,26 @@ var transactionFactory databasepkg.TransactionFactory
 
 // ResolveTransactionFactory resolves the TransactionFactory dependency.
 // Only the first call to this function will create a new TransactionFactory, after which it will be retrieved from memory.
+// If the token cache is enabled, transactions publish invalidations for the tokens they revoke.
 func ResolveTransactionFactory() databasepkg.TransactionFactory {
 	createTransactionFactoryOnce.Do(func() {
-		transactionFactory = sqladapter.SQLTransactionFactory{
-			DB: ResolveDatabase().(*sqladapter.SQLDB),
+		switch db := ResolveDatabase().(type) {
+		case *inmemory.DB:
+			transactionFactory = inmemory.TransactionFactory{
+				DB:              db,
+				MetricsRecorder: ResolveMetricsRecorder(),
+			}
+		default:
+			transactionFactory = sqladapter.SQLTransactionFactory{
+				DB: db.(*sqladapter.SQLDB),
+			}
+		}
+
+		if ResolveTokenCache() != nil {
+			transactionFactory = tokencache.InvalidatingTransactionFactory{
+				TransactionFactory: transactionFactory,
+				Backplane:          ResolveTokenCacheBackplane(),
+			}
 		}
 	})
 	return transactionFactory
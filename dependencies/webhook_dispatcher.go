@@ -0,0 +1,21 @@
+package dependencies
+
+import (
+	"authserver/config"
+	"authserver/webhook"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+var createWebhookDispatcherOnce sync.Once
+var webhookDispatcher *webhook.Dispatcher
+
+// ResolveWebhookDispatcher resolves the webhook Dispatcher dependency.
+// Only the first call to this function will create a new Dispatcher, after which it will be retrieved from memory.
+func ResolveWebhookDispatcher() *webhook.Dispatcher {
+	createWebhookDispatcherOnce.Do(func() {
+		webhookDispatcher = webhook.CreateDispatcher(ResolveTransactionFactory(), viper.Get("webhooks").(config.WebhookConfig))
+	})
+	return webhookDispatcher
+}
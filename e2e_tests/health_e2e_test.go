@@ -0,0 +1,38 @@
+package e2e_test
+
+import (
+	"authserver/common"
+	"authserver/health"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthE2ETestSuite struct {
+	E2ETestSuite
+}
+
+func (suite *HealthE2ETestSuite) TearDownSuite() {
+	//close server and db connection
+	suite.Server.Close()
+	suite.DBConnection.CloseConnection()
+}
+
+func (suite *HealthE2ETestSuite) TestGetHealthz_ReturnsPassingProcessCheck() {
+	//act
+	res := suite.SendRequest(http.MethodGet, "/healthz", "", nil)
+
+	//assert
+	report := health.Report{}
+	common.AssertResponseOK(&suite.Suite, res, &report)
+
+	suite.Equal(health.StatusPass, report.Status)
+	suite.Require().Contains(report.Checks, "process")
+	suite.Equal(health.StatusPass, report.Checks["process"].Status)
+	suite.GreaterOrEqual(report.Checks["process"].LatencyMs, 0.0)
+}
+
+func TestHealthE2ETestSuite(t *testing.T) {
+	suite.Run(t, &HealthE2ETestSuite{})
+}
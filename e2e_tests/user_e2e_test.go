@@ -3,7 +3,9 @@ package e2e_test
 import (
 	"authserver/common"
 	"authserver/config"
+	"authserver/dependencies"
 	"authserver/router"
+	"context"
 	"net/http"
 	"testing"
 
@@ -58,6 +60,172 @@ func (suite *UserE2ETestSuite) TestCreateUser_Login_UpdateUserPassword_DeleteUse
 	//delete user
 	res = suite.SendRequest(http.MethodDelete, "/user", tokenRes.AccessToken, nil)
 	common.AssertSuccessResponse(&suite.Suite, res)
+
+	//the user's tokens are revoked, even if they were cached
+	res = suite.SendRequest(http.MethodDelete, "/token", tokenRes.AccessToken, nil)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusUnauthorized, "invalid bearer token")
+}
+
+func (suite *UserE2ETestSuite) createUserAndLogin(username string, password string) string {
+	res := suite.SendRequest(http.MethodPost, "/user", "", router.PostUserBody{
+		Username: username,
+		Password: password,
+	})
+	common.AssertSuccessResponse(&suite.Suite, res)
+
+	return suite.login(username, password)
+}
+
+func (suite *UserE2ETestSuite) login(username string, password string) string {
+	res := suite.SendRequest(http.MethodPost, "/token", "", passwordGrantBody(username, password))
+
+	tokenRes := common.AccessTokenResponse{}
+	common.AssertResponseOK(&suite.Suite, res, &tokenRes)
+	return tokenRes.AccessToken
+}
+
+func passwordGrantBody(username string, password string) router.PostTokenBody {
+	return router.PostTokenBody{
+		GrantType: "password",
+		PostTokenPasswordGrantBody: router.PostTokenPasswordGrantBody{
+			Username: username,
+			Password: password,
+			ClientID: config.GetAppId().String(),
+			Scope:    "all",
+		},
+	}
+}
+
+// promoteToAdmin makes the user with the username an admin, since there is no endpoint to do so.
+func (suite *UserE2ETestSuite) promoteToAdmin(username string) {
+	tx, err := dependencies.ResolveTransactionFactory().CreateTransaction(context.Background())
+	suite.Require().NoError(err)
+
+	user, err := tx.GetUserByUsername(context.Background(), username)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(user)
+
+	user.IsAdmin = true
+	suite.Require().NoError(tx.UpdateUser(context.Background(), user))
+	suite.Require().NoError(tx.CommitTransaction())
+}
+
+func (suite *UserE2ETestSuite) TestGetUser_UpdateUser() {
+	password := "Password123!"
+	token := suite.createUserAndLogin("profile_user", password)
+	otherToken := suite.createUserAndLogin("profile_other", password)
+
+	//get user
+	res := suite.SendRequest(http.MethodGet, "/user", token, nil)
+
+	var getRes struct {
+		Data router.UserResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &getRes)
+	suite.Equal("profile_user", getRes.Data.Username)
+	suite.False(getRes.Data.IsAdmin)
+
+	//update to a username that is taken
+	taken := "profile_other"
+	res = suite.SendRequest(http.MethodPatch, "/user", token, router.PatchUserBody{Username: &taken})
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusConflict, "username is already taken")
+
+	//update to an invalid username
+	empty := ""
+	res = suite.SendRequest(http.MethodPatch, "/user", token, router.PatchUserBody{Username: &empty})
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "username cannot be empty")
+
+	//update username
+	username := "profile_renamed"
+	res = suite.SendRequest(http.MethodPatch, "/user", token, router.PatchUserBody{Username: &username})
+
+	var patchRes struct {
+		Data router.UserResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &patchRes)
+	suite.Equal(getRes.Data.ID, patchRes.Data.ID)
+	suite.Equal(username, patchRes.Data.Username)
+
+	//the update is seen by the user's existing tokens, even if they were cached
+	res = suite.SendRequest(http.MethodGet, "/user", token, nil)
+	common.AssertResponseOK(&suite.Suite, res, &getRes)
+	suite.Equal(username, getRes.Data.Username)
+
+	//the user logs in with their new username
+	token = suite.login(username, password)
+
+	//delete users
+	res = suite.SendRequest(http.MethodDelete, "/user", token, nil)
+	common.AssertSuccessResponse(&suite.Suite, res)
+
+	res = suite.SendRequest(http.MethodDelete, "/user", otherToken, nil)
+	common.AssertSuccessResponse(&suite.Suite, res)
+}
+
+func (suite *UserE2ETestSuite) TestAdminListUsers_DisableUser_ResetPassword_DeleteUser() {
+	password := "Password123!"
+	adminToken := suite.createUserAndLogin("admin_users_admin", password)
+	suite.promoteToAdmin("admin_users_admin")
+	userToken := suite.createUserAndLogin("admin_users_target", password)
+
+	//non admins cannot manage users
+	res := suite.SendRequest(http.MethodGet, "/admin/users", userToken, nil)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusForbidden, "admin access is required")
+
+	//search for the user
+	res = suite.SendRequest(http.MethodGet, "/admin/users?username=ADMIN_USERS_T", adminToken, nil)
+
+	var listRes struct {
+		Data router.AdminUsersResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &listRes)
+	suite.Require().Len(listRes.Data.Users, 1)
+	suite.Equal(1, listRes.Data.Total)
+
+	user := listRes.Data.Users[0]
+	suite.Equal("admin_users_target", user.Username)
+	suite.False(user.IsDisabled)
+
+	//disable the user
+	disabled := true
+	res = suite.SendRequest(http.MethodPatch, "/admin/users/"+user.ID, adminToken, router.PatchAdminUserBody{Disabled: &disabled})
+
+	var userRes struct {
+		Data router.UserResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &userRes)
+	suite.True(userRes.Data.IsDisabled)
+
+	//the user's tokens are revoked, even if they were cached, and they cannot login
+	res = suite.SendRequest(http.MethodGet, "/user", userToken, nil)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusUnauthorized, "invalid bearer token")
+
+	res = suite.SendRequest(http.MethodPost, "/token", "", passwordGrantBody("admin_users_target", password))
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_grant", "disabled")
+
+	//enable the user and reset their password
+	disabled = false
+	res = suite.SendRequest(http.MethodPatch, "/admin/users/"+user.ID, adminToken, router.PatchAdminUserBody{Disabled: &disabled})
+	common.AssertResponseOK(&suite.Suite, res, &userRes)
+	suite.False(userRes.Data.IsDisabled)
+
+	newPassword := "NewPassword123!"
+	res = suite.SendRequest(http.MethodPost, "/admin/users/"+user.ID+"/password", adminToken, router.PostAdminUserPasswordBody{Password: newPassword})
+	common.AssertSuccessResponse(&suite.Suite, res)
+
+	//the user logs in with their new password
+	suite.login("admin_users_target", newPassword)
+
+	//delete the user
+	res = suite.SendRequest(http.MethodDelete, "/admin/users/"+user.ID, adminToken, nil)
+	common.AssertSuccessResponse(&suite.Suite, res)
+
+	res = suite.SendRequest(http.MethodGet, "/admin/users/"+user.ID, adminToken, nil)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusNotFound, "user not found")
+
+	//delete the admin
+	res = suite.SendRequest(http.MethodDelete, "/user", adminToken, nil)
+	common.AssertSuccessResponse(&suite.Suite, res)
 }
 
 func TestUserE2ETestSuite(t *testing.T) {
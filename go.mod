@@ -3,10 +3,13 @@ module authserver
 go 1.14
 
 require (
+	github.com/go-sql-driver/mysql v1.5.0
 	github.com/google/uuid v1.1.1
 	github.com/julienschmidt/httprouter v1.3.0
 	github.com/lib/pq v1.7.0
+	github.com/mattn/go-sqlite3 v1.14.7
 	github.com/mhogar/migrationrunner v0.3.0
+	github.com/prometheus/client_golang v1.7.1
 	github.com/spf13/viper v1.7.0
 	github.com/stretchr/testify v1.6.0
 	golang.org/x/crypto v0.0.0-20200510223506-06a226fb4e37
@@ -0,0 +1,81 @@
+package health
+
+import (
+	"authserver/common"
+	"authserver/database"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/mhogar/migrationrunner"
+)
+
+// DatabaseCheck checks the database can be reached.
+type DatabaseCheck struct {
+	DBConnection database.DBConnection
+}
+
+// Check pings the database. Returns an error if it cannot be reached.
+func (c DatabaseCheck) Check() error {
+	return c.DBConnection.Ping()
+}
+
+// MigrationCheck checks the database has been migrated to the latest migration the binary expects.
+type MigrationCheck struct {
+	MigrationCRUD       migrationrunner.MigrationCRUD
+	MigrationRepository migrationrunner.MigrationRepository
+}
+
+// Check compares the database's latest migration timestamp to the latest timestamp in the migration repository.
+// Returns an error if they do not match.
+func (c MigrationCheck) Check() error {
+	//find the latest timestamp the binary expects
+	expected := ""
+	for _, migration := range c.MigrationRepository.GetMigrations() {
+		if migration.GetTimestamp() > expected {
+			expected = migration.GetTimestamp()
+		}
+	}
+
+	//get the latest timestamp from the database
+	latest, hasLatest, err := c.MigrationCRUD.GetLatestTimestamp()
+	if err != nil {
+		return common.ChainError("error getting latest timestamp", err)
+	}
+
+	if !hasLatest {
+		return errors.New("no migrations have been run")
+	}
+	if latest != expected {
+		return fmt.Errorf("latest migration is %s but expected %s", latest, expected)
+	}
+
+	return nil
+}
+
+// ProcessCheck checks the server process is alive.
+type ProcessCheck struct{}
+
+// Check always returns a nil error, since the process is alive if it is able to run the check.
+func (ProcessCheck) Check() error {
+	return nil
+}
+
+// ShutdownCheck checks the server has not started shutting down.
+type ShutdownCheck struct {
+	shuttingDown int32
+}
+
+// BeginShutdown marks the server as shutting down. All future checks will fail.
+func (c *ShutdownCheck) BeginShutdown() {
+	atomic.StoreInt32(&c.shuttingDown, 1)
+}
+
+// Check returns an error if the server is shutting down.
+func (c *ShutdownCheck) Check() error {
+	if atomic.LoadInt32(&c.shuttingDown) != 0 {
+		return errors.New("server is shutting down")
+	}
+
+	return nil
+}
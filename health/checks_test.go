@@ -0,0 +1,136 @@
+package health_test
+
+import (
+	"authserver/common"
+	databasemocks "authserver/database/mocks"
+	"authserver/health"
+	"errors"
+	"testing"
+
+	"github.com/mhogar/migrationrunner"
+	"github.com/stretchr/testify/suite"
+)
+
+type testMigration string
+
+func (m testMigration) GetTimestamp() string { return string(m) }
+func (testMigration) Up() error              { return nil }
+func (testMigration) Down() error            { return nil }
+
+type testMigrationRepository []migrationrunner.Migration
+
+func (r testMigrationRepository) GetMigrations() []migrationrunner.Migration { return r }
+
+type ChecksTestSuite struct {
+	suite.Suite
+	DBConnectionMock databasemocks.DBConnection
+	CRUDMock         databasemocks.CRUDOperations
+	MigrationCheck   health.MigrationCheck
+}
+
+func (suite *ChecksTestSuite) SetupTest() {
+	suite.DBConnectionMock = databasemocks.DBConnection{}
+	suite.CRUDMock = databasemocks.CRUDOperations{}
+
+	suite.MigrationCheck = health.MigrationCheck{
+		MigrationCRUD: &suite.CRUDMock,
+		MigrationRepository: testMigrationRepository{
+			testMigration("20200101000000"),
+			testMigration("20200202000000"),
+		},
+	}
+}
+
+func (suite *ChecksTestSuite) TestDatabaseCheck_WithErrorPingingDatabase_ReturnsError() {
+	//arrange
+	message := "Ping mock error"
+	suite.DBConnectionMock.On("Ping").Return(errors.New(message))
+
+	//act
+	err := health.DatabaseCheck{DBConnection: &suite.DBConnectionMock}.Check()
+
+	//assert
+	common.AssertError(&suite.Suite, err, message)
+}
+
+func (suite *ChecksTestSuite) TestDatabaseCheck_WithDatabaseReachable_ReturnsNoError() {
+	//arrange
+	suite.DBConnectionMock.On("Ping").Return(nil)
+
+	//act
+	err := health.DatabaseCheck{DBConnection: &suite.DBConnectionMock}.Check()
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *ChecksTestSuite) TestMigrationCheck_WithErrorGettingLatestTimestamp_ReturnsError() {
+	//arrange
+	message := "GetLatestTimestamp mock error"
+	suite.CRUDMock.On("GetLatestTimestamp").Return("", false, errors.New(message))
+
+	//act
+	err := suite.MigrationCheck.Check()
+
+	//assert
+	common.AssertError(&suite.Suite, err, message)
+}
+
+func (suite *ChecksTestSuite) TestMigrationCheck_WithNoMigrationsRun_ReturnsError() {
+	//arrange
+	suite.CRUDMock.On("GetLatestTimestamp").Return("", false, nil)
+
+	//act
+	err := suite.MigrationCheck.Check()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "no migrations")
+}
+
+func (suite *ChecksTestSuite) TestMigrationCheck_WhereLatestTimestampDoesNotMatchExpected_ReturnsError() {
+	//arrange
+	suite.CRUDMock.On("GetLatestTimestamp").Return("20200101000000", true, nil)
+
+	//act
+	err := suite.MigrationCheck.Check()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "20200101000000", "20200202000000")
+}
+
+func (suite *ChecksTestSuite) TestMigrationCheck_WhereLatestTimestampMatchesExpected_ReturnsNoError() {
+	//arrange
+	suite.CRUDMock.On("GetLatestTimestamp").Return("20200202000000", true, nil)
+
+	//act
+	err := suite.MigrationCheck.Check()
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *ChecksTestSuite) TestProcessCheck_ReturnsNoError() {
+	//act
+	err := health.ProcessCheck{}.Check()
+
+	//assert
+	suite.NoError(err)
+}
+
+func (suite *ChecksTestSuite) TestShutdownCheck_BeforeAndAfterBeginShutdown() {
+	//arrange
+	check := &health.ShutdownCheck{}
+
+	//act
+	errBefore := check.Check()
+	check.BeginShutdown()
+	errAfter := check.Check()
+
+	//assert
+	suite.NoError(errBefore)
+	common.AssertError(&suite.Suite, errAfter, "shutting down")
+}
+
+func TestChecksTestSuite(t *testing.T) {
+	suite.Run(t, &ChecksTestSuite{})
+}
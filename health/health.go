@@ -0,0 +1,66 @@
+package health
+
+import (
+	"authserver/logger"
+	"time"
+)
+
+// Check statuses.
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+// Check is an interface for checking the health of a single dependency.
+type Check interface {
+	// Check runs the check. Returns an error if the dependency is not healthy.
+	Check() error
+}
+
+// CheckResult is the result of running a single check.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+// Report is the combined result of running all of a checker's checks.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker runs a set of named checks.
+type Checker struct {
+	// Checks maps the name of each check to the check to run.
+	Checks map[string]Check
+}
+
+// Run runs all of the checker's checks and reports their results.
+// The report's status is only StatusPass if every check passed. The errors of failed checks are logged rather than reported.
+func (c Checker) Run() Report {
+	report := Report{
+		Status: StatusPass,
+		Checks: map[string]CheckResult{},
+	}
+
+	for name, check := range c.Checks {
+		start := time.Now()
+		err := check.Check()
+
+		result := CheckResult{
+			Status:    StatusPass,
+			LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+		}
+
+		//the report is public, so the check's error is only logged
+		if err != nil {
+			logger.Default().With("check", name).With("error", err.Error()).Warn("health check failed")
+			result.Status = StatusFail
+			report.Status = StatusFail
+		}
+
+		report.Checks[name] = result
+	}
+
+	return report
+}
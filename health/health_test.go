@@ -0,0 +1,77 @@
+package health_test
+
+import (
+	"authserver/health"
+	"authserver/health/mocks"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckerTestSuite struct {
+	suite.Suite
+	CheckMock1 mocks.Check
+	CheckMock2 mocks.Check
+	Checker    health.Checker
+}
+
+func (suite *CheckerTestSuite) SetupTest() {
+	suite.CheckMock1 = mocks.Check{}
+	suite.CheckMock2 = mocks.Check{}
+
+	suite.Checker = health.Checker{
+		Checks: map[string]health.Check{
+			"check1": &suite.CheckMock1,
+			"check2": &suite.CheckMock2,
+		},
+	}
+}
+
+func (suite *CheckerTestSuite) TestRun_WithNoChecks_ReturnsPass() {
+	//act
+	report := health.Checker{}.Run()
+
+	//assert
+	suite.Equal(health.StatusPass, report.Status)
+	suite.Empty(report.Checks)
+}
+
+func (suite *CheckerTestSuite) TestRun_WhereAllChecksPass_ReturnsPass() {
+	//arrange
+	suite.CheckMock1.On("Check").Return(nil)
+	suite.CheckMock2.On("Check").Return(nil)
+
+	//act
+	report := suite.Checker.Run()
+
+	//assert
+	suite.Equal(health.StatusPass, report.Status)
+	suite.Equal(health.StatusPass, report.Checks["check1"].Status)
+	suite.Equal(health.StatusPass, report.Checks["check2"].Status)
+}
+
+func (suite *CheckerTestSuite) TestRun_WhereACheckFails_ReturnsFailWithoutCheckError() {
+	//arrange
+	message := "Check mock error"
+
+	suite.CheckMock1.On("Check").Return(nil)
+	suite.CheckMock2.On("Check").Return(errors.New(message))
+
+	//act
+	report := suite.Checker.Run()
+
+	//assert
+	suite.Equal(health.StatusFail, report.Status)
+	suite.Equal(health.StatusPass, report.Checks["check1"].Status)
+	suite.Equal(health.StatusFail, report.Checks["check2"].Status)
+
+	data, err := json.Marshal(report)
+	suite.Require().NoError(err)
+	suite.NotContains(string(data), message)
+}
+
+func TestCheckerTestSuite(t *testing.T) {
+	suite.Run(t, &CheckerTestSuite{})
+}
@@ -0,0 +1,35 @@
+package logger
+
+import (
+	"context"
+	"os"
+)
+
+type contextKey struct{}
+
+var defaultLogger Logger = CreateStandardLogger(os.Stderr, FormatText, LevelInfo)
+
+// Default returns the logger used when no logger is available, such as outside of a request.
+func Default() Logger {
+	return defaultLogger
+}
+
+// SetDefault sets the default logger. Should be called at the start of the application.
+func SetDefault(l Logger) {
+	defaultLogger = l
+}
+
+// NewContext returns a child of the context that carries the logger.
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by the context, or the default logger if it has none.
+func FromContext(ctx context.Context) Logger {
+	l, ok := ctx.Value(contextKey{}).(Logger)
+	if !ok {
+		return Default()
+	}
+
+	return l
+}
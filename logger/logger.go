@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"errors"
+	"strings"
+)
+
+// Level is the severity of a log entry.
+type Level int
+
+// Log levels, in increasing order of severity.
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Log output formats.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Logger is an interface for writing structured, leveled log entries.
+type Logger interface {
+	// Debug logs the message at the debug level.
+	Debug(message string)
+
+	// Info logs the message at the info level.
+	Info(message string)
+
+	// Warn logs the message at the warn level.
+	Warn(message string)
+
+	// Error logs the error at the error level.
+	Error(err error)
+
+	// With returns a child logger that attaches the key and value to every entry it logs.
+	With(key string, value interface{}) Logger
+}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel parses the level from its name. Returns an error if the name is not a valid level.
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	default:
+		return LevelInfo, errors.New("invalid log level " + name)
+	}
+}
@@ -0,0 +1,65 @@
+package logger_test
+
+import (
+	"authserver/logger"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoggerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LoggerTestSuite) TestParseLevel_ValidName_ReturnsLevel() {
+	//arrange
+	names := map[string]logger.Level{
+		"debug": logger.LevelDebug,
+		"INFO":  logger.LevelInfo,
+		"":      logger.LevelInfo,
+		"warn":  logger.LevelWarn,
+		"error": logger.LevelError,
+	}
+
+	for name, expected := range names {
+		//act
+		level, err := logger.ParseLevel(name)
+
+		//assert
+		suite.NoError(err)
+		suite.Equal(expected, level)
+	}
+}
+
+func (suite *LoggerTestSuite) TestParseLevel_InvalidName_ReturnsError() {
+	//act
+	_, err := logger.ParseLevel("verbose")
+
+	//assert
+	suite.Error(err)
+}
+
+func (suite *LoggerTestSuite) TestFromContext_WithLogger_ReturnsLogger() {
+	//arrange
+	l := logger.CreateStandardLogger(nil, logger.FormatJSON, logger.LevelInfo)
+	ctx := logger.NewContext(context.Background(), l)
+
+	//act
+	result := logger.FromContext(ctx)
+
+	//assert
+	suite.Same(l, result)
+}
+
+func (suite *LoggerTestSuite) TestFromContext_WithoutLogger_ReturnsDefault() {
+	//act
+	result := logger.FromContext(context.Background())
+
+	//assert
+	suite.Same(logger.Default(), result)
+}
+
+func TestLoggerTestSuite(t *testing.T) {
+	suite.Run(t, &LoggerTestSuite{})
+}
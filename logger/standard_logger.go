@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedactedValue is logged in place of the value of any sensitive field.
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeys are substrings of field keys whose values must never be logged.
+var sensitiveKeys = []string{"password", "token", "secret", "authorization"}
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// StandardLogger is an implementation of the Logger interface that writes entries to an io.Writer as json or text.
+type StandardLogger struct {
+	mutex  *sync.Mutex
+	writer io.Writer
+	format string
+	level  Level
+	fields []field
+}
+
+// CreateStandardLogger creates a new StandardLogger that writes entries at or above the level to the writer in the given format.
+// Unknown formats fall back to text.
+func CreateStandardLogger(writer io.Writer, format string, level Level) *StandardLogger {
+	return &StandardLogger{
+		mutex:  &sync.Mutex{},
+		writer: writer,
+		format: format,
+		level:  level,
+	}
+}
+
+// Debug logs the message at the debug level.
+func (l *StandardLogger) Debug(message string) {
+	l.log(LevelDebug, message)
+}
+
+// Info logs the message at the info level.
+func (l *StandardLogger) Info(message string) {
+	l.log(LevelInfo, message)
+}
+
+// Warn logs the message at the warn level.
+func (l *StandardLogger) Warn(message string) {
+	l.log(LevelWarn, message)
+}
+
+// Error logs the error at the error level.
+func (l *StandardLogger) Error(err error) {
+	l.log(LevelError, err.Error())
+}
+
+// With returns a child logger that attaches the key and value to every entry it logs.
+// The values of sensitive keys such as passwords and tokens are redacted.
+func (l *StandardLogger) With(key string, value interface{}) Logger {
+	if isSensitiveKey(key) {
+		value = RedactedValue
+	}
+
+	//copy the fields so the parent logger is not affected
+	fields := make([]field, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+
+	child := *l
+	child.fields = append(fields, field{key: key, value: value})
+
+	return &child
+}
+
+func (l *StandardLogger) log(level Level, message string) {
+	if level < l.level {
+		return
+	}
+
+	var buf bytes.Buffer
+	if l.format == FormatJSON {
+		l.writeJSON(&buf, level, message)
+	} else {
+		l.writeText(&buf, level, message)
+	}
+	buf.WriteByte('\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.writer.Write(buf.Bytes())
+}
+
+func (l *StandardLogger) writeJSON(buf *bytes.Buffer, level Level, message string) {
+	buf.WriteString(`{"time":`)
+	writeJSONValue(buf, time.Now().UTC().Format(time.RFC3339Nano))
+	buf.WriteString(`,"level":`)
+	writeJSONValue(buf, level.String())
+	buf.WriteString(`,"message":`)
+	writeJSONValue(buf, message)
+
+	for _, f := range l.fields {
+		buf.WriteByte(',')
+		writeJSONValue(buf, f.key)
+		buf.WriteByte(':')
+		writeJSONValue(buf, f.value)
+	}
+
+	buf.WriteByte('}')
+}
+
+func (l *StandardLogger) writeText(buf *bytes.Buffer, level Level, message string) {
+	buf.WriteString(time.Now().UTC().Format(time.RFC3339Nano))
+	buf.WriteByte(' ')
+	buf.WriteString(strings.ToUpper(level.String()))
+	buf.WriteByte(' ')
+	buf.WriteString(message)
+
+	for _, f := range l.fields {
+		buf.WriteByte(' ')
+		buf.WriteString(f.key)
+		buf.WriteByte('=')
+
+		value := fmt.Sprint(f.value)
+		if strings.ContainsAny(value, " \t\r\n\"=") {
+			value = strconv.Quote(value)
+		}
+		buf.WriteString(value)
+	}
+}
+
+func writeJSONValue(buf *bytes.Buffer, value interface{}) {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(value))
+	}
+
+	buf.Write(data)
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitiveKey := range sensitiveKeys {
+		if strings.Contains(key, sensitiveKey) {
+			return true
+		}
+	}
+
+	return false
+}
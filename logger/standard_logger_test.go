@@ -0,0 +1,123 @@
+package logger_test
+
+import (
+	"authserver/logger"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StandardLoggerTestSuite struct {
+	suite.Suite
+	Buffer *bytes.Buffer
+}
+
+func (suite *StandardLoggerTestSuite) SetupTest() {
+	suite.Buffer = &bytes.Buffer{}
+}
+
+func (suite *StandardLoggerTestSuite) ParseJSONEntries() []map[string]interface{} {
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(suite.Buffer.String()), "\n") {
+		var entry map[string]interface{}
+		suite.Require().NoError(json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func (suite *StandardLoggerTestSuite) TestLog_JSONFormat_WritesEntryWithFields() {
+	//arrange
+	l := logger.CreateStandardLogger(suite.Buffer, logger.FormatJSON, logger.LevelDebug).With("request_id", "abc").With("count", 5)
+
+	//act
+	l.Info("hello world")
+
+	//assert
+	entries := suite.ParseJSONEntries()
+	suite.Require().Len(entries, 1)
+	suite.Equal("info", entries[0]["level"])
+	suite.Equal("hello world", entries[0]["message"])
+	suite.Equal("abc", entries[0]["request_id"])
+	suite.Equal(float64(5), entries[0]["count"])
+	suite.NotEmpty(entries[0]["time"])
+}
+
+func (suite *StandardLoggerTestSuite) TestLog_JSONFormat_EscapesMultilineErrors() {
+	//arrange
+	l := logger.CreateStandardLogger(suite.Buffer, logger.FormatJSON, logger.LevelDebug)
+
+	//act
+	l.Error(errors.New("outer error\n\tinner error"))
+
+	//assert
+	entries := suite.ParseJSONEntries()
+	suite.Require().Len(entries, 1)
+	suite.Equal("error", entries[0]["level"])
+	suite.Equal("outer error\n\tinner error", entries[0]["message"])
+}
+
+func (suite *StandardLoggerTestSuite) TestLog_TextFormat_WritesEntryWithFields() {
+	//arrange
+	l := logger.CreateStandardLogger(suite.Buffer, logger.FormatText, logger.LevelDebug).With("request_id", "abc").With("detail", "two words")
+
+	//act
+	l.Warn("hello world")
+
+	//assert
+	suite.Contains(suite.Buffer.String(), ` WARN hello world request_id=abc detail="two words"`)
+}
+
+func (suite *StandardLoggerTestSuite) TestLog_BelowLevel_WritesNothing() {
+	//arrange
+	l := logger.CreateStandardLogger(suite.Buffer, logger.FormatJSON, logger.LevelWarn)
+
+	//act
+	l.Debug("debug")
+	l.Info("info")
+
+	//assert
+	suite.Empty(suite.Buffer.String())
+}
+
+func (suite *StandardLoggerTestSuite) TestWith_SensitiveKey_RedactsValue() {
+	//arrange
+	l := logger.CreateStandardLogger(suite.Buffer, logger.FormatJSON, logger.LevelDebug).
+		With("password", "secret-password").
+		With("access_token", "secret-token").
+		With("Authorization", "Bearer secret-token")
+
+	//act
+	l.Info("message")
+
+	//assert
+	suite.NotContains(suite.Buffer.String(), "secret-")
+	entries := suite.ParseJSONEntries()
+	suite.Require().Len(entries, 1)
+	suite.Equal(logger.RedactedValue, entries[0]["password"])
+	suite.Equal(logger.RedactedValue, entries[0]["access_token"])
+	suite.Equal(logger.RedactedValue, entries[0]["Authorization"])
+}
+
+func (suite *StandardLoggerTestSuite) TestWith_DoesNotModifyParent() {
+	//arrange
+	parent := logger.CreateStandardLogger(suite.Buffer, logger.FormatJSON, logger.LevelDebug).With("a", 1)
+	parent.With("b", 2)
+
+	//act
+	parent.Info("message")
+
+	//assert
+	entries := suite.ParseJSONEntries()
+	suite.Require().Len(entries, 1)
+	suite.NotContains(entries[0], "b")
+}
+
+func TestStandardLoggerTestSuite(t *testing.T) {
+	suite.Run(t, &StandardLoggerTestSuite{})
+}
@@ -5,6 +5,9 @@ import (
 	"authserver/dependencies"
 	"authserver/server"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"authserver/config"
 )
@@ -15,6 +18,27 @@ func main() {
 		log.Fatal(common.ChainError("error initing config", err))
 	}
 
+	//resolve the logger first so it becomes the default logger
+	dependencies.ResolveLogger()
+
+	//listen for shutdown signals
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
 	serverRunner := server.CreateHTTPServerRunner(dependencies.ResolveDatabase(), dependencies.ResolveRouterFactory())
-	log.Fatal(serverRunner.Run())
+	serverRunner.ShutdownCheck = dependencies.ResolveShutdownCheck()
+	serverRunner.Workers = []server.Worker{dependencies.ResolveWebhookDispatcher()}
+
+	//some token cache backplanes listen for invalidations in the background
+	if dependencies.ResolveTokenCache() != nil {
+		if worker, ok := dependencies.ResolveTokenCacheBackplane().(server.Worker); ok {
+			serverRunner.Workers = append(serverRunner.Workers, worker)
+		}
+	}
+
+	err = serverRunner.RunUntilSignaled(signals)
+	if err != nil {
+		dependencies.ResolveLogger().Error(err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// PrometheusRecorder is an implementation of Recorder that exposes its metrics in the prometheus format.
+type PrometheusRecorder struct {
+	registry *prometheus.Registry
+
+	requests             *prometheus.CounterVec
+	requestDuration      *prometheus.HistogramVec
+	tokenGrants          *prometheus.CounterVec
+	loginFailures        *prometheus.CounterVec
+	transactions         *prometheus.CounterVec
+	queryDuration        *prometheus.HistogramVec
+	passwordHashDuration *prometheus.HistogramVec
+	tokenCacheRequests   *prometheus.CounterVec
+}
+
+// CreatePrometheusRecorder creates a new PrometheusRecorder with all of its metrics registered to its own registry.
+func CreatePrometheusRecorder() *PrometheusRecorder {
+	r := &PrometheusRecorder{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "authserver_http_requests_total",
+			Help: "Total number of http requests by method, route, and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "authserver_http_request_duration_seconds",
+			Help:    "Duration of http requests by method, route, and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		tokenGrants: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "authserver_token_grants_total",
+			Help: "Total number of token requests by grant type and outcome.",
+		}, []string{"grant_type", "outcome"}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "authserver_login_failures_total",
+			Help: "Total number of failed logins by reason.",
+		}, []string{"reason"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "authserver_db_transactions_total",
+			Help: "Total number of database transactions by outcome.",
+		}, []string{"outcome"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "authserver_db_query_duration_seconds",
+			Help:    "Duration of database queries by script name.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"script"}),
+		passwordHashDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "authserver_password_hash_duration_seconds",
+			Help:    "Duration of password hash operations.",
+			Buckets: []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+		}, []string{"operation"}),
+		tokenCacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "authserver_token_cache_requests_total",
+			Help: "Total number of access token cache lookups by result.",
+		}, []string{"result"}),
+	}
+
+	r.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		r.requests,
+		r.requestDuration,
+		r.tokenGrants,
+		r.loginFailures,
+		r.transactions,
+		r.queryDuration,
+		r.passwordHashDuration,
+		r.tokenCacheRequests,
+	)
+
+	return r
+}
+
+// Handler returns an http handler that serves the recorder's metrics.
+func (r *PrometheusRecorder) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records a request to the route with the given method, the status of its response, and how long it took.
+func (r *PrometheusRecorder) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	statusStr := strconv.Itoa(status)
+
+	r.requests.WithLabelValues(method, route, statusStr).Inc()
+	r.requestDuration.WithLabelValues(method, route, statusStr).Observe(duration.Seconds())
+}
+
+// ObserveTokenGrant records an attempt to issue a token with the given grant type, and its outcome.
+func (r *PrometheusRecorder) ObserveTokenGrant(grantType string, outcome string) {
+	r.tokenGrants.WithLabelValues(grantType, outcome).Inc()
+}
+
+// ObserveLoginFailure records a failed login and the reason it failed.
+func (r *PrometheusRecorder) ObserveLoginFailure(reason string) {
+	r.loginFailures.WithLabelValues(reason).Inc()
+}
+
+// ObserveTransaction records the outcome of a database transaction.
+func (r *PrometheusRecorder) ObserveTransaction(outcome string) {
+	r.transactions.WithLabelValues(outcome).Inc()
+}
+
+// ObserveQuery records how long the database script with the given name took to execute.
+func (r *PrometheusRecorder) ObserveQuery(script string, duration time.Duration) {
+	r.queryDuration.WithLabelValues(script).Observe(duration.Seconds())
+}
+
+// ObservePasswordHash records how long the password hash operation took.
+func (r *PrometheusRecorder) ObservePasswordHash(operation string, duration time.Duration) {
+	r.passwordHashDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// ObserveTokenCache records whether an access token was found in the token cache.
+func (r *PrometheusRecorder) ObserveTokenCache(result string) {
+	r.tokenCacheRequests.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,74 @@
+package metrics_test
+
+import (
+	"authserver/common"
+	"authserver/metrics"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PrometheusRecorderTestSuite struct {
+	suite.Suite
+	Recorder *metrics.PrometheusRecorder
+}
+
+func (suite *PrometheusRecorderTestSuite) SetupTest() {
+	suite.Recorder = metrics.CreatePrometheusRecorder()
+}
+
+func (suite *PrometheusRecorderTestSuite) GetMetrics() string {
+	w := httptest.NewRecorder()
+	suite.Recorder.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, err := ioutil.ReadAll(w.Result().Body)
+	suite.Require().NoError(err)
+
+	return string(body)
+}
+
+func (suite *PrometheusRecorderTestSuite) TestHandler_ServesRecordedMetrics() {
+	//arrange
+	suite.Recorder.ObserveRequest(http.MethodPost, "/token", http.StatusOK, time.Millisecond)
+	suite.Recorder.ObserveTokenGrant("password", "success")
+	suite.Recorder.ObserveLoginFailure("invalid_password")
+	suite.Recorder.ObserveTransaction(metrics.TransactionCommit)
+	suite.Recorder.ObserveQuery("GetUserById", time.Millisecond)
+	suite.Recorder.ObservePasswordHash(metrics.PasswordHashOperationHash, time.Millisecond)
+	suite.Recorder.ObserveTokenCache(metrics.TokenCacheHit)
+
+	//act
+	body := suite.GetMetrics()
+
+	//assert
+	common.AssertContainsSubstrings(&suite.Suite, body,
+		`authserver_http_requests_total{method="POST",route="/token",status="200"} 1`,
+		`authserver_http_request_duration_seconds_count{method="POST",route="/token",status="200"} 1`,
+		`authserver_token_grants_total{grant_type="password",outcome="success"} 1`,
+		`authserver_login_failures_total{reason="invalid_password"} 1`,
+		`authserver_db_transactions_total{outcome="commit"} 1`,
+		`authserver_db_query_duration_seconds_count{script="GetUserById"} 1`,
+		`authserver_password_hash_duration_seconds_count{operation="hash"} 1`,
+		`authserver_token_cache_requests_total{result="hit"} 1`,
+	)
+}
+
+func (suite *PrometheusRecorderTestSuite) TestCreatePrometheusRecorder_UsesSeparateRegistries() {
+	//arrange
+	other := metrics.CreatePrometheusRecorder()
+	other.ObserveLoginFailure("unknown_user")
+
+	//act
+	body := suite.GetMetrics()
+
+	//assert
+	suite.NotContains(body, "unknown_user")
+}
+
+func TestPrometheusRecorderTestSuite(t *testing.T) {
+	suite.Run(t, &PrometheusRecorderTestSuite{})
+}
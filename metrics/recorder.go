@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"time"
+)
+
+// Transaction outcomes.
+const (
+	TransactionCommit      = "commit"
+	TransactionCommitError = "commit_error"
+	TransactionRollback    = "rollback"
+	TransactionRetry       = "retry"
+)
+
+// Password hash operations.
+const (
+	PasswordHashOperationHash    = "hash"
+	PasswordHashOperationCompare = "compare"
+)
+
+// Token cache results.
+const (
+	TokenCacheHit  = "hit"
+	TokenCacheMiss = "miss"
+)
+
+// Recorder is an interface for recording application metrics.
+type Recorder interface {
+	// ObserveRequest records a request to the route with the given method, the status of its response, and how long it took.
+	ObserveRequest(method string, route string, status int, duration time.Duration)
+
+	// ObserveTokenGrant records an attempt to issue a token with the given grant type, and its outcome.
+	ObserveTokenGrant(grantType string, outcome string)
+
+	// ObserveLoginFailure records a failed login and the reason it failed.
+	ObserveLoginFailure(reason string)
+
+	// ObserveTransaction records the outcome of a database transaction.
+	ObserveTransaction(outcome string)
+
+	// ObserveQuery records how long the database script with the given name took to execute.
+	ObserveQuery(script string, duration time.Duration)
+
+	// ObservePasswordHash records how long the password hash operation took.
+	ObservePasswordHash(operation string, duration time.Duration)
+
+	// ObserveTokenCache records whether an access token was found in the token cache.
+	ObserveTokenCache(result string)
+}
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 // AccessToken ValidateError statuses.
 const (
@@ -25,17 +29,20 @@ type AccessToken struct {
 // AccessTokenCRUD is an interface for performing CRUD operations on an access token.
 type AccessTokenCRUD interface {
 	// SaveAccessToken saves the access token and returns any errors.
-	SaveAccessToken(token *AccessToken) error
+	SaveAccessToken(ctx context.Context, token *AccessToken) error
 
 	// GetAccessTokenByID fetches the access token associated with the id.
 	// If no tokens are found, returns nil token. Also returns any errors.
-	GetAccessTokenByID(ID uuid.UUID) (*AccessToken, error)
+	GetAccessTokenByID(ctx context.Context, ID uuid.UUID) (*AccessToken, error)
 
 	// DeleteAccessToken deletes the token and returns any errors.
-	DeleteAccessToken(token *AccessToken) error
+	DeleteAccessToken(ctx context.Context, token *AccessToken) error
 
 	// DeleteAllOtherUserTokens deletes all of the user's tokens expect for the provided one and returns any errors.
-	DeleteAllOtherUserTokens(token *AccessToken) error
+	DeleteAllOtherUserTokens(ctx context.Context, token *AccessToken) error
+
+	// DeleteAllUserTokens deletes all of the user's tokens and returns any errors.
+	DeleteAllUserTokens(ctx context.Context, user *User) error
 }
 
 // CreateNewAccessToken creates a access token model with a new id and the provided fields.
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent ValidateError statuses.
+const (
+	ValidateAuditEventValid            = 0x0
+	ValidateAuditEventNilID            = 0x1
+	ValidateAuditEventZeroTimestamp    = 0x2
+	ValidateAuditEventEmptyAction      = 0x4
+	ValidateAuditEventActionTooLong    = 0x8
+	ValidateAuditEventEmptyOutcome     = 0x10
+	ValidateAuditEventOutcomeTooLong   = 0x20
+	ValidateAuditEventIPAddressTooLong = 0x40
+	ValidateAuditEventUserAgentTooLong = 0x80
+	ValidateAuditEventDetailsTooLong   = 0x100
+)
+
+// Max lengths of the audit event's fields.
+const (
+	AuditEventActionMaxLength    = 30
+	AuditEventOutcomeMaxLength   = 15
+	AuditEventIPAddressMaxLength = 45
+	AuditEventUserAgentMaxLength = 255
+	AuditEventDetailsMaxLength   = 255
+)
+
+// AuditEventFilter pagination limits.
+const (
+	AuditEventFilterDefaultLimit = 50
+	AuditEventFilterMaxLimit     = 500
+)
+
+// AuditEvent actions.
+const (
+	AuditActionLogin          = "login"
+	AuditActionTokenIssue     = "token_issue"
+	AuditActionTokenRevoke    = "token_revoke"
+	AuditActionPasswordChange = "password_change"
+	AuditActionPasswordReset  = "password_reset"
+	AuditActionUserCreate     = "user_create"
+	AuditActionUserDelete     = "user_delete"
+	AuditActionUserUpdate     = "user_update"
+	AuditActionUserDisable    = "user_disable"
+	AuditActionUserEnable     = "user_enable"
+	AuditActionAuditLogQuery  = "audit_log_query"
+	AuditActionAdminAccess    = "admin_access"
+)
+
+// AuditEvent outcomes.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditEvent represents the audit event model.
+// The actor, target and client ids are nil if they do not apply to the event.
+type AuditEvent struct {
+	ID        uuid.UUID
+	Timestamp time.Time
+	Action    string
+	Outcome   string
+	ActorID   uuid.UUID
+	TargetID  uuid.UUID
+	ClientID  uuid.UUID
+	IPAddress string
+	UserAgent string
+	Details   string
+}
+
+// AuditEventFilter is used to filter and paginate audit events. Zero value fields are not filtered on.
+type AuditEventFilter struct {
+	Action   string
+	Outcome  string
+	ActorID  uuid.UUID
+	TargetID uuid.UUID
+	ClientID uuid.UUID
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+	Offset   int
+}
+
+// AuditEventCRUD is an interface for performing CRUD operations on an audit event.
+type AuditEventCRUD interface {
+	// SaveAuditEvent saves the audit event and returns any errors.
+	SaveAuditEvent(ctx context.Context, event *AuditEvent) error
+
+	// GetAuditEvents fetches the audit events that match the filter, newest first. Also returns any errors.
+	GetAuditEvents(ctx context.Context, filter AuditEventFilter) ([]*AuditEvent, error)
+}
+
+// CreateNewAuditEvent creates an audit event model with a new id, the current time and the provided fields.
+func CreateNewAuditEvent(action string, outcome string) *AuditEvent {
+	return &AuditEvent{
+		ID:        uuid.New(),
+		Timestamp: time.Now().UTC(),
+		Action:    action,
+		Outcome:   outcome,
+	}
+}
+
+// Validate validates the audit event model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (e *AuditEvent) Validate() int {
+	code := ValidateAuditEventValid
+
+	if e.ID == uuid.Nil {
+		code |= ValidateAuditEventNilID
+	}
+
+	if e.Timestamp.IsZero() {
+		code |= ValidateAuditEventZeroTimestamp
+	}
+
+	if e.Action == "" {
+		code |= ValidateAuditEventEmptyAction
+	} else if len(e.Action) > AuditEventActionMaxLength {
+		code |= ValidateAuditEventActionTooLong
+	}
+
+	if e.Outcome == "" {
+		code |= ValidateAuditEventEmptyOutcome
+	} else if len(e.Outcome) > AuditEventOutcomeMaxLength {
+		code |= ValidateAuditEventOutcomeTooLong
+	}
+
+	if len(e.IPAddress) > AuditEventIPAddressMaxLength {
+		code |= ValidateAuditEventIPAddressTooLong
+	}
+
+	if len(e.UserAgent) > AuditEventUserAgentMaxLength {
+		code |= ValidateAuditEventUserAgentTooLong
+	}
+
+	if len(e.Details) > AuditEventDetailsMaxLength {
+		code |= ValidateAuditEventDetailsTooLong
+	}
+
+	return code
+}
+
+// Normalize clamps the filter's limit and offset to valid values.
+func (f *AuditEventFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = AuditEventFilterDefaultLimit
+	} else if f.Limit > AuditEventFilterMaxLimit {
+		f.Limit = AuditEventFilterMaxLimit
+	}
+
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
@@ -0,0 +1,139 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"authserver/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditEventTestSuite struct {
+	suite.Suite
+	AuditEvent *models.AuditEvent
+}
+
+func (suite *AuditEventTestSuite) SetupTest() {
+	suite.AuditEvent = models.CreateNewAuditEvent(models.AuditActionLogin, models.AuditOutcomeSuccess)
+}
+
+func (suite *AuditEventTestSuite) TestCreateNewAuditEvent_CreatesAuditEventWithSuppliedFields() {
+	//arrange
+	action := models.AuditActionUserCreate
+	outcome := models.AuditOutcomeFailure
+
+	//act
+	event := models.CreateNewAuditEvent(action, outcome)
+
+	//assert
+	suite.Require().NotNil(event)
+	suite.NotEqual(event.ID, uuid.Nil)
+	suite.WithinDuration(time.Now(), event.Timestamp, time.Second)
+	suite.Equal(action, event.Action)
+	suite.Equal(outcome, event.Outcome)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithValidAuditEvent_ReturnsValid() {
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventValid, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithNilID_ReturnsAuditEventNilID() {
+	//arrange
+	suite.AuditEvent.ID = uuid.Nil
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventNilID, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithZeroTimestamp_ReturnsAuditEventZeroTimestamp() {
+	//arrange
+	suite.AuditEvent.Timestamp = time.Time{}
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventZeroTimestamp, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithEmptyActionAndOutcome_ReturnsAuditEventEmptyActionAndOutcome() {
+	//arrange
+	suite.AuditEvent.Action = ""
+	suite.AuditEvent.Outcome = ""
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventEmptyAction|models.ValidateAuditEventEmptyOutcome, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithFieldsLongerThanMax_ReturnsTooLongErrors() {
+	//arrange
+	suite.AuditEvent.Action = strings.Repeat("a", models.AuditEventActionMaxLength+1)
+	suite.AuditEvent.Outcome = strings.Repeat("a", models.AuditEventOutcomeMaxLength+1)
+	suite.AuditEvent.IPAddress = strings.Repeat("a", models.AuditEventIPAddressMaxLength+1)
+	suite.AuditEvent.UserAgent = strings.Repeat("a", models.AuditEventUserAgentMaxLength+1)
+	suite.AuditEvent.Details = strings.Repeat("a", models.AuditEventDetailsMaxLength+1)
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventActionTooLong|models.ValidateAuditEventOutcomeTooLong|
+		models.ValidateAuditEventIPAddressTooLong|models.ValidateAuditEventUserAgentTooLong|
+		models.ValidateAuditEventDetailsTooLong, verr)
+}
+
+func (suite *AuditEventTestSuite) TestValidate_WithFieldsOfMaxLength_ReturnsValid() {
+	//arrange
+	suite.AuditEvent.IPAddress = strings.Repeat("a", models.AuditEventIPAddressMaxLength)
+	suite.AuditEvent.UserAgent = strings.Repeat("a", models.AuditEventUserAgentMaxLength)
+	suite.AuditEvent.Details = strings.Repeat("a", models.AuditEventDetailsMaxLength)
+
+	//act
+	verr := suite.AuditEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateAuditEventValid, verr)
+}
+
+func (suite *AuditEventTestSuite) TestNormalize_ClampsLimitAndOffset() {
+	var tests = []struct {
+		limit          int
+		offset         int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{0, 0, models.AuditEventFilterDefaultLimit, 0},
+		{-1, -1, models.AuditEventFilterDefaultLimit, 0},
+		{models.AuditEventFilterMaxLimit + 1, 10, models.AuditEventFilterMaxLimit, 10},
+		{10, 20, 10, 20},
+	}
+
+	for _, test := range tests {
+		//arrange
+		filter := models.AuditEventFilter{Limit: test.limit, Offset: test.offset}
+
+		//act
+		filter.Normalize()
+
+		//assert
+		suite.Equal(test.expectedLimit, filter.Limit)
+		suite.Equal(test.expectedOffset, filter.Offset)
+	}
+}
+
+func TestAuditEventTestSuite(t *testing.T) {
+	suite.Run(t, &AuditEventTestSuite{})
+}
@@ -1,28 +1,38 @@
 package models
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 )
 
 // Client ValidateError statuses.
 const (
-	ValidateClientValid = 0x0
-	ValidateClientNilID = 0x1
+	ValidateClientValid                         = 0x0
+	ValidateClientNilID                         = 0x1
+	ValidateClientTLSClientAuthSubjectDNTooLong = 0x2
 )
 
+// ClientTLSClientAuthSubjectDNMaxLength is the max length a client's tls client auth subject dn can be.
+const ClientTLSClientAuthSubjectDNMaxLength = 255
+
 // Client represents the client model.
 type Client struct {
 	ID uuid.UUID
+
+	// TLSClientAuthSubjectDN is the subject distinguished name the client's certificate must have when using tls_client_auth (RFC 8705).
+	// If empty, the client is a public client and does not need to authenticate.
+	TLSClientAuthSubjectDN string
 }
 
 // ClientCRUD is an interface for performing CRUD operations on a client.
 type ClientCRUD interface {
 	// SaveClient saves the client and returns any errors.
-	SaveClient(client *Client) error
+	SaveClient(ctx context.Context, client *Client) error
 
 	// GetClientByID fetches the client associated with the id.
 	// If no clients are found, returns nil client. Also returns any errors.
-	GetClientByID(ID uuid.UUID) (*Client, error)
+	GetClientByID(ctx context.Context, ID uuid.UUID) (*Client, error)
 }
 
 // CreateNewClient creates a client model with a new id and the provided fields.
@@ -41,5 +51,9 @@ func (c *Client) Validate() int {
 		code |= ValidateClientNilID
 	}
 
+	if len(c.TLSClientAuthSubjectDN) > ClientTLSClientAuthSubjectDNMaxLength {
+		code |= ValidateClientTLSClientAuthSubjectDNTooLong
+	}
+
 	return code
 }
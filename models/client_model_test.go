@@ -1,6 +1,7 @@
 package models_test
 
 import (
+	"strings"
 	"testing"
 
 	"authserver/models"
@@ -46,6 +47,17 @@ func (suite *ClientTestSuite) TestValidate_WithNilID_ReturnsClientNilID() {
 	suite.Equal(models.ValidateClientNilID, verr)
 }
 
+func (suite *ClientTestSuite) TestValidate_WithTLSClientAuthSubjectDNLongerThanMax_ReturnsClientTLSClientAuthSubjectDNTooLong() {
+	//arrange
+	suite.Client.TLSClientAuthSubjectDN = strings.Repeat("a", models.ClientTLSClientAuthSubjectDNMaxLength+1)
+
+	//act
+	verr := suite.Client.Validate()
+
+	//assert
+	suite.Equal(models.ValidateClientTLSClientAuthSubjectDNTooLong, verr)
+}
+
 func TestClientTestSuite(t *testing.T) {
 	suite.Run(t, &ClientTestSuite{})
 }
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent ValidateError statuses.
+const (
+	ValidateOutboxEventValid             = 0x0
+	ValidateOutboxEventNilID             = 0x1
+	ValidateOutboxEventEmptyType         = 0x2
+	ValidateOutboxEventTypeTooLong       = 0x4
+	ValidateOutboxEventEmptyPayload      = 0x8
+	ValidateOutboxEventZeroCreatedAt     = 0x10
+	ValidateOutboxEventZeroNextAttemptAt = 0x20
+	ValidateOutboxEventLastErrorTooLong  = 0x40
+)
+
+// Max lengths of the outbox event's fields.
+const (
+	OutboxEventTypeMaxLength      = 50
+	OutboxEventLastErrorMaxLength = 255
+)
+
+// OutboxEventFilter pagination limits.
+const (
+	OutboxEventFilterDefaultLimit = 50
+	OutboxEventFilterMaxLimit     = 500
+)
+
+// OutboxEvent types.
+const (
+	OutboxEventTypeUserCreated         = "user.created"
+	OutboxEventTypeUserDeleted         = "user.deleted"
+	OutboxEventTypeUserUpdated         = "user.updated"
+	OutboxEventTypeUserDisabled        = "user.disabled"
+	OutboxEventTypeUserEnabled         = "user.enabled"
+	OutboxEventTypeUserPasswordChanged = "user.password_changed"
+)
+
+// OutboxEvent represents the outbox event model.
+// Events are saved in the same transaction as the change they describe and delivered to webhooks afterwards.
+// The delivered at time is zero until the event has been delivered.
+type OutboxEvent struct {
+	ID            uuid.UUID
+	Type          string
+	Payload       string
+	CreatedAt     time.Time
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredAt   time.Time
+	DeadLettered  bool
+}
+
+// OutboxEventFilter is used to paginate outbox events.
+type OutboxEventFilter struct {
+	Limit  int
+	Offset int
+}
+
+// OutboxEventCRUD is an interface for performing CRUD operations on an outbox event.
+type OutboxEventCRUD interface {
+	// SaveOutboxEvent saves the outbox event and returns any errors.
+	SaveOutboxEvent(ctx context.Context, event *OutboxEvent) error
+
+	// GetPendingOutboxEvents fetches up to limit undelivered events that are due for an attempt at the given time, earliest due first.
+	// The events stay locked until the transaction ends so other dispatchers skip them. Also returns any errors.
+	GetPendingOutboxEvents(ctx context.Context, now time.Time, limit int) ([]*OutboxEvent, error)
+
+	// GetDeadLetteredOutboxEvents fetches a page of the events that ran out of delivery attempts, newest first. Also returns any errors.
+	GetDeadLetteredOutboxEvents(ctx context.Context, filter OutboxEventFilter) ([]*OutboxEvent, error)
+
+	// UpdateOutboxEvent updates the outbox event's delivery state and returns any errors.
+	UpdateOutboxEvent(ctx context.Context, event *OutboxEvent) error
+}
+
+// CreateNewOutboxEvent creates an outbox event model with a new id, the current time and the provided fields.
+// The event is due for its first attempt immediately.
+func CreateNewOutboxEvent(eventType string, payload string) *OutboxEvent {
+	now := time.Now().UTC()
+
+	return &OutboxEvent{
+		ID:            uuid.New(),
+		Type:          eventType,
+		Payload:       payload,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+}
+
+// Validate validates the outbox event model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (e *OutboxEvent) Validate() int {
+	code := ValidateOutboxEventValid
+
+	if e.ID == uuid.Nil {
+		code |= ValidateOutboxEventNilID
+	}
+
+	if e.Type == "" {
+		code |= ValidateOutboxEventEmptyType
+	} else if len(e.Type) > OutboxEventTypeMaxLength {
+		code |= ValidateOutboxEventTypeTooLong
+	}
+
+	if e.Payload == "" {
+		code |= ValidateOutboxEventEmptyPayload
+	}
+
+	if e.CreatedAt.IsZero() {
+		code |= ValidateOutboxEventZeroCreatedAt
+	}
+
+	if e.NextAttemptAt.IsZero() {
+		code |= ValidateOutboxEventZeroNextAttemptAt
+	}
+
+	if len(e.LastError) > OutboxEventLastErrorMaxLength {
+		code |= ValidateOutboxEventLastErrorTooLong
+	}
+
+	return code
+}
+
+// Normalize clamps the filter's limit and offset to valid values.
+func (f *OutboxEventFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = OutboxEventFilterDefaultLimit
+	} else if f.Limit > OutboxEventFilterMaxLimit {
+		f.Limit = OutboxEventFilterMaxLimit
+	}
+
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
@@ -0,0 +1,138 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"authserver/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxEventTestSuite struct {
+	suite.Suite
+	OutboxEvent *models.OutboxEvent
+}
+
+func (suite *OutboxEventTestSuite) SetupTest() {
+	suite.OutboxEvent = models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+}
+
+func (suite *OutboxEventTestSuite) TestCreateNewOutboxEvent_CreatesOutboxEventWithSuppliedFields() {
+	//arrange
+	eventType := models.OutboxEventTypeUserDeleted
+	payload := `{"key":"value"}`
+
+	//act
+	event := models.CreateNewOutboxEvent(eventType, payload)
+
+	//assert
+	suite.Require().NotNil(event)
+	suite.NotEqual(event.ID, uuid.Nil)
+	suite.Equal(eventType, event.Type)
+	suite.Equal(payload, event.Payload)
+	suite.WithinDuration(time.Now(), event.CreatedAt, time.Second)
+	suite.Equal(event.CreatedAt, event.NextAttemptAt)
+	suite.Zero(event.Attempts)
+	suite.True(event.DeliveredAt.IsZero())
+	suite.False(event.DeadLettered)
+}
+
+func (suite *OutboxEventTestSuite) TestValidate_WithValidOutboxEvent_ReturnsValid() {
+	//act
+	verr := suite.OutboxEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateOutboxEventValid, verr)
+}
+
+func (suite *OutboxEventTestSuite) TestValidate_WithNilID_ReturnsOutboxEventNilID() {
+	//arrange
+	suite.OutboxEvent.ID = uuid.Nil
+
+	//act
+	verr := suite.OutboxEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateOutboxEventNilID, verr)
+}
+
+func (suite *OutboxEventTestSuite) TestValidate_WithEmptyTypeAndPayload_ReturnsOutboxEventEmptyTypeAndPayload() {
+	//arrange
+	suite.OutboxEvent.Type = ""
+	suite.OutboxEvent.Payload = ""
+
+	//act
+	verr := suite.OutboxEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateOutboxEventEmptyType|models.ValidateOutboxEventEmptyPayload, verr)
+}
+
+func (suite *OutboxEventTestSuite) TestValidate_WithZeroTimes_ReturnsOutboxEventZeroTimes() {
+	//arrange
+	suite.OutboxEvent.CreatedAt = time.Time{}
+	suite.OutboxEvent.NextAttemptAt = time.Time{}
+
+	//act
+	verr := suite.OutboxEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateOutboxEventZeroCreatedAt|models.ValidateOutboxEventZeroNextAttemptAt, verr)
+}
+
+func (suite *OutboxEventTestSuite) TestValidate_WithFieldsLongerThanMax_ReturnsTooLongErrors() {
+	//arrange
+	suite.OutboxEvent.Type = strings.Repeat("a", models.OutboxEventTypeMaxLength+1)
+	suite.OutboxEvent.LastError = strings.Repeat("a", models.OutboxEventLastErrorMaxLength+1)
+
+	//act
+	verr := suite.OutboxEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateOutboxEventTypeTooLong|models.ValidateOutboxEventLastErrorTooLong, verr)
+}
+
+func (suite *OutboxEventTestSuite) TestValidate_WithFieldsOfMaxLength_ReturnsValid() {
+	//arrange
+	suite.OutboxEvent.Type = strings.Repeat("a", models.OutboxEventTypeMaxLength)
+	suite.OutboxEvent.LastError = strings.Repeat("a", models.OutboxEventLastErrorMaxLength)
+
+	//act
+	verr := suite.OutboxEvent.Validate()
+
+	//assert
+	suite.Equal(models.ValidateOutboxEventValid, verr)
+}
+
+func (suite *OutboxEventTestSuite) TestNormalize_ClampsLimitAndOffset() {
+	var tests = []struct {
+		limit          int
+		offset         int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{0, 0, models.OutboxEventFilterDefaultLimit, 0},
+		{-1, -1, models.OutboxEventFilterDefaultLimit, 0},
+		{models.OutboxEventFilterMaxLimit + 1, 10, models.OutboxEventFilterMaxLimit, 10},
+		{10, 20, 10, 20},
+	}
+
+	for _, test := range tests {
+		//arrange
+		filter := models.OutboxEventFilter{Limit: test.limit, Offset: test.offset}
+
+		//act
+		filter.Normalize()
+
+		//assert
+		suite.Equal(test.expectedLimit, filter.Limit)
+		suite.Equal(test.expectedOffset, filter.Offset)
+	}
+}
+
+func TestOutboxEventTestSuite(t *testing.T) {
+	suite.Run(t, &OutboxEventTestSuite{})
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitBucket ValidateError statuses.
+const (
+	ValidateRateLimitBucketValid          = 0x0
+	ValidateRateLimitBucketEmptyKey       = 0x1
+	ValidateRateLimitBucketKeyTooLong     = 0x2
+	ValidateRateLimitBucketNegativeTokens = 0x4
+	ValidateRateLimitBucketZeroUpdatedAt  = 0x8
+)
+
+// RateLimitBucketKeyMaxLength is the max length a rate limit bucket's key can be.
+const RateLimitBucketKeyMaxLength = 64
+
+// RateLimitBucket represents the rate limit bucket model.
+// It holds the state of a token bucket, which is shared by every application replica when saved to the database.
+type RateLimitBucket struct {
+	Key       string
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// RateLimitBucketCRUD is an interface for performing CRUD operations on a rate limit bucket.
+type RateLimitBucketCRUD interface {
+	// GetRateLimitBucketByKey fetches the rate limit bucket with the matching key.
+	// The bucket stays locked until the transaction ends so concurrent requests can't both take its tokens.
+	// If no buckets are found, returns nil bucket. Also returns any errors.
+	GetRateLimitBucketByKey(ctx context.Context, key string) (*RateLimitBucket, error)
+
+	// SaveRateLimitBucket saves the rate limit bucket, replacing any existing bucket with the same key, and returns any errors.
+	SaveRateLimitBucket(ctx context.Context, bucket *RateLimitBucket) error
+
+	// DeleteRateLimitBucketsUpdatedBefore deletes the rate limit buckets last updated before the given time and returns any errors.
+	DeleteRateLimitBucketsUpdatedBefore(ctx context.Context, before time.Time) error
+}
+
+// Validate validates the rate limit bucket model has valid fields.
+// Returns an int indicating which fields are invalid.
+func (b *RateLimitBucket) Validate() int {
+	code := ValidateRateLimitBucketValid
+
+	if b.Key == "" {
+		code |= ValidateRateLimitBucketEmptyKey
+	} else if len(b.Key) > RateLimitBucketKeyMaxLength {
+		code |= ValidateRateLimitBucketKeyTooLong
+	}
+
+	if b.Tokens < 0 {
+		code |= ValidateRateLimitBucketNegativeTokens
+	}
+
+	if b.UpdatedAt.IsZero() {
+		code |= ValidateRateLimitBucketZeroUpdatedAt
+	}
+
+	return code
+}
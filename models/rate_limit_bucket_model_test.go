@@ -0,0 +1,93 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"authserver/models"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitBucketTestSuite struct {
+	suite.Suite
+	RateLimitBucket *models.RateLimitBucket
+}
+
+func (suite *RateLimitBucketTestSuite) SetupTest() {
+	suite.RateLimitBucket = &models.RateLimitBucket{
+		Key:       "key",
+		Tokens:    1.5,
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (suite *RateLimitBucketTestSuite) TestValidate_WithValidRateLimitBucket_ReturnsValid() {
+	//act
+	verr := suite.RateLimitBucket.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRateLimitBucketValid, verr)
+}
+
+func (suite *RateLimitBucketTestSuite) TestValidate_WithEmptyKey_ReturnsRateLimitBucketEmptyKey() {
+	//arrange
+	suite.RateLimitBucket.Key = ""
+
+	//act
+	verr := suite.RateLimitBucket.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRateLimitBucketEmptyKey, verr)
+}
+
+func (suite *RateLimitBucketTestSuite) TestValidate_KeyLengthTests() {
+	var key string
+	var expectedValidateError int
+
+	testCase := func() {
+		//arrange
+		suite.RateLimitBucket.Key = key
+
+		//act
+		verr := suite.RateLimitBucket.Validate()
+
+		//assert
+		suite.Equal(expectedValidateError, verr)
+	}
+
+	key = strings.Repeat("a", models.RateLimitBucketKeyMaxLength)
+	expectedValidateError = models.ValidateRateLimitBucketValid
+	suite.Run("ExactlyMaxLengthIsValid", testCase)
+
+	key = strings.Repeat("a", models.RateLimitBucketKeyMaxLength+1)
+	expectedValidateError = models.ValidateRateLimitBucketKeyTooLong
+	suite.Run("OneMoreThanMaxLengthIsInvalid", testCase)
+}
+
+func (suite *RateLimitBucketTestSuite) TestValidate_WithNegativeTokens_ReturnsRateLimitBucketNegativeTokens() {
+	//arrange
+	suite.RateLimitBucket.Tokens = -1
+
+	//act
+	verr := suite.RateLimitBucket.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRateLimitBucketNegativeTokens, verr)
+}
+
+func (suite *RateLimitBucketTestSuite) TestValidate_WithZeroUpdatedAt_ReturnsRateLimitBucketZeroUpdatedAt() {
+	//arrange
+	suite.RateLimitBucket.UpdatedAt = time.Time{}
+
+	//act
+	verr := suite.RateLimitBucket.Validate()
+
+	//assert
+	suite.Equal(models.ValidateRateLimitBucketZeroUpdatedAt, verr)
+}
+
+func TestRateLimitBucketTestSuite(t *testing.T) {
+	suite.Run(t, &RateLimitBucketTestSuite{})
+}
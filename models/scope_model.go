@@ -1,6 +1,8 @@
 package models
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 )
 
@@ -24,11 +26,11 @@ type Scope struct {
 // ScopeCRUD is an interface for performing CRUD operations on a scope.
 type ScopeCRUD interface {
 	// SaveScope saves the scope and returns any errors.
-	SaveScope(scope *Scope) error
+	SaveScope(ctx context.Context, scope *Scope) error
 
 	// GetScopeByName fetches the scope with the matching name.
 	// If no scopes are found, returns nil scope. Also returns any errors.
-	GetScopeByName(name string) (*Scope, error)
+	GetScopeByName(ctx context.Context, name string) (*Scope, error)
 }
 
 // CreateNewScope creates a Scope model with a new id and the provided fields.
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 )
 
@@ -16,31 +18,58 @@ const (
 // UserUsernameMaxLength is the max length a user's username can be.
 const UserUsernameMaxLength = 30
 
+// UserFilter pagination limits.
+const (
+	UserFilterDefaultLimit = 50
+	UserFilterMaxLimit     = 500
+)
+
 // User represents the user model.
 type User struct {
 	ID           uuid.UUID
 	Username     string
 	PasswordHash []byte
+	IsAdmin      bool
+	IsDisabled   bool
+}
+
+// UserUpdate contains the changes to make to a user's profile. Nil fields are left unchanged.
+type UserUpdate struct {
+	Username *string
+}
+
+// UserFilter is used to search and paginate users. Zero value fields are not filtered on.
+type UserFilter struct {
+	// Username matches users whose username contains it, ignoring case.
+	Username string
+	Limit    int
+	Offset   int
 }
 
 // UserCRUD is an interface for performing CRUD operations on a user.
 type UserCRUD interface {
 	// SaveUser saves the user and returns any errors.
-	SaveUser(user *User) error
+	SaveUser(ctx context.Context, user *User) error
 
 	// GetUserByID fetches the user associated with the id.
 	// If no users are found, returns nil user. Also returns any errors.
-	GetUserByID(ID uuid.UUID) (*User, error)
+	GetUserByID(ctx context.Context, ID uuid.UUID) (*User, error)
 
 	// GetUserByUsername fetches the user with the matching username.
 	// If no users are found, returns nil user. Also returns any errors.
-	GetUserByUsername(username string) (*User, error)
+	GetUserByUsername(ctx context.Context, username string) (*User, error)
+
+	// GetUsers fetches the users that match the filter, ordered by username. Also returns any errors.
+	GetUsers(ctx context.Context, filter UserFilter) ([]*User, error)
+
+	// CountUsers counts the users that match the filter, ignoring its limit and offset. Also returns any errors.
+	CountUsers(ctx context.Context, filter UserFilter) (int, error)
 
 	// UpdateUser updates the user and returns any errors.
-	UpdateUser(user *User) error
+	UpdateUser(ctx context.Context, user *User) error
 
 	// DeleteUser deletes the user and returns any errors.
-	DeleteUser(user *User) error
+	DeleteUser(ctx context.Context, user *User) error
 }
 
 // CreateNewUser creates a user model with a new id and the provided fields.
@@ -73,3 +102,16 @@ func (u *User) Validate() int {
 
 	return code
 }
+
+// Normalize clamps the filter's limit and offset to valid values.
+func (f *UserFilter) Normalize() {
+	if f.Limit <= 0 {
+		f.Limit = UserFilterDefaultLimit
+	} else if f.Limit > UserFilterMaxLimit {
+		f.Limit = UserFilterMaxLimit
+	}
+
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+}
@@ -98,6 +98,32 @@ func (suite *UserTestSuite) TestValidate_WithEmptyPasswordHash_ReturnsUserInvali
 	suite.Equal(models.ValidateUserInvalidPasswordHash, verr)
 }
 
+func (suite *UserTestSuite) TestNormalize_ClampsLimitAndOffset() {
+	var tests = []struct {
+		limit          int
+		offset         int
+		expectedLimit  int
+		expectedOffset int
+	}{
+		{0, 0, models.UserFilterDefaultLimit, 0},
+		{-1, -1, models.UserFilterDefaultLimit, 0},
+		{models.UserFilterMaxLimit + 1, 10, models.UserFilterMaxLimit, 10},
+		{10, 20, 10, 20},
+	}
+
+	for _, test := range tests {
+		//arrange
+		filter := models.UserFilter{Limit: test.limit, Offset: test.offset}
+
+		//act
+		filter.Normalize()
+
+		//assert
+		suite.Equal(test.expectedLimit, filter.Limit)
+		suite.Equal(test.expectedOffset, filter.Offset)
+	}
+}
+
 func TestUserTestSuite(t *testing.T) {
 	suite.Run(t, &UserTestSuite{})
 }
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"authserver/common"
+	"authserver/database"
+	"authserver/logger"
+	"authserver/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// DatabaseStore is an implementation of the Store interface that keeps the buckets in the database, so they are shared by every application replica.
+// Each take is done in its own transaction, with the bucket locked so concurrent takes from other replicas wait for it.
+type DatabaseStore struct {
+	TransactionFactory database.TransactionFactory
+
+	// Retention is how long buckets are kept after they were last updated. It should be at least the longest period of any limit,
+	// since a deleted bucket is refilled. Stale buckets are deleted at most once per retention. Buckets are never deleted if zero.
+	Retention time.Duration
+
+	mutex       sync.Mutex
+	lastCleanup time.Time
+}
+
+// Take takes a token from the bucket with the key, which is refilled at the limit. Returns the result and any errors.
+func (s *DatabaseStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	tx, err := s.TransactionFactory.CreateTransaction(ctx)
+	if err != nil {
+		return Result{}, common.ChainError("error creating transaction", err)
+	}
+
+	bucket, err := tx.GetRateLimitBucketByKey(ctx, key)
+	if err != nil {
+		tx.RollbackTransaction()
+		return Result{}, common.ChainError("error getting rate limit bucket by key", err)
+	}
+	if bucket == nil {
+		bucket = &models.RateLimitBucket{Key: key}
+	}
+
+	now := time.Now().UTC()
+	tokens, res := take(limit, bucket.Tokens, bucket.UpdatedAt, now)
+
+	bucket.Tokens = tokens
+	bucket.UpdatedAt = now
+
+	err = tx.SaveRateLimitBucket(ctx, bucket)
+	if err != nil {
+		tx.RollbackTransaction()
+		return Result{}, common.ChainError("error saving rate limit bucket", err)
+	}
+
+	err = tx.CommitTransaction()
+	if err != nil {
+		return Result{}, common.ChainError("error committing transaction", err)
+	}
+
+	s.cleanup(ctx, now)
+	return res, nil
+}
+
+// cleanup deletes the buckets that haven't been updated for the retention, if the retention has passed since the last cleanup.
+// Errors are logged rather than returned since the take has already succeeded.
+func (s *DatabaseStore) cleanup(ctx context.Context, now time.Time) {
+	if s.Retention <= 0 {
+		return
+	}
+
+	s.mutex.Lock()
+	if now.Sub(s.lastCleanup) < s.Retention {
+		s.mutex.Unlock()
+		return
+	}
+	s.lastCleanup = now
+	s.mutex.Unlock()
+
+	tx, err := s.TransactionFactory.CreateTransaction(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error creating rate limit cleanup transaction", err))
+		return
+	}
+
+	err = tx.DeleteRateLimitBucketsUpdatedBefore(ctx, now.Add(-s.Retention))
+	if err != nil {
+		tx.RollbackTransaction()
+		logger.FromContext(ctx).Error(common.ChainError("error deleting stale rate limit buckets", err))
+		return
+	}
+
+	err = tx.CommitTransaction()
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error committing rate limit cleanup transaction", err))
+	}
+}
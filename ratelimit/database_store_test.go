@@ -0,0 +1,153 @@
+package ratelimit_test
+
+import (
+	"authserver/common"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"authserver/ratelimit"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DatabaseStoreTestSuite struct {
+	suite.Suite
+	TransactionFactoryMock databasemocks.TransactionFactory
+	TransactionMock        databasemocks.Transaction
+	Store                  *ratelimit.DatabaseStore
+	Limit                  ratelimit.Limit
+}
+
+func (suite *DatabaseStoreTestSuite) SetupTest() {
+	suite.TransactionFactoryMock = databasemocks.TransactionFactory{}
+	suite.TransactionMock = databasemocks.Transaction{}
+	suite.Store = &ratelimit.DatabaseStore{
+		TransactionFactory: &suite.TransactionFactoryMock,
+	}
+	suite.Limit = ratelimit.Limit{Requests: 2, Period: time.Minute}
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.TransactionMock.On("RollbackTransaction")
+}
+
+func (suite *DatabaseStoreTestSuite) TestTake_WithErrorCreatingTransaction_ReturnsError() {
+	//arrange
+	suite.TransactionFactoryMock = databasemocks.TransactionFactory{}
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	_, err := suite.Store.Take(context.Background(), "key", suite.Limit)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error creating transaction")
+}
+
+func (suite *DatabaseStoreTestSuite) TestTake_WithErrorGettingBucket_RollsBackAndReturnsError() {
+	//arrange
+	suite.TransactionMock.On("GetRateLimitBucketByKey", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	_, err := suite.Store.Take(context.Background(), "key", suite.Limit)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error getting rate limit bucket")
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+}
+
+func (suite *DatabaseStoreTestSuite) TestTake_WithErrorSavingBucket_RollsBackAndReturnsError() {
+	//arrange
+	suite.TransactionMock.On("GetRateLimitBucketByKey", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.TransactionMock.On("SaveRateLimitBucket", mock.Anything, mock.Anything).Return(errors.New(""))
+
+	//act
+	_, err := suite.Store.Take(context.Background(), "key", suite.Limit)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error saving rate limit bucket")
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+}
+
+func (suite *DatabaseStoreTestSuite) TestTake_WithErrorCommittingTransaction_ReturnsError() {
+	//arrange
+	suite.TransactionMock.On("GetRateLimitBucketByKey", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.TransactionMock.On("SaveRateLimitBucket", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(errors.New(""))
+
+	//act
+	_, err := suite.Store.Take(context.Background(), "key", suite.Limit)
+
+	//assert
+	common.AssertError(&suite.Suite, err, "error committing transaction")
+}
+
+func (suite *DatabaseStoreTestSuite) TestTake_WhereBucketNotFound_SavesFullBucketLessOneToken() {
+	//arrange
+	key := "key"
+	suite.TransactionMock.On("GetRateLimitBucketByKey", mock.Anything, key).Return(nil, nil)
+	suite.TransactionMock.On("SaveRateLimitBucket", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := suite.Store.Take(context.Background(), key, suite.Limit)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.True(res.Allowed)
+	suite.Equal(1, res.Remaining)
+
+	suite.TransactionMock.AssertCalled(suite.T(), "SaveRateLimitBucket", mock.Anything, mock.MatchedBy(func(b *models.RateLimitBucket) bool {
+		return b.Key == key && b.Tokens == 1 && time.Since(b.UpdatedAt) < time.Second
+	}))
+	suite.TransactionMock.AssertNotCalled(suite.T(), "RollbackTransaction")
+}
+
+func (suite *DatabaseStoreTestSuite) TestTake_WhereBucketIsEmpty_DeniesRequest() {
+	//arrange
+	bucket := &models.RateLimitBucket{
+		Key:       "key",
+		Tokens:    0,
+		UpdatedAt: time.Now().UTC(),
+	}
+	suite.TransactionMock.On("GetRateLimitBucketByKey", mock.Anything, bucket.Key).Return(bucket, nil)
+	suite.TransactionMock.On("SaveRateLimitBucket", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := suite.Store.Take(context.Background(), bucket.Key, suite.Limit)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.False(res.Allowed)
+	suite.InDelta(float64(30*time.Second), float64(res.RetryAfter), float64(time.Second))
+}
+
+func (suite *DatabaseStoreTestSuite) TestTake_WithRetention_DeletesStaleBucketsOncePerRetention() {
+	//arrange
+	suite.Store.Retention = time.Hour
+
+	suite.TransactionMock.On("GetRateLimitBucketByKey", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.TransactionMock.On("SaveRateLimitBucket", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+	suite.TransactionMock.On("DeleteRateLimitBucketsUpdatedBefore", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	_, err1 := suite.Store.Take(context.Background(), "key", suite.Limit)
+	_, err2 := suite.Store.Take(context.Background(), "key", suite.Limit)
+
+	//assert
+	suite.NoError(err1)
+	suite.NoError(err2)
+
+	suite.TransactionMock.AssertNumberOfCalls(suite.T(), "DeleteRateLimitBucketsUpdatedBefore", 1)
+	suite.TransactionMock.AssertCalled(suite.T(), "DeleteRateLimitBucketsUpdatedBefore", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Until(before) < -59*time.Minute
+	}))
+}
+
+func TestDatabaseStoreTestSuite(t *testing.T) {
+	suite.Run(t, &DatabaseStoreTestSuite{})
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Limit is the rate a token bucket allows requests at.
+// The bucket holds up to Requests tokens and is refilled at Requests per Period, so bursts of up to Requests are allowed.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Enabled returns whether the limit allows a finite number of requests.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	// Allowed is whether the bucket had a token for the request.
+	Allowed bool
+
+	// Limit is the number of tokens the bucket holds when full.
+	Limit int
+
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+
+	// RetryAfter is how long until the bucket has a token again. Zero if the request was allowed.
+	RetryAfter time.Duration
+
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// refill returns the tokens in the bucket at the given time, adding the tokens accrued since it was last updated.
+// A bucket that was never updated is full.
+func refill(limit Limit, tokens float64, updatedAt time.Time, now time.Time) float64 {
+	capacity := float64(limit.Requests)
+	if updatedAt.IsZero() {
+		return capacity
+	}
+
+	//the clocks of other replicas may be ahead, so a bucket updated in the future is not refilled
+	elapsed := now.Sub(updatedAt)
+	if elapsed <= 0 {
+		return tokens
+	}
+
+	return math.Min(capacity, tokens+float64(elapsed)*capacity/float64(limit.Period))
+}
+
+// take refills the bucket, then takes a token from it if there is one. Returns the tokens left in the bucket and the result.
+func take(limit Limit, tokens float64, updatedAt time.Time, now time.Time) (float64, Result) {
+	capacity := float64(limit.Requests)
+	rate := capacity / float64(limit.Period)
+
+	tokens = refill(limit, tokens, updatedAt, now)
+
+	res := Result{
+		Limit: limit.Requests,
+	}
+
+	if tokens >= 1 {
+		tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = time.Duration(math.Ceil((1 - tokens) / rate))
+	}
+
+	res.Remaining = int(tokens)
+	res.Reset = time.Duration(math.Ceil((capacity - tokens) / rate))
+
+	return tokens, res
+}
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"authserver/common"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Keys identify who made a request. Empty keys are not limited.
+type Keys struct {
+	IP       string
+	ClientID string
+	Username string
+}
+
+// Rules are the limits of a route for each kind of key. Limits that aren't enabled are not applied.
+type Rules struct {
+	IP       Limit
+	ClientID Limit
+	Username Limit
+}
+
+// Limiter limits the rate of requests to each route, using a token bucket for every key of the request the route has a limit for.
+type Limiter struct {
+	Store Store
+
+	// Routes are the rules of each route, keyed by the route's name. Routes without rules are not limited.
+	Routes map[string]Rules
+}
+
+// Allow takes a token from the buckets of each of the request's keys the route limits. The request is allowed only if every bucket allowed it.
+// Returns the result of the most restrictive bucket, or nil if the route doesn't limit any of the keys. Also returns any errors.
+func (l *Limiter) Allow(ctx context.Context, route string, keys Keys) (*Result, error) {
+	rules := l.Routes[route]
+
+	var result *Result
+	for _, bucket := range []struct {
+		kind  string
+		key   string
+		limit Limit
+	}{
+		{"ip", keys.IP, rules.IP},
+		{"client_id", keys.ClientID, rules.ClientID},
+		{"username", keys.Username, rules.Username},
+	} {
+		if bucket.key == "" || !bucket.limit.Enabled() {
+			continue
+		}
+
+		res, err := l.Store.Take(ctx, bucketKey(route, bucket.kind, bucket.key), bucket.limit)
+		if err != nil {
+			return nil, common.ChainError("error taking token from "+bucket.kind+" bucket", err)
+		}
+
+		if result == nil || isMoreRestrictive(res, *result) {
+			result = &res
+		}
+	}
+
+	return result, nil
+}
+
+// bucketKey returns the key of the bucket for the route and key. It is hashed so its length is bounded and credentials aren't stored.
+func bucketKey(route string, kind string, key string) string {
+	hash := sha256.Sum256([]byte(route + "\x00" + kind + "\x00" + key))
+	return hex.EncodeToString(hash[:])
+}
+
+// isMoreRestrictive returns whether result a is more restrictive than result b.
+// A denial is more restrictive than an allowance, and longer waits or fewer remaining tokens are more restrictive.
+func isMoreRestrictive(a Result, b Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.RetryAfter > b.RetryAfter
+	}
+	return a.Remaining < b.Remaining
+}
@@ -0,0 +1,124 @@
+package ratelimit_test
+
+import (
+	"authserver/common"
+	"authserver/ratelimit"
+	"authserver/ratelimit/mocks"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LimiterTestSuite struct {
+	suite.Suite
+	Limiter ratelimit.Limiter
+}
+
+func (suite *LimiterTestSuite) SetupTest() {
+	suite.Limiter = ratelimit.Limiter{
+		Store: ratelimit.CreateMemoryStore(),
+		Routes: map[string]ratelimit.Rules{
+			"route": {
+				IP:       ratelimit.Limit{Requests: 3, Period: time.Minute},
+				Username: ratelimit.Limit{Requests: 1, Period: time.Minute},
+			},
+		},
+	}
+}
+
+func (suite *LimiterTestSuite) allow(route string, keys ratelimit.Keys) *ratelimit.Result {
+	res, err := suite.Limiter.Allow(context.Background(), route, keys)
+	suite.Require().NoError(err)
+	return res
+}
+
+func (suite *LimiterTestSuite) TestAllow_WhereRouteHasNoRules_ReturnsNilResult() {
+	//act
+	res := suite.allow("other", ratelimit.Keys{IP: "ip", Username: "username"})
+
+	//assert
+	suite.Nil(res)
+}
+
+func (suite *LimiterTestSuite) TestAllow_WhereRouteDoesNotLimitAnyKeys_ReturnsNilResult() {
+	//act
+	res := suite.allow("route", ratelimit.Keys{ClientID: "client"})
+
+	//assert
+	suite.Nil(res)
+}
+
+func (suite *LimiterTestSuite) TestAllow_ReturnsResultOfMostRestrictiveBucket() {
+	//act
+	res := suite.allow("route", ratelimit.Keys{IP: "ip", Username: "username"})
+
+	//assert
+	suite.Require().NotNil(res)
+	suite.True(res.Allowed)
+	suite.Equal(1, res.Limit)
+	suite.Zero(res.Remaining)
+}
+
+func (suite *LimiterTestSuite) TestAllow_WhereAnyBucketIsEmpty_DeniesRequest() {
+	//arrange
+	suite.allow("route", ratelimit.Keys{IP: "ip1", Username: "username"})
+
+	//act
+	res := suite.allow("route", ratelimit.Keys{IP: "ip2", Username: "username"})
+
+	//assert
+	suite.Require().NotNil(res)
+	suite.False(res.Allowed)
+	suite.Equal(1, res.Limit)
+	suite.NotZero(res.RetryAfter)
+}
+
+func (suite *LimiterTestSuite) TestAllow_KeepsSeparateBucketsForEachRoute() {
+	//arrange
+	suite.Limiter.Routes["other"] = suite.Limiter.Routes["route"]
+	suite.allow("route", ratelimit.Keys{Username: "username"})
+
+	//act
+	res := suite.allow("other", ratelimit.Keys{Username: "username"})
+
+	//assert
+	suite.Require().NotNil(res)
+	suite.True(res.Allowed)
+}
+
+func (suite *LimiterTestSuite) TestAllow_WithErrorTakingToken_ReturnsError() {
+	//arrange
+	storeMock := &mocks.Store{}
+	storeMock.On("Take", mock.Anything, mock.Anything, mock.Anything).Return(ratelimit.Result{}, errors.New(""))
+	suite.Limiter.Store = storeMock
+
+	//act
+	res, err := suite.Limiter.Allow(context.Background(), "route", ratelimit.Keys{IP: "ip"})
+
+	//assert
+	suite.Nil(res)
+	common.AssertError(&suite.Suite, err, "error taking token", "ip")
+}
+
+func (suite *LimiterTestSuite) TestAllow_HashesBucketKeys() {
+	//arrange
+	storeMock := &mocks.Store{}
+	storeMock.On("Take", mock.Anything, mock.Anything, mock.Anything).Return(ratelimit.Result{Allowed: true}, nil)
+	suite.Limiter.Store = storeMock
+
+	//act
+	suite.allow("route", ratelimit.Keys{Username: "username"})
+
+	//assert
+	storeMock.AssertCalled(suite.T(), "Take", mock.Anything, mock.MatchedBy(func(key string) bool {
+		return len(key) == 64
+	}), suite.Limiter.Routes["route"].Username)
+}
+
+func TestLimiterTestSuite(t *testing.T) {
+	suite.Run(t, &LimiterTestSuite{})
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store is an interface for keeping the state of token buckets.
+type Store interface {
+	// Take takes a token from the bucket with the key, which is refilled at the limit. Returns the result and any errors.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// memorySweepInterval is how often the memory store removes its full buckets.
+const memorySweepInterval = time.Minute
+
+// MemoryStore is an in-process implementation of the Store interface.
+// It is only suitable when a single replica of the application is running, since each replica's buckets are independent.
+// The store is safe for concurrent use.
+type MemoryStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	limit     Limit
+	tokens    float64
+	updatedAt time.Time
+}
+
+// CreateMemoryStore creates a new memory store with no buckets.
+func CreateMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:   map[string]memoryBucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+// Take takes a token from the bucket with the key, which is refilled at the limit. Never returns an error.
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	b := s.buckets[key]
+	tokens, res := take(limit, b.tokens, b.updatedAt, now)
+
+	s.buckets[key] = memoryBucket{
+		limit:     limit,
+		tokens:    tokens,
+		updatedAt: now,
+	}
+	return res, nil
+}
+
+// sweep removes the buckets that have been refilled since they were last updated, since they are equivalent to new buckets.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if refill(b.limit, b.tokens, b.updatedAt, now) >= float64(b.limit.Requests) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package ratelimit_test
+
+import (
+	"authserver/ratelimit"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryStoreTestSuite struct {
+	suite.Suite
+	Store *ratelimit.MemoryStore
+}
+
+func (suite *MemoryStoreTestSuite) SetupTest() {
+	suite.Store = ratelimit.CreateMemoryStore()
+}
+
+func (suite *MemoryStoreTestSuite) take(key string, limit ratelimit.Limit) ratelimit.Result {
+	res, err := suite.Store.Take(context.Background(), key, limit)
+	suite.Require().NoError(err)
+	return res
+}
+
+func (suite *MemoryStoreTestSuite) TestTake_WithNewBucket_AllowsRequestFromFullBucket() {
+	//arrange
+	limit := ratelimit.Limit{Requests: 3, Period: time.Minute}
+
+	//act
+	res := suite.take("key", limit)
+
+	//assert
+	suite.True(res.Allowed)
+	suite.Equal(3, res.Limit)
+	suite.Equal(2, res.Remaining)
+	suite.Zero(res.RetryAfter)
+	suite.InDelta(float64(20*time.Second), float64(res.Reset), float64(time.Second))
+}
+
+func (suite *MemoryStoreTestSuite) TestTake_WhereBucketIsEmpty_DeniesRequest() {
+	//arrange
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+	suite.take("key", limit)
+	suite.take("key", limit)
+
+	//act
+	res := suite.take("key", limit)
+
+	//assert
+	suite.False(res.Allowed)
+	suite.Zero(res.Remaining)
+	suite.InDelta(float64(30*time.Second), float64(res.RetryAfter), float64(time.Second))
+	suite.InDelta(float64(time.Minute), float64(res.Reset), float64(time.Second))
+}
+
+func (suite *MemoryStoreTestSuite) TestTake_KeepsSeparateBucketForEachKey() {
+	//arrange
+	limit := ratelimit.Limit{Requests: 1, Period: time.Minute}
+	suite.take("key1", limit)
+
+	//act
+	res := suite.take("key2", limit)
+
+	//assert
+	suite.True(res.Allowed)
+}
+
+func (suite *MemoryStoreTestSuite) TestTake_AfterPeriod_RefillsBucket() {
+	//arrange
+	limit := ratelimit.Limit{Requests: 1, Period: 50 * time.Millisecond}
+	suite.take("key", limit)
+	suite.Require().False(suite.take("key", limit).Allowed)
+
+	//act
+	time.Sleep(60 * time.Millisecond)
+	res := suite.take("key", limit)
+
+	//assert
+	suite.True(res.Allowed)
+}
+
+func TestMemoryStoreTestSuite(t *testing.T) {
+	suite.Run(t, &MemoryStoreTestSuite{})
+}
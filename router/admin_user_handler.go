@@ -0,0 +1,173 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/database"
+	"authserver/models"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+// AdminUsersResponse is the struct a page of users is returned as
+type AdminUsersResponse struct {
+	Users  []UserResponse `json:"users"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// getAdminUsers handles GET requests to "/admin/users"
+func (h RouterFactory) getAdminUsers(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the filter
+	filter, err := parseUserFilter(req.URL.Query())
+	if err != nil {
+		return newErrorResponse(requesterror.InvalidRequestError(err.Error()))
+	}
+	filter.Normalize()
+
+	//get the users
+	users, total, rerr := h.Controllers.GetUsers(req.Context(), tx, token.User, filter)
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	res := AdminUsersResponse{
+		Users:  make([]UserResponse, len(users)),
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for i, user := range users {
+		res.Users[i] = newUserResponse(user)
+	}
+
+	return common.NewSuccessDataResponse(res)
+}
+
+// getAdminUser handles GET requests to "/admin/users/:id"
+func (h RouterFactory) getAdminUser(req *http.Request, params httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the id
+	ID, err := parseUserIDParam(params)
+	if err != nil {
+		return newErrorResponse(err)
+	}
+
+	//get the user
+	user, rerr := h.Controllers.GetUserByID(req.Context(), tx, token.User, ID)
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	return common.NewSuccessDataResponse(newUserResponse(user))
+}
+
+// PatchAdminUserBody is the struct the body of requests to PatchAdminUser should be parsed into. Omitted fields are left unchanged.
+type PatchAdminUserBody struct {
+	Disabled *bool `json:"disabled"`
+}
+
+// patchAdminUser handles PATCH requests to "/admin/users/:id"
+func (h RouterFactory) patchAdminUser(req *http.Request, params httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the id
+	ID, err := parseUserIDParam(params)
+	if err != nil {
+		return newErrorResponse(err)
+	}
+
+	//parse the body
+	var body PatchAdminUserBody
+	err = parseJSONBody(req, &body)
+	if err != nil {
+		logBodyError(req, "error parsing PatchAdminUser request body", err)
+		return newErrorResponse(err)
+	}
+
+	//disable or enable the user
+	var user *models.User
+	if body.Disabled != nil {
+		user, err = h.Controllers.SetUserDisabled(req.Context(), tx, token.User, ID, *body.Disabled)
+	} else {
+		user, err = h.Controllers.GetUserByID(req.Context(), tx, token.User, ID)
+	}
+	if err != nil {
+		return newErrorResponse(err)
+	}
+
+	return common.NewSuccessDataResponse(newUserResponse(user))
+}
+
+// deleteAdminUser handles DELETE requests to "/admin/users/:id"
+func (h RouterFactory) deleteAdminUser(req *http.Request, params httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the id
+	ID, err := parseUserIDParam(params)
+	if err != nil {
+		return newErrorResponse(err)
+	}
+
+	//delete the user
+	rerr := h.Controllers.DeleteUserByID(req.Context(), tx, token.User, ID)
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	return common.NewSuccessResponse()
+}
+
+// PostAdminUserPasswordBody is the struct the body of requests to PostAdminUserPassword should be parsed into
+type PostAdminUserPasswordBody struct {
+	Password string `json:"password"`
+}
+
+// postAdminUserPassword handles POST requests to "/admin/users/:id/password"
+func (h RouterFactory) postAdminUserPassword(req *http.Request, params httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the id
+	ID, err := parseUserIDParam(params)
+	if err != nil {
+		return newErrorResponse(err)
+	}
+
+	//parse the body
+	var body PostAdminUserPasswordBody
+	err = parseJSONBody(req, &body)
+	if err != nil {
+		logBodyError(req, "error parsing PostAdminUserPassword request body", err)
+		return newErrorResponse(err)
+	}
+
+	//reset the password
+	rerr := h.Controllers.ResetUserPassword(req.Context(), tx, token.User, ID, body.Password)
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	return common.NewSuccessResponse()
+}
+
+func parseUserFilter(query url.Values) (models.UserFilter, error) {
+	filter := models.UserFilter{
+		Username: query.Get("username"),
+	}
+
+	if len(filter.Username) > models.UserUsernameMaxLength {
+		return filter, errors.New(fmt.Sprint("username cannot be longer than ", models.UserUsernameMaxLength, " characters"))
+	}
+
+	err := parsePagination(query, &filter.Limit, &filter.Offset)
+	return filter, err
+}
+
+func parseUserIDParam(params httprouter.Params) (uuid.UUID, error) {
+	ID, err := uuid.Parse(params.ByName("id"))
+	if err != nil {
+		return uuid.Nil, requesterror.NotFoundError("user not found")
+	}
+
+	return ID, nil
+}
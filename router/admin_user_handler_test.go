@@ -0,0 +1,329 @@
+package router_test
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/models"
+	"authserver/router"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminUserHandlerTestSuite struct {
+	RouterTestSuite
+	Token *models.AccessToken
+}
+
+func (suite *AdminUserHandlerTestSuite) SetupTest() {
+	suite.RouterTestSuite.SetupTest()
+	suite.Token = &models.AccessToken{User: &models.User{ID: uuid.New(), IsAdmin: true}}
+}
+
+func (suite *AdminUserHandlerTestSuite) TestGetAdminUsers_WhereUserIsNotAdmin_ReturnsForbidden() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/users", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.TransactionMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNotCalled(suite.T(), "GetUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusForbidden, "admin")
+}
+
+func (suite *AdminUserHandlerTestSuite) TestGetAdminUsers_WithInvalidQuery_ReturnsBadRequest() {
+	var queries = []struct {
+		query             string
+		expectedSubstring string
+	}{
+		{"limit=-1", "limit"},
+		{"offset=abc", "offset"},
+		{"username=aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "username"},
+	}
+
+	for _, test := range queries {
+		suite.Run(test.query, func() {
+			//arrange
+			server := httptest.NewServer(suite.Router)
+			defer server.Close()
+
+			req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/users?"+test.query, "", nil)
+
+			suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+			suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+			//act
+			res, err := http.DefaultClient.Do(req)
+			suite.Require().NoError(err)
+
+			//assert
+			common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, test.expectedSubstring)
+		})
+	}
+}
+
+func (suite *AdminUserHandlerTestSuite) TestGetAdminUsers_WithValidRequest_ReturnsUsers() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	user := models.CreateNewUser("username", nil)
+	user.IsDisabled = true
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/users?username=user&limit=5&offset=10", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*models.User{user}, 11, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUsers", mock.Anything, &suite.TransactionMock, suite.Token.User, models.UserFilter{
+		Username: "user",
+		Limit:    5,
+		Offset:   10,
+	})
+
+	var body struct {
+		Data router.AdminUsersResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &body)
+	suite.Equal(router.AdminUsersResponse{
+		Users:  []router.UserResponse{{ID: user.ID.String(), Username: "username", IsDisabled: true}},
+		Total:  11,
+		Limit:  5,
+		Offset: 10,
+	}, body.Data)
+}
+
+func (suite *AdminUserHandlerTestSuite) TestGetAdminUser_WithInvalidID_ReturnsNotFound() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/users/invalid", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNotCalled(suite.T(), "GetUserByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusNotFound, "user not found")
+}
+
+func (suite *AdminUserHandlerTestSuite) TestGetAdminUser_WithValidRequest_ReturnsUser() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	user := models.CreateNewUser("username", nil)
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/users/"+user.ID.String(), "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetUserByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(user, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUserByID", mock.Anything, &suite.TransactionMock, suite.Token.User, user.ID)
+
+	var body struct {
+		Data router.UserResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &body)
+	suite.Equal(router.UserResponse{ID: user.ID.String(), Username: "username"}, body.Data)
+}
+
+func (suite *AdminUserHandlerTestSuite) TestPatchAdminUser_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/admin/users/"+uuid.New().String(), "", map[string]string{
+		"disabled": "true",
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNotCalled(suite.T(), "SetUserDisabled", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "disabled must be a json boolean")
+}
+
+func (suite *AdminUserHandlerTestSuite) TestPatchAdminUser_WithForbiddenErrorDisablingUser_ReturnsForbidden() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/admin/users/"+suite.Token.User.ID.String(), "", map[string]interface{}{
+		"disabled": true,
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("SetUserDisabled", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.ForbiddenError("admins cannot disable their own account"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusForbidden, "cannot disable their own account")
+}
+
+func (suite *AdminUserHandlerTestSuite) TestPatchAdminUser_WithValidRequest_ReturnsUpdatedUser() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	user := models.CreateNewUser("username", nil)
+	user.IsDisabled = true
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/admin/users/"+user.ID.String(), "", map[string]interface{}{
+		"disabled": true,
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("SetUserDisabled", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(user, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "SetUserDisabled", mock.Anything, &suite.TransactionMock, suite.Token.User, user.ID, true)
+	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
+
+	var body struct {
+		Data router.UserResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &body)
+	suite.Equal(router.UserResponse{ID: user.ID.String(), Username: "username", IsDisabled: true}, body.Data)
+}
+
+func (suite *AdminUserHandlerTestSuite) TestPostAdminUserPassword_WithClientErrorResettingPassword_ReturnsBadRequest() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/admin/users/"+uuid.New().String()+"/password", "", map[string]string{
+		"password": "short",
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("ResetUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InvalidFieldError("password", "password does not meet minimum criteria"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "password does not meet minimum criteria")
+}
+
+func (suite *AdminUserHandlerTestSuite) TestPostAdminUserPassword_WithValidRequest_ReturnsSuccess() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	ID := uuid.New()
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/admin/users/"+ID.String()+"/password", "", map[string]string{
+		"password": "new password",
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("ResetUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "ResetUserPassword", mock.Anything, &suite.TransactionMock, suite.Token.User, ID, "new password")
+	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
+	common.AssertSuccessResponse(&suite.Suite, res)
+}
+
+func (suite *AdminUserHandlerTestSuite) TestDeleteAdminUser_WithNotFoundErrorDeletingUser_ReturnsNotFound() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/admin/users/"+uuid.New().String(), "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteUserByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.NotFoundError("user not found"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusNotFound, "user not found")
+}
+
+func (suite *AdminUserHandlerTestSuite) TestDeleteAdminUser_WithValidRequest_ReturnsSuccess() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	ID := uuid.New()
+	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/admin/users/"+ID.String(), "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(suite.Token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteUserByID", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteUserByID", mock.Anything, &suite.TransactionMock, suite.Token.User, ID)
+	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
+	common.AssertSuccessResponse(&suite.Suite, res)
+}
+
+func TestAdminUserHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &AdminUserHandlerTestSuite{})
+}
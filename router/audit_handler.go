@@ -0,0 +1,129 @@
+package router
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/database"
+	"authserver/models"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+// AuditEventResponse is the struct audit events are returned as
+type AuditEventResponse struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Action    string    `json:"action"`
+	Outcome   string    `json:"outcome"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	TargetID  string    `json:"target_id,omitempty"`
+	ClientID  string    `json:"client_id,omitempty"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Details   string    `json:"details,omitempty"`
+}
+
+// AuditEventsResponse is the struct a page of audit events is returned as
+type AuditEventsResponse struct {
+	Events []AuditEventResponse `json:"events"`
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+}
+
+// getAuditEvents handles GET requests to "/admin/audit-events"
+func (h RouterFactory) getAuditEvents(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the filter
+	filter, err := parseAuditEventFilter(req.URL.Query())
+	if err != nil {
+		return newErrorResponse(requesterror.InvalidRequestError(err.Error()))
+	}
+	filter.Normalize()
+
+	//get the events
+	events, rerr := h.Controllers.GetAuditEvents(req.Context(), tx, token.User, filter)
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	res := AuditEventsResponse{
+		Events: make([]AuditEventResponse, len(events)),
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for i, event := range events {
+		res.Events[i] = newAuditEventResponse(event)
+	}
+
+	return common.NewSuccessDataResponse(res)
+}
+
+func parseAuditEventFilter(query url.Values) (models.AuditEventFilter, error) {
+	filter := models.AuditEventFilter{
+		Action:  query.Get("action"),
+		Outcome: query.Get("outcome"),
+	}
+
+	var err error
+	ids := map[string]*uuid.UUID{
+		"actor_id":  &filter.ActorID,
+		"target_id": &filter.TargetID,
+		"client_id": &filter.ClientID,
+	}
+	for name, id := range ids {
+		if query.Get(name) == "" {
+			continue
+		}
+
+		*id, err = uuid.Parse(query.Get(name))
+		if err != nil {
+			return filter, errors.New(name + " was in invalid format")
+		}
+	}
+
+	times := map[string]*time.Time{
+		"since": &filter.Since,
+		"until": &filter.Until,
+	}
+	for name, t := range times {
+		if query.Get(name) == "" {
+			continue
+		}
+
+		*t, err = time.Parse(time.RFC3339, query.Get(name))
+		if err != nil {
+			return filter, errors.New(name + " must be an RFC 3339 timestamp")
+		}
+	}
+
+	err = parsePagination(query, &filter.Limit, &filter.Offset)
+	return filter, err
+}
+
+func newAuditEventResponse(event *models.AuditEvent) AuditEventResponse {
+	res := AuditEventResponse{
+		ID:        event.ID.String(),
+		Timestamp: event.Timestamp,
+		Action:    event.Action,
+		Outcome:   event.Outcome,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		Details:   event.Details,
+	}
+
+	if event.ActorID != uuid.Nil {
+		res.ActorID = event.ActorID.String()
+	}
+	if event.TargetID != uuid.Nil {
+		res.TargetID = event.TargetID.String()
+	}
+	if event.ClientID != uuid.Nil {
+		res.ClientID = event.ClientID.String()
+	}
+
+	return res
+}
@@ -0,0 +1,248 @@
+package router_test
+
+import (
+	"authserver/audit"
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"authserver/router"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditHandlerTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_WhereUserIsNotAdmin_ReturnsForbidden() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/audit-events", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.TransactionMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNotCalled(suite.T(), "GetAuditEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.Equal(`Bearer realm="authserver", error="insufficient_scope", error_description="admin access is required"`, res.Header.Get("WWW-Authenticate"))
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusForbidden, "admin")
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_WithInvalidQuery_ReturnsBadRequest() {
+	var queries = []struct {
+		query             string
+		expectedSubstring string
+	}{
+		{"actor_id=invalid", "actor_id"},
+		{"since=yesterday", "since"},
+		{"limit=-1", "limit"},
+		{"offset=abc", "offset"},
+	}
+
+	for _, test := range queries {
+		suite.Run(test.query, func() {
+			//arrange
+			server := httptest.NewServer(suite.Router)
+			defer server.Close()
+
+			token := &models.AccessToken{User: &models.User{IsAdmin: true}}
+			req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/audit-events?"+test.query, "", nil)
+
+			suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+			suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+			//act
+			res, err := http.DefaultClient.Do(req)
+			suite.Require().NoError(err)
+
+			//assert
+			common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, test.expectedSubstring)
+		})
+	}
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_WithInternalErrorGettingAuditEvents_ReturnsInternalServerError() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{IsAdmin: true}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/audit-events", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetAuditEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InternalError())
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+}
+
+func (suite *AuditHandlerTestSuite) TestGetAuditEvents_WithValidRequest_ReturnsEvents() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	actorID := uuid.New()
+	token := &models.AccessToken{User: &models.User{ID: uuid.New(), IsAdmin: true}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/audit-events?action=login&actor_id="+actorID.String()+"&since=2020-10-01T00:00:00Z&limit=5&offset=10", "", nil)
+
+	event := models.CreateNewAuditEvent(models.AuditActionLogin, models.AuditOutcomeSuccess)
+	event.ActorID = actorID
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetAuditEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*models.AuditEvent{event}, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "GetAuditEvents", mock.Anything, &suite.TransactionMock, token.User, mock.MatchedBy(func(filter models.AuditEventFilter) bool {
+		return filter.Action == models.AuditActionLogin && filter.ActorID == actorID &&
+			!filter.Since.IsZero() && filter.Until.IsZero() && filter.Limit == 5 && filter.Offset == 10
+	}))
+
+	var body struct {
+		Success bool                       `json:"success"`
+		Data    router.AuditEventsResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &body)
+
+	suite.Equal(5, body.Data.Limit)
+	suite.Equal(10, body.Data.Offset)
+	suite.Require().Len(body.Data.Events, 1)
+	suite.Equal(event.ID.String(), body.Data.Events[0].ID)
+	suite.Equal(actorID.String(), body.Data.Events[0].ActorID)
+	suite.Empty(body.Data.Events[0].TargetID)
+}
+
+func (suite *AuditHandlerTestSuite) TestRequest_WithAuditFailuresRecorded_SavesFailuresInNewTransaction() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", router.PatchUserPasswordBody{})
+	req.Header.Set("User-Agent", "test agent")
+
+	var event *models.AuditEvent
+	auditTx := databasemocks.Transaction{}
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil).Once()
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&auditTx, nil).Once()
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		trail := audit.FromContext(args.Get(0).(context.Context))
+		event = trail.NewEvent(models.AuditActionPasswordChange, models.AuditOutcomeFailure)
+		trail.AddFailure(event)
+	}).Return(requesterror.InvalidRequestError("old password is invalid"))
+	auditTx.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	auditTx.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "old password")
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	suite.Require().NotNil(event)
+	suite.Equal("127.0.0.1", event.IPAddress)
+	suite.Equal("test agent", event.UserAgent)
+	auditTx.AssertCalled(suite.T(), "SaveAuditEvent", mock.Anything, event)
+	auditTx.AssertCalled(suite.T(), "CommitTransaction")
+}
+
+func (suite *AuditHandlerTestSuite) TestRequest_WithErrorSavingAuditFailures_RollsBackAuditTransaction() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", router.PatchUserPasswordBody{})
+
+	auditTx := databasemocks.Transaction{}
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil).Once()
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&auditTx, nil).Once()
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		trail := audit.FromContext(args.Get(0).(context.Context))
+		trail.AddFailure(trail.NewEvent(models.AuditActionPasswordChange, models.AuditOutcomeFailure))
+	}).Return(requesterror.InvalidRequestError("old password is invalid"))
+	auditTx.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(errors.New(""))
+	auditTx.On("RollbackTransaction")
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "old password")
+	auditTx.AssertCalled(suite.T(), "RollbackTransaction")
+	auditTx.AssertNotCalled(suite.T(), "CommitTransaction")
+}
+
+func (suite *AuditHandlerTestSuite) TestRequest_WithAuditFailuresRecordedAndErrorCommittingTransaction_SavesFailuresInNewTransaction() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", router.PatchUserPasswordBody{})
+
+	var event *models.AuditEvent
+	tx := databasemocks.Transaction{}
+	auditTx := databasemocks.Transaction{}
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&tx, nil).Once()
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&auditTx, nil).Once()
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		trail := audit.FromContext(args.Get(0).(context.Context))
+		event = trail.NewEvent(models.AuditActionPasswordChange, models.AuditOutcomeFailure)
+		trail.AddFailure(event)
+	}).Return(nil)
+	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tx.On("RetryableError").Return(nil)
+	tx.On("CommitTransaction").Return(errors.New("CommitTransaction mock error"))
+	auditTx.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	auditTx.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+	suite.Require().NotNil(event)
+	auditTx.AssertCalled(suite.T(), "SaveAuditEvent", mock.Anything, event)
+	auditTx.AssertCalled(suite.T(), "CommitTransaction")
+}
+
+func TestAuditHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &AuditHandlerTestSuite{})
+}
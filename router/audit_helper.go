@@ -0,0 +1,69 @@
+package router
+
+import (
+	"authserver/audit"
+	"authserver/common"
+	"authserver/logger"
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// getClientIP returns the ip address of the client that made the request.
+func getClientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// saveAuditFailures saves the audit events of failed actions in a new transaction, since the request's transaction was rolled back.
+// The events are saved even if the request's context is done, so a client cannot avoid the audit log by disconnecting.
+// Errors are logged rather than returned since the response has already been decided.
+func (h RouterFactory) saveAuditFailures(ctx context.Context, trail *audit.Trail) {
+	failures := trail.Failures()
+	if len(failures) == 0 {
+		return
+	}
+
+	ctx = detachedContext{ctx}
+
+	tx, err := h.TransactionFactory.CreateTransaction(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error creating audit transaction", err))
+		return
+	}
+
+	for _, event := range failures {
+		err = tx.SaveAuditEvent(ctx, event)
+		if err != nil {
+			logger.FromContext(ctx).Error(common.ChainError("error saving audit event", err))
+			tx.RollbackTransaction()
+			return
+		}
+	}
+
+	err = tx.CommitTransaction()
+	if err != nil {
+		logger.FromContext(ctx).Error(common.ChainError("error commiting audit transaction", err))
+	}
+}
+
+// detachedContext carries the values of its parent context, such as the logger, without its deadline or cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
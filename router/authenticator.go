@@ -1,13 +1,27 @@
 package router
 
 import (
-	requesterror "authserver/common/request_error"
 	"authserver/models"
+	"context"
 	"net/http"
 )
 
 // Authenticator is an interface for authenticating and creating an access token from an http request.
 type Authenticator interface {
 	// Authenticate attempts to create an access token from the given http request.
-	Authenticate(req *http.Request) (*models.AccessToken, requesterror.RequestError)
+	// Returns an unauthorized request error if the request could not be authenticated.
+	Authenticate(req *http.Request) (*models.AccessToken, error)
+}
+
+type tokenContextKey struct{}
+
+// NewTokenContext returns a child of the context that carries the authenticated access token.
+func NewTokenContext(ctx context.Context, token *models.AccessToken) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, token)
+}
+
+// TokenFromContext returns the authenticated access token carried by the context, or nil if the request wasn't authenticated.
+func TokenFromContext(ctx context.Context) *models.AccessToken {
+	token, _ := ctx.Value(tokenContextKey{}).(*models.AccessToken)
+	return token
 }
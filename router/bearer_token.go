@@ -0,0 +1,170 @@
+package router
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/logger"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const bearerScheme = "Bearer"
+
+// errNoBearerToken is returned if the request lacks any bearer token, in which case its challenge doesn't include an error.
+var errNoBearerToken = requesterror.UnauthorizedError("no bearer token provided")
+
+// parseBearerToken extracts the bearer token from the request as defined by RFC 6750, and parses it into a token id.
+// The token is taken from the authorization header, or from the access_token form parameter if allowed. A request may only use one of them.
+func parseBearerToken(req *http.Request, allowFormParameter bool) (uuid.UUID, error) {
+	headers := req.Header.Values("Authorization")
+	if len(headers) > 1 {
+		return uuid.Nil, requesterror.InvalidRequestError("authorization header was included more than once")
+	}
+
+	var headerToken, formToken string
+	var err error
+
+	if len(headers) == 1 {
+		headerToken, err = parseAuthorizationHeader(headers[0])
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	if allowFormParameter {
+		formToken, err = parseAccessTokenFormParameter(req)
+		if err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	//the token must be provided using exactly one method
+	token := headerToken
+	if headerToken == "" && formToken == "" {
+		return uuid.Nil, errNoBearerToken
+	} else if headerToken != "" && formToken != "" {
+		return uuid.Nil, requesterror.InvalidRequestError("bearer token was provided using more than one method")
+	} else if formToken != "" {
+		token = formToken
+	}
+
+	//parse the token
+	tokenID, err := uuid.Parse(token)
+	if err != nil {
+		logger.FromContext(req.Context()).Error(common.ChainError("error parsing access token id", err))
+		return uuid.Nil, requesterror.UnauthorizedError("bearer token was in invalid format")
+	}
+
+	return tokenID, nil
+}
+
+// parseAuthorizationHeader returns the bearer token in the authorization header, or an empty string if it uses another scheme.
+// The scheme is case insensitive, and must be separated from the token by spaces.
+func parseAuthorizationHeader(header string) (string, error) {
+	i := strings.IndexByte(header, ' ')
+	if i < 0 {
+		if strings.EqualFold(header, bearerScheme) {
+			return "", requesterror.InvalidRequestError("authorization header is missing the bearer token")
+		}
+		return "", nil
+	}
+
+	if !strings.EqualFold(header[:i], bearerScheme) {
+		return "", nil
+	}
+
+	token := strings.TrimLeft(header[i:], " ")
+	if !isB64Token(token) {
+		return "", requesterror.InvalidRequestError("authorization header was in invalid format")
+	}
+
+	return token, nil
+}
+
+// parseAccessTokenFormParameter returns the bearer token in the access_token form parameter of the request's body, or an empty string if there isn't one.
+// Only form encoded bodies of requests that aren't GET requests are checked, as defined by RFC 6750.
+func parseAccessTokenFormParameter(req *http.Request) (string, error) {
+	if req.Method == http.MethodGet || req.Body == nil {
+		return "", nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return "", nil
+	}
+
+	err = req.ParseForm()
+	if err != nil {
+		return "", requesterror.InvalidRequestError("invalid request body")
+	}
+
+	values := req.PostForm["access_token"]
+	if len(values) > 1 {
+		return "", requesterror.InvalidRequestError("access_token parameter was included more than once")
+	} else if len(values) == 0 {
+		return "", nil
+	}
+
+	return values[0], nil
+}
+
+// isB64Token returns whether the token matches the b64token syntax of RFC 6750.
+func isB64Token(token string) bool {
+	trimmed := strings.TrimRight(token, "=")
+	if trimmed == "" {
+		return false
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte("-._~+/", c) >= 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bearerChallenge returns the bearer challenge of an error response to a request that requires a bearer token, as defined by RFC 6750.
+// Returns an empty string if the response doesn't need one.
+func bearerChallenge(err error, status int, res interface{}) string {
+	var errorName string
+	switch status {
+	case http.StatusBadRequest:
+		errorName = "invalid_request"
+	case http.StatusUnauthorized:
+		errorName = "invalid_token"
+	case http.StatusForbidden:
+		errorName = "insufficient_scope"
+	default:
+		return ""
+	}
+
+	challenge := bearerScheme + ` realm="` + realm + `"`
+
+	//requests that lack any authentication information are only told how to authenticate
+	errRes, ok := res.(common.ErrorResponse)
+	if err == errNoBearerToken || !ok {
+		return challenge
+	}
+
+	challenge += `, error="` + errorName + `", error_description="` + quoteChallengeParam(errRes.Error) + `"`
+	if errRes.Scope != "" {
+		challenge += `, scope="` + quoteChallengeParam(errRes.Scope) + `"`
+	}
+
+	return challenge
+}
+
+// quoteChallengeParam removes the characters RFC 6750 doesn't allow in challenge parameters.
+func quoteChallengeParam(param string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' || r == '"' || r == '\\' {
+			return -1
+		}
+		return r
+	}, param)
+}
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"authserver/metrics"
+	"authserver/models"
+	"authserver/tokencache"
+	"net/http"
+)
+
+// CachingAuthenticator is an implementation of the Authenticator interface that caches the tokens authenticated by another authenticator.
+// Cached tokens are removed when they expire, or when they are revoked and an invalidation is published for them.
+type CachingAuthenticator struct {
+	// Authenticator is used to authenticate tokens that are not cached.
+	Authenticator Authenticator
+
+	// Cache is the cache of authenticated tokens.
+	Cache *tokencache.Cache
+
+	// MetricsRecorder is used to record cache hits and misses. Optional.
+	MetricsRecorder metrics.Recorder
+
+	// AllowFormParameter determines if the bearer token may be sent in the access_token form parameter instead of the authorization header.
+	AllowFormParameter bool
+}
+
+// Authenticate attempts to create an access token from the given http request, using the cached token if there is one.
+func (a CachingAuthenticator) Authenticate(req *http.Request) (*models.AccessToken, error) {
+	//parse the token id from the authorization header
+	tokenID, err := parseBearerToken(req, a.AllowFormParameter)
+	if err != nil {
+		return nil, err
+	}
+
+	//use the cached token if there is one
+	token := a.Cache.Get(tokenID)
+	if token != nil {
+		a.observe(metrics.TokenCacheHit)
+		return token, nil
+	}
+	a.observe(metrics.TokenCacheMiss)
+
+	//authenticate and cache the token, unless it was invalidated while it was being fetched, since it may have been revoked
+	generation := a.Cache.Generation()
+	token, err = a.Authenticator.Authenticate(req)
+	if err == nil {
+		a.Cache.AddFromGeneration(token, generation)
+	}
+
+	return token, err
+}
+
+func (a CachingAuthenticator) observe(result string) {
+	if a.MetricsRecorder != nil {
+		a.MetricsRecorder.ObserveTokenCache(result)
+	}
+}
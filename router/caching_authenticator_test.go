@@ -0,0 +1,139 @@
+package router_test
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/metrics"
+	metricsmocks "authserver/metrics/mocks"
+	"authserver/models"
+	"authserver/router"
+	"authserver/router/mocks"
+	"authserver/tokencache"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CachingAuthenticatorTestSuite struct {
+	suite.Suite
+	AuthenticatorMock    mocks.Authenticator
+	MetricsRecorderMock  metricsmocks.Recorder
+	Cache                *tokencache.Cache
+	CachingAuthenticator router.CachingAuthenticator
+}
+
+func (suite *CachingAuthenticatorTestSuite) SetupTest() {
+	suite.AuthenticatorMock = mocks.Authenticator{}
+	suite.MetricsRecorderMock = metricsmocks.Recorder{}
+	suite.Cache = tokencache.CreateCache(10, time.Minute)
+
+	suite.MetricsRecorderMock.On("ObserveTokenCache", mock.Anything)
+
+	suite.CachingAuthenticator = router.CachingAuthenticator{
+		Authenticator:   &suite.AuthenticatorMock,
+		Cache:           suite.Cache,
+		MetricsRecorder: &suite.MetricsRecorderMock,
+	}
+}
+
+func (suite *CachingAuthenticatorTestSuite) TestAuthenticate_WithBearerTokenInInvalidFormat_ReturnsClientRequestError() {
+	//arrange
+	req := common.CreateRequest(&suite.Suite, "", "", "invalid", nil)
+
+	//act
+	token, rerr := suite.CachingAuthenticator.Authenticate(req)
+
+	//assert
+	suite.Nil(token)
+
+	common.AssertError(&suite.Suite, rerr, "bearer token", "invalid format")
+	suite.True(errors.Is(rerr, requesterror.ErrUnauthorized))
+
+	suite.AuthenticatorMock.AssertNotCalled(suite.T(), "Authenticate", mock.Anything)
+	suite.MetricsRecorderMock.AssertNotCalled(suite.T(), "ObserveTokenCache", mock.Anything)
+}
+
+func (suite *CachingAuthenticatorTestSuite) TestAuthenticate_WhereTokenIsNotCached_AuthenticatesAndCachesToken() {
+	//arrange
+	token := models.CreateNewAccessToken(models.CreateNewUser("username", []byte("password")), models.CreateNewClient(), models.CreateNewScope("name"))
+	req := common.CreateRequest(&suite.Suite, "", "", token.ID.String(), nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+
+	//act
+	result, rerr := suite.CachingAuthenticator.Authenticate(req)
+
+	//assert
+	suite.NoError(rerr)
+	suite.Same(token, result)
+	suite.Equal(token, suite.Cache.Get(token.ID))
+
+	suite.AuthenticatorMock.AssertCalled(suite.T(), "Authenticate", req)
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveTokenCache", metrics.TokenCacheMiss)
+}
+
+func (suite *CachingAuthenticatorTestSuite) TestAuthenticate_WhereTokenIsInvalidatedWhileAuthenticating_DoesNotCacheToken() {
+	//arrange
+	token := models.CreateNewAccessToken(models.CreateNewUser("username", []byte("password")), models.CreateNewClient(), models.CreateNewScope("name"))
+	req := common.CreateRequest(&suite.Suite, "", "", token.ID.String(), nil)
+
+	//the token is revoked by another request after it was fetched, but before it is cached
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Run(func(_ mock.Arguments) {
+		suite.Cache.Invalidate(tokencache.Invalidation{TokenID: token.ID})
+	}).Return(token, nil)
+
+	//act
+	result, rerr := suite.CachingAuthenticator.Authenticate(req)
+
+	//assert
+	suite.NoError(rerr)
+	suite.Same(token, result)
+	suite.Nil(suite.Cache.Get(token.ID))
+}
+
+func (suite *CachingAuthenticatorTestSuite) TestAuthenticate_WhereTokenIsCached_ReturnsCachedTokenWithoutAuthenticating() {
+	//arrange
+	token := models.CreateNewAccessToken(models.CreateNewUser("username", []byte("password")), models.CreateNewClient(), models.CreateNewScope("name"))
+	req := common.CreateRequest(&suite.Suite, "", "", token.ID.String(), nil)
+
+	suite.Cache.Add(token)
+
+	//act
+	result, rerr := suite.CachingAuthenticator.Authenticate(req)
+
+	//assert
+	suite.NoError(rerr)
+	suite.Equal(token, result)
+
+	suite.AuthenticatorMock.AssertNotCalled(suite.T(), "Authenticate", mock.Anything)
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveTokenCache", metrics.TokenCacheHit)
+}
+
+func (suite *CachingAuthenticatorTestSuite) TestAuthenticate_WhereTokenIsInvalidated_AuthenticatesAgain() {
+	//arrange
+	token := models.CreateNewAccessToken(models.CreateNewUser("username", []byte("password")), models.CreateNewClient(), models.CreateNewScope("name"))
+	req := common.CreateRequest(&suite.Suite, "", "", token.ID.String(), nil)
+
+	suite.Cache.Add(token)
+	suite.Cache.Invalidate(tokencache.Invalidation{TokenID: token.ID})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.UnauthorizedError("invalid bearer token"))
+
+	//act
+	result, rerr := suite.CachingAuthenticator.Authenticate(req)
+
+	//assert
+	suite.Nil(result)
+	common.AssertError(&suite.Suite, rerr, "invalid bearer token")
+	suite.True(errors.Is(rerr, requesterror.ErrUnauthorized))
+	suite.Zero(suite.Cache.Len())
+
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveTokenCache", metrics.TokenCacheMiss)
+}
+
+func TestCachingAuthenticatorTestSuite(t *testing.T) {
+	suite.Run(t, &CachingAuthenticatorTestSuite{})
+}
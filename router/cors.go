@@ -0,0 +1,201 @@
+package router
+
+import (
+	"authserver/logger"
+	"authserver/models"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// CORSPolicy determines which cross origin requests browsers are allowed to make, as defined by the fetch spec.
+type CORSPolicy struct {
+	// AllowedOrigins are the origins allowed to make requests on behalf of any client. "*" allows every origin.
+	AllowedOrigins []string
+
+	// ClientOrigins are the origins registered to each client, keyed by client id.
+	// Requests that identify their client must come from one of its origins or an allowed origin.
+	// Other requests, including preflight requests, are allowed from the origins of every client.
+	ClientOrigins map[string][]string
+
+	// AllowedMethods are the methods allowed in cross origin requests. Every method of the route is allowed if empty.
+	AllowedMethods []string
+
+	// AllowedHeaders are the request headers allowed in cross origin requests, in addition to the headers browsers always allow.
+	AllowedHeaders []string
+
+	// ExposedHeaders are the response headers browsers expose to cross origin requests, in addition to the headers they always expose.
+	ExposedHeaders []string
+
+	// AllowCredentials determines if browsers may include credentials, such as cookies, in cross origin requests.
+	// It should not be combined with the "*" allowed origin, which the config rejects.
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache preflight responses. Browsers use their default if zero.
+	MaxAge time.Duration
+}
+
+// handleCORS is middleware that adds the cors headers to the responses of cross origin requests from allowed origins.
+// Requests from other origins are still handled, but browsers don't let the origin read their responses.
+func (rf RouterFactory) handleCORS(next httprouter.Handle) httprouter.Handle {
+	if rf.CORS == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		origin := req.Header.Get("Origin")
+		if origin == "" {
+			next(w, req, params)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+
+		//only peek at the client if it could restrict the origin
+		clientID := ""
+		if len(rf.CORS.ClientOrigins) > 0 {
+			clientID = peekRequestCredentials(req).ClientID
+		}
+
+		if !rf.CORS.isOriginAllowed(origin, clientID) {
+			logCORSRejection(req, origin, "origin not allowed")
+			next(w, req, params)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if rf.CORS.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		if len(rf.CORS.ExposedHeaders) > 0 {
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(rf.CORS.ExposedHeaders, ", "))
+		}
+
+		next(w, req, params)
+	}
+}
+
+// restrictCORSToClient removes the cors headers handleCORS added to the response if the origin isn't allowed for the client of the authenticated token.
+// Requests authenticated by a bearer token don't identify their client until the token is authenticated, so handleCORS allows the origins of every client.
+func (rf RouterFactory) restrictCORSToClient(w http.ResponseWriter, req *http.Request, token *models.AccessToken) {
+	origin := req.Header.Get("Origin")
+	if rf.CORS == nil || origin == "" || token.Client == nil || w.Header().Get("Access-Control-Allow-Origin") == "" {
+		return
+	}
+
+	if rf.CORS.isOriginAllowed(origin, token.Client.ID.String()) {
+		return
+	}
+
+	logCORSRejection(req, origin, "origin not allowed for client")
+	w.Header().Del("Access-Control-Allow-Origin")
+	w.Header().Del("Access-Control-Allow-Credentials")
+	w.Header().Del("Access-Control-Expose-Headers")
+}
+
+// handlePreflight handles OPTIONS requests to every route. The router sets the Allow header to the route's methods before calling it.
+// Preflight requests from allowed origins, for allowed methods and headers, are responded to with the cors headers. Other preflight requests are responded to without them, so browsers block the request.
+// Preflight requests don't identify their client, so the origins of every client are allowed. The actual request is checked against its client's origins.
+func (rf RouterFactory) handlePreflight(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+	origin := req.Header.Get("Origin")
+	method := req.Header.Get("Access-Control-Request-Method")
+
+	//requests that aren't preflight requests only need the allowed methods
+	if rf.CORS == nil || origin == "" || method == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Add("Vary", "Origin")
+	w.Header().Add("Vary", "Access-Control-Request-Method")
+	w.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	//the method must exist for the route, as well as be allowed
+	routeMethods := splitHeaderList(w.Header().Get("Allow"))
+	allowedMethods := rf.CORS.AllowedMethods
+	if len(allowedMethods) == 0 {
+		allowedMethods = routeMethods
+	}
+
+	requestedHeaders := splitHeaderList(req.Header.Get("Access-Control-Request-Headers"))
+
+	if !rf.CORS.isOriginAllowed(origin, "") {
+		logCORSRejection(req, origin, "origin not allowed")
+	} else if !containsFold(allowedMethods, method) || !containsFold(routeMethods, method) {
+		logCORSRejection(req, origin, "method not allowed")
+	} else if header := firstNotContained(requestedHeaders, rf.CORS.AllowedHeaders); header != "" {
+		logCORSRejection(req, origin, "header "+header+" not allowed")
+	} else {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Allow-Methods", method)
+		if len(requestedHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(requestedHeaders, ", "))
+		}
+		if rf.CORS.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		if rf.CORS.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(rf.CORS.MaxAge.Seconds())))
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isOriginAllowed returns whether the origin may make requests on behalf of the client.
+// If the client is empty, because the request doesn't identify it, the origins of every client are allowed.
+func (p *CORSPolicy) isOriginAllowed(origin string, clientID string) bool {
+	if containsFold(p.AllowedOrigins, "*") || containsFold(p.AllowedOrigins, origin) {
+		return true
+	}
+
+	if clientID != "" {
+		return containsFold(p.ClientOrigins[clientID], origin)
+	}
+
+	for _, origins := range p.ClientOrigins {
+		if containsFold(origins, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func logCORSRejection(req *http.Request, origin string, reason string) {
+	logger.FromContext(req.Context()).With("origin", origin).With("reason", reason).Warn("rejected cross origin request")
+}
+
+// splitHeaderList splits the value of a header that is a comma separated list, such as Allow, into its trimmed elements.
+func splitHeaderList(value string) []string {
+	var elems []string
+	for _, elem := range strings.Split(value, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+	return elems
+}
+
+// containsFold returns whether the list contains the value, ignoring case.
+func containsFold(list []string, value string) bool {
+	for _, elem := range list {
+		if strings.EqualFold(elem, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstNotContained returns the first value that isn't in the list, ignoring case, or an empty string if they all are.
+func firstNotContained(values []string, list []string) string {
+	for _, value := range values {
+		if !containsFold(list, value) {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,225 @@
+package router_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"authserver/router"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CORSTestSuite struct {
+	RouterTestSuite
+	ClientID uuid.UUID
+	Server   *httptest.Server
+}
+
+func (suite *CORSTestSuite) SetupTest() {
+	suite.RouterTestSuite.SetupTest()
+
+	suite.ClientID = uuid.New()
+	suite.RouterFactory.CORS = &router.CORSPolicy{
+		AllowedOrigins: []string{"https://global.example.com"},
+		ClientOrigins: map[string][]string{
+			suite.ClientID.String(): {"https://client.example.com"},
+		},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"Retry-After", "X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+	suite.Server = httptest.NewServer(suite.RouterFactory.CreateRouter())
+}
+
+func (suite *CORSTestSuite) TearDownTest() {
+	suite.Server.Close()
+}
+
+func (suite *CORSTestSuite) createPreflightRequest(path string, origin string, method string, headers string) *http.Request {
+	req := common.CreateRequest(&suite.Suite, http.MethodOptions, suite.Server.URL+path, "", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return req
+}
+
+func (suite *CORSTestSuite) TestPreflight_FromAllowedOrigin_ReturnsCORSHeaders() {
+	//act
+	res, err := http.DefaultClient.Do(suite.createPreflightRequest("/user/password", "https://client.example.com", http.MethodPatch, "authorization, content-type"))
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusNoContent, res.StatusCode)
+	suite.Equal("https://client.example.com", res.Header.Get("Access-Control-Allow-Origin"))
+	suite.Equal(http.MethodPatch, res.Header.Get("Access-Control-Allow-Methods"))
+	suite.Equal("authorization, content-type", res.Header.Get("Access-Control-Allow-Headers"))
+	suite.Equal("true", res.Header.Get("Access-Control-Allow-Credentials"))
+	suite.Equal("600", res.Header.Get("Access-Control-Max-Age"))
+	suite.Contains(res.Header.Values("Vary"), "Origin")
+	suite.NotEmpty(res.Header.Get("X-Request-ID"))
+}
+
+func (suite *CORSTestSuite) TestPreflight_ForEveryRoute_ReturnsCORSHeaders() {
+	routes := map[string]string{
+		"/user":                             http.MethodDelete,
+		"/token":                            http.MethodPost,
+		"/admin/audit-events":               http.MethodGet,
+		"/admin/webhook-events/dead-letter": http.MethodGet,
+		"/healthz":                          http.MethodGet,
+	}
+
+	for path, method := range routes {
+		//act
+		res, err := http.DefaultClient.Do(suite.createPreflightRequest(path, "https://global.example.com", method, ""))
+		suite.Require().NoError(err)
+
+		//assert
+		suite.Equal(http.StatusNoContent, res.StatusCode, path)
+		suite.Equal("https://global.example.com", res.Header.Get("Access-Control-Allow-Origin"), path)
+		suite.Equal(method, res.Header.Get("Access-Control-Allow-Methods"), path)
+	}
+}
+
+func (suite *CORSTestSuite) TestPreflight_WithRejectedRequest_ReturnsNoCORSHeaders() {
+	requests := map[string]*http.Request{
+		"origin not allowed":          suite.createPreflightRequest("/token", "https://evil.example.com", http.MethodPost, ""),
+		"method not allowed":          suite.createPreflightRequest("/token", "https://global.example.com", http.MethodPut, ""),
+		"header X-Custom not allowed": suite.createPreflightRequest("/token", "https://global.example.com", http.MethodPost, "Content-Type, X-Custom"),
+	}
+
+	for reason, req := range requests {
+		//act
+		res, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+
+		//assert
+		suite.Equal(http.StatusNoContent, res.StatusCode, reason)
+		suite.Empty(res.Header.Get("Access-Control-Allow-Origin"), reason)
+		suite.Empty(res.Header.Get("Access-Control-Allow-Methods"), reason)
+		common.AssertContainsSubstrings(&suite.Suite, suite.LogBuffer.String(), "rejected cross origin request", reason, req.Header.Get("Origin"))
+	}
+}
+
+func (suite *CORSTestSuite) TestOptions_WithoutPreflightHeaders_ReturnsAllowedMethods() {
+	//act
+	res, err := http.DefaultClient.Do(common.CreateRequest(&suite.Suite, http.MethodOptions, suite.Server.URL+"/user", "", nil))
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusNoContent, res.StatusCode)
+	common.AssertContainsSubstrings(&suite.Suite, res.Header.Get("Allow"), http.MethodPost, http.MethodDelete)
+	suite.Empty(res.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func (suite *CORSTestSuite) TestRequest_FromAllowedOrigin_ReturnsCORSHeaders() {
+	//arrange
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, suite.Server.URL+"/healthz", "", nil)
+	req.Header.Set("Origin", "https://global.example.com")
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Equal("https://global.example.com", res.Header.Get("Access-Control-Allow-Origin"))
+	suite.Equal("true", res.Header.Get("Access-Control-Allow-Credentials"))
+	suite.Equal("Retry-After, X-Request-ID", res.Header.Get("Access-Control-Expose-Headers"))
+	suite.Contains(res.Header.Values("Vary"), "Origin")
+}
+
+func (suite *CORSTestSuite) TestRequest_FromOriginOfAnotherClient_ReturnsNoCORSHeaders() {
+	//arrange
+	createRequest := func(clientID uuid.UUID) *http.Request {
+		req := common.CreateRequest(&suite.Suite, http.MethodPost, suite.Server.URL+"/token", "", map[string]string{
+			"grant_type": "password",
+			"client_id":  clientID.String(),
+		})
+		req.Header.Set("Origin", "https://client.example.com")
+		return req
+	}
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	res1, err := http.DefaultClient.Do(createRequest(suite.ClientID))
+	suite.Require().NoError(err)
+
+	res2, err := http.DefaultClient.Do(createRequest(uuid.New()))
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal("https://client.example.com", res1.Header.Get("Access-Control-Allow-Origin"))
+	common.AssertInternalServerErrorResponse(&suite.Suite, res1)
+
+	suite.Empty(res2.Header.Get("Access-Control-Allow-Origin"))
+	common.AssertContainsSubstrings(&suite.Suite, suite.LogBuffer.String(), "rejected cross origin request", "https://client.example.com")
+}
+
+func (suite *CORSTestSuite) TestRequest_ToBearerRouteFromOriginOfAnotherClient_ReturnsNoCORSHeaders() {
+	//arrange
+	createRequest := func(origin string) *http.Request {
+		req := common.CreateRequest(&suite.Suite, http.MethodGet, suite.Server.URL+"/user", uuid.New().String(), nil)
+		req.Header.Set("Origin", origin)
+		return req
+	}
+
+	otherClientID := uuid.New()
+	suite.RouterFactory.CORS.ClientOrigins[otherClientID.String()] = []string{"https://other.example.com"}
+
+	token := &models.AccessToken{User: &models.User{}, Client: &models.Client{ID: suite.ClientID}}
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	res1, err := http.DefaultClient.Do(createRequest("https://client.example.com"))
+	suite.Require().NoError(err)
+
+	res2, err := http.DefaultClient.Do(createRequest("https://other.example.com"))
+	suite.Require().NoError(err)
+
+	res3, err := http.DefaultClient.Do(createRequest("https://global.example.com"))
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal("https://client.example.com", res1.Header.Get("Access-Control-Allow-Origin"))
+
+	suite.Empty(res2.Header.Get("Access-Control-Allow-Origin"))
+	suite.Empty(res2.Header.Get("Access-Control-Allow-Credentials"))
+	suite.Empty(res2.Header.Get("Access-Control-Expose-Headers"))
+	common.AssertContainsSubstrings(&suite.Suite, suite.LogBuffer.String(), "rejected cross origin request", "https://other.example.com", "origin not allowed for client")
+
+	suite.Equal("https://global.example.com", res3.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func (suite *CORSTestSuite) TestRequest_WithCORSDisabled_ReturnsNoCORSHeaders() {
+	//arrange
+	suite.RouterFactory.CORS = nil
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/healthz", "", nil)
+	req.Header.Set("Origin", "https://global.example.com")
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Empty(res.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSTestSuite(t *testing.T) {
+	suite.Run(t, &CORSTestSuite{})
+}
@@ -1,11 +1,18 @@
 package router
 
 import (
+	"authserver/audit"
 	"authserver/common"
 	requesterror "authserver/common/request_error"
 	"authserver/database"
+	"authserver/logger"
+	"authserver/metrics"
 	"authserver/models"
-	"log"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
@@ -13,46 +20,183 @@ import (
 
 type handlerFunc func(req *http.Request, params httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{})
 
-func (h RouterFactory) createHandler(handler handlerFunc, authenticateUser bool) httprouter.Handle {
+type commitHooksContextKey struct{}
+
+// commitHooks are the functions to call once a handler's transaction is committed.
+type commitHooks []func()
+
+// afterCommit registers the function to be called once the transaction of the handler executing with the context is committed,
+// so side effects outside of the transaction, such as recording metrics, only happen if it succeeds.
+// The function is called immediately if the context doesn't belong to a handler execution.
+func afterCommit(ctx context.Context, f func()) {
+	hooks, ok := ctx.Value(commitHooksContextKey{}).(*commitHooks)
+	if !ok {
+		f()
+		return
+	}
+
+	*hooks = append(*hooks, f)
+}
+
+// handlerResult is the outcome of executing a handler once.
+type handlerResult struct {
+	status int
+	body   interface{}
+
+	// trail is the audit trail of the execution. Its failures are saved if the result is sent.
+	trail *audit.Trail
+
+	// challenge is the WWW-Authenticate challenge sent with the result, if any.
+	challenge string
+
+	// retryErr is the error that failed the execution's transaction if it can be resolved by retrying the execution.
+	retryErr error
+}
+
+// maxRetryBodyBytes is the max size of request body that is buffered so a retryable handler can be executed again.
+// Requests with larger bodies are only executed once.
+const maxRetryBodyBytes = 64 << 10
+
+// createHandler creates a handle that executes the handler in a new transaction, committing it if the handler succeeds.
+// The handler is given the access token the authenticate middleware added to the request's context, if any.
+// The execution is never retried, so the handler may have side effects outside of the transaction, such as recording metrics.
+func (h RouterFactory) createHandler(handler handlerFunc) httprouter.Handle {
+	return h.createTransactionHandler(handler, RetryPolicy{})
+}
+
+// createRetryableHandler creates a handle like createHandler, except if the transaction fails because of contention with other transactions,
+// the whole execution is retried using the transaction retry policy.
+// The handler must only change state through the transaction, so executing it again is safe.
+func (h RouterFactory) createRetryableHandler(handler handlerFunc) httprouter.Handle {
+	return h.createTransactionHandler(handler, h.TransactionRetryPolicy)
+}
+
+// createTransactionHandler creates a handle that executes the handler in a new transaction, retrying it using the retry policy.
+func (h RouterFactory) createTransactionHandler(handler handlerFunc, policy RetryPolicy) httprouter.Handle {
 	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
-		var token *models.AccessToken
-		var rerr requesterror.RequestError
-
-		//authenticate the user if required
-		if authenticateUser {
-			token, rerr = h.Authenticator.Authenticate(req)
-			if rerr.Type == requesterror.ErrorTypeClient {
-				sendErrorResponse(w, http.StatusUnauthorized, rerr.Error())
-				return
-			} else if rerr.Type == requesterror.ErrorTypeInternal {
-				sendInternalErrorResponse(w)
+		maxRetries := policy.MaxRetries
+
+		//buffer the body so it can be read again by retries
+		var body []byte
+		if maxRetries > 0 && req.Body != nil && req.Body != http.NoBody {
+			var err error
+			body, err = ioutil.ReadAll(io.LimitReader(req.Body, maxRetryBodyBytes+1))
+			if errors.Is(err, errBodyTooLarge) || len(body) > maxRetryBodyBytes {
+				//leave the body for the handler, which rejects it with 413 in the route's error format if it is too large for the route.
+				//it can't be read again, so the execution isn't retried
+				req.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), req.Body))
+				body = nil
+				maxRetries = 0
+			} else if err != nil {
+				logger.FromContext(req.Context()).Error(common.ChainError("error reading request body", err))
+				sendErrorResponse(w, req, requesterror.InvalidRequestError("error reading request body"))
 				return
 			}
 		}
 
-		//start a new transaction
-		tx, err := h.TransactionFactory.CreateTransaction()
-		if err != nil {
-			log.Println(common.ChainError("error creating transaction", err))
-			sendInternalErrorResponse(w)
+		for retry := 0; ; retry++ {
+			if body != nil {
+				req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			}
+
+			result := h.executeHandler(req, params, handler)
+
+			//retry if the transaction failed because of contention and there are retries left
+			if result.retryErr != nil && retry < maxRetries {
+				logger.FromContext(req.Context()).With("retry", retry+1).With("error", result.retryErr.Error()).Warn("transaction failed because of contention, retrying")
+				if h.MetricsRecorder != nil {
+					h.MetricsRecorder.ObserveTransaction(metrics.TransactionRetry)
+				}
+
+				if policy.wait(req.Context(), retry) {
+					continue
+				}
+			}
+
+			if result.trail != nil {
+				h.saveAuditFailures(req.Context(), result.trail)
+			}
+			if result.challenge != "" {
+				w.Header().Set("WWW-Authenticate", result.challenge)
+			}
+			sendNegotiatedResponse(w, req, result.status, result.body)
 			return
 		}
+	}
+}
 
-		//execute the handler, commit the transaction on success, rollback on error
-		status, body := handler(req, params, token, tx)
-		if status == http.StatusOK {
-			//commit the transaction
-			err = tx.CommitTransaction()
-			if err != nil {
-				log.Println(common.ChainError("error commiting transaction", err))
-				sendInternalErrorResponse(w)
-				return
-			}
-		} else {
-			tx.RollbackTransaction()
+// executeHandler executes the handler in a new transaction.
+// The transaction is committed if the handler succeeds, after which the functions registered with afterCommit are called, otherwise it is rolled back.
+func (h RouterFactory) executeHandler(req *http.Request, params httprouter.Params, handler handlerFunc) handlerResult {
+	token := TokenFromContext(req.Context())
+
+	//attach an audit trail for the execution
+	trail := audit.CreateTrail(getClientIP(req), req.UserAgent())
+	req = req.WithContext(audit.NewContext(req.Context(), trail))
+
+	//collect the functions to call once the transaction is committed
+	hooks := &commitHooks{}
+	req = req.WithContext(context.WithValue(req.Context(), commitHooksContextKey{}, hooks))
+
+	//start a new transaction
+	tx, err := h.TransactionFactory.CreateTransaction(req.Context())
+	if err != nil {
+		logger.FromContext(req.Context()).Error(common.ChainError("error creating transaction", err))
+		result := newErrorResult(err)
+		result.retryErr = retryableError(err)
+		return result
+	}
+
+	//execute the handler, commit the transaction on success, rollback on error
+	status, body := handler(req, params, token, tx)
+
+	//a transaction that failed because of contention can't be committed, so roll it back and let the handler be retried
+	if err := tx.RetryableError(); err != nil {
+		tx.RollbackTransaction()
+		result := newErrorResult(err)
+		result.trail = trail
+		result.retryErr = err
+		return result
+	}
+
+	if status != http.StatusOK {
+		tx.RollbackTransaction()
+		result := handlerResult{status: status, body: body, trail: trail}
+
+		//the token was valid but doesn't grant access to the resource
+		if token != nil && status == http.StatusForbidden {
+			result.challenge = bearerChallenge(nil, status, body)
 		}
+		return result
+	}
+
+	//commit the transaction
+	err = tx.CommitTransaction()
+	if err != nil {
+		logger.FromContext(req.Context()).Error(common.ChainError("error commiting transaction", err))
+		result := newErrorResult(err)
+		result.trail = trail
+		result.retryErr = retryableError(err)
+		return result
+	}
 
-		//send the response
-		sendResponse(w, status, body)
+	for _, hook := range *hooks {
+		hook()
 	}
+
+	return handlerResult{status: status, body: body}
+}
+
+// retryableError returns the error if it can be resolved by retrying the transaction, otherwise nil.
+func retryableError(err error) error {
+	if database.IsRetryable(err) {
+		return err
+	}
+	return nil
+}
+
+// newErrorResult creates a result that responds with the error.
+func newErrorResult(err error) handlerResult {
+	status, body := newErrorResponse(err)
+	return handlerResult{status: status, body: body}
 }
@@ -0,0 +1,259 @@
+package router_test
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/database"
+	databasemocks "authserver/database/mocks"
+	"authserver/metrics"
+	"authserver/models"
+	"authserver/router"
+	"authserver/router/mocks"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HandlerFactoryTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *HandlerFactoryTestSuite) SetupTest() {
+	suite.RouterTestSuite.SetupTest()
+
+	suite.MetricsRecorderMock.On("ObserveTransaction", mock.Anything)
+
+	suite.RouterFactory.TransactionRetryPolicy = router.RetryPolicy{
+		MaxRetries: 2,
+	}
+	suite.Router = suite.RouterFactory.CreateRouter()
+}
+
+func (suite *HandlerFactoryTestSuite) createUpdateUserRequest(url string, username string) (*http.Request, router.PatchUserBody) {
+	body := router.PatchUserBody{
+		Username: &username,
+	}
+	return common.CreateRequest(&suite.Suite, http.MethodPatch, url+"/user", "", body), body
+}
+
+func (suite *HandlerFactoryTestSuite) createUpdatePasswordRequest(url string) (*http.Request, router.PatchUserPasswordBody) {
+	body := router.PatchUserPasswordBody{
+		OldPassword: "old password",
+		NewPassword: "new password",
+	}
+	return common.CreateRequest(&suite.Suite, http.MethodPatch, url+"/user/password", "", body), body
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_WhereCommitFailsWithRetryableError_RetriesWithSameBody() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req, body := suite.createUpdateUserRequest(server.URL, "username")
+
+	token := &models.AccessToken{User: &models.User{}}
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(token.User, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(&database.Error{Class: database.ErrorClassSerializationFailure, Err: errors.New("")}).Once()
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionFactoryMock.AssertNumberOfCalls(suite.T(), "CreateTransaction", 2)
+	suite.ControllersMock.AssertNumberOfCalls(suite.T(), "UpdateUser", 2)
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, &suite.TransactionMock, token.User, models.UserUpdate{Username: body.Username})
+	suite.TransactionMock.AssertNumberOfCalls(suite.T(), "CommitTransaction", 2)
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveTransaction", metrics.TransactionRetry)
+	suite.Equal(http.StatusOK, res.StatusCode)
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_WhereTransactionHasRetryableError_RollsBackAndRetries() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req, _ := suite.createUpdateUserRequest(server.URL, "username")
+
+	token := &models.AccessToken{User: &models.User{}}
+	suite.TransactionMock = databasemocks.Transaction{}
+	suite.TransactionMock.On("RollbackTransaction")
+	suite.TransactionMock.On("RetryableError").Return(&database.Error{Class: database.ErrorClassDeadlock, Err: errors.New("")}).Once()
+	suite.TransactionMock.On("RetryableError").Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(token.User, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionFactoryMock.AssertNumberOfCalls(suite.T(), "CreateTransaction", 2)
+	suite.TransactionMock.AssertNumberOfCalls(suite.T(), "RollbackTransaction", 1)
+	suite.TransactionMock.AssertNumberOfCalls(suite.T(), "CommitTransaction", 1)
+	suite.Equal(http.StatusOK, res.StatusCode)
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_WhereRetriesAreExhausted_ReturnsInternalServerError() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req, _ := suite.createUpdateUserRequest(server.URL, "username")
+
+	token := &models.AccessToken{User: &models.User{}}
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(token.User, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(&database.Error{Class: database.ErrorClassLockTimeout, Err: errors.New("")})
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionMock.AssertNumberOfCalls(suite.T(), "CommitTransaction", 3)
+	suite.MetricsRecorderMock.AssertNumberOfCalls(suite.T(), "ObserveTransaction", 2)
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_WhereCommitFailsWithNonRetryableError_DoesNotRetry() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req, _ := suite.createUpdateUserRequest(server.URL, "username")
+
+	token := &models.AccessToken{User: &models.User{}}
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(token.User, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(&database.Error{Class: database.ErrorClassUniqueViolation, Err: errors.New("")})
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionMock.AssertNumberOfCalls(suite.T(), "CommitTransaction", 1)
+	suite.MetricsRecorderMock.AssertNotCalled(suite.T(), "ObserveTransaction", metrics.TransactionRetry)
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_ForRouteThatIsNotRetryable_DoesNotRetry() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req, _ := suite.createUpdatePasswordRequest(server.URL)
+
+	token := &models.AccessToken{User: &models.User{}}
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(&database.Error{Class: database.ErrorClassSerializationFailure, Err: errors.New("")})
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNumberOfCalls(suite.T(), "UpdateUserPassword", 1)
+	suite.MetricsRecorderMock.AssertNotCalled(suite.T(), "ObserveTransaction", metrics.TransactionRetry)
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_WithBodyTooLargeToBuffer_DoesNotRetry() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req, _ := suite.createUpdateUserRequest(server.URL, strings.Repeat("a", 64<<10))
+
+	token := &models.AccessToken{User: &models.User{}}
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(token.User, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(&database.Error{Class: database.ErrorClassSerializationFailure, Err: errors.New("")})
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNumberOfCalls(suite.T(), "UpdateUser", 1)
+	suite.MetricsRecorderMock.AssertNotCalled(suite.T(), "ObserveTransaction", metrics.TransactionRetry)
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+}
+
+func TestHandlerFactoryTestSuite(t *testing.T) {
+	suite.Run(t, &HandlerFactoryTestSuite{})
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_WithNoBearerToken_ReturnsChallengeWithoutError() {
+	//arrange
+	suite.RouterFactory.Authenticator = &router.OAuthAuthenticator{}
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req, _ := suite.createUpdatePasswordRequest(server.URL)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(`Bearer realm="authserver"`, res.Header.Get("WWW-Authenticate"))
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusUnauthorized, "no bearer token")
+}
+
+func (suite *HandlerFactoryTestSuite) TestCreateHandler_WithErrorAuthenticatingUser_ReturnsChallengeWithError() {
+	var authErr error
+	var expectedChallenge string
+
+	testCase := func() {
+		//arrange
+		suite.AuthenticatorMock = mocks.Authenticator{}
+		suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, authErr)
+
+		server := httptest.NewServer(suite.Router)
+		defer server.Close()
+
+		req, _ := suite.createUpdatePasswordRequest(server.URL)
+
+		//act
+		res, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+
+		//assert
+		suite.Equal(expectedChallenge, res.Header.Get("WWW-Authenticate"))
+	}
+
+	authErr = requesterror.UnauthorizedError(`invalid "bearer" token`)
+	expectedChallenge = `Bearer realm="authserver", error="invalid_token", error_description="invalid bearer token"`
+	suite.Run("InvalidToken", testCase)
+
+	authErr = requesterror.InvalidRequestError("authorization header was in invalid format")
+	expectedChallenge = `Bearer realm="authserver", error="invalid_request", error_description="authorization header was in invalid format"`
+	suite.Run("InvalidRequest", testCase)
+
+	authErr = requesterror.InsufficientScopeError("admin", "token lacks scope")
+	expectedChallenge = `Bearer realm="authserver", error="insufficient_scope", error_description="token lacks scope", scope="admin"`
+	suite.Run("InsufficientScope", testCase)
+
+	authErr = requesterror.InternalError()
+	expectedChallenge = ""
+	suite.Run("InternalError", testCase)
+}
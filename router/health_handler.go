@@ -0,0 +1,28 @@
+package router
+
+import (
+	"authserver/health"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// getHealthz handles GET requests to "/healthz"
+func (h RouterFactory) getHealthz(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	sendHealthReport(w, h.LivenessChecker.Run())
+}
+
+// getReadyz handles GET requests to "/readyz"
+func (h RouterFactory) getReadyz(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+	sendHealthReport(w, h.ReadinessChecker.Run())
+}
+
+func sendHealthReport(w http.ResponseWriter, report health.Report) {
+	status := http.StatusOK
+	if report.Status != health.StatusPass {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	sendResponse(w, status, report)
+}
@@ -0,0 +1,84 @@
+package router_test
+
+import (
+	"authserver/common"
+	"authserver/health"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthHandlerTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *HealthHandlerTestSuite) TestGetHealthz_ReturnsPassWithoutAuthenticating() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/healthz", "", nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	var report health.Report
+	common.AssertResponseOK(&suite.Suite, res, &report)
+
+	suite.Equal(health.StatusPass, report.Status)
+	suite.AuthenticatorMock.AssertNotCalled(suite.T(), "Authenticate", mock.Anything)
+}
+
+func (suite *HealthHandlerTestSuite) TestGetReadyz_WhereCheckFails_ReturnsServiceUnavailable() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/readyz", "", nil)
+
+	message := "Check mock error"
+	suite.ReadinessCheckMock.On("Check").Return(errors.New(message))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	var report health.Report
+	status := common.ParseResponse(&suite.Suite, res, &report)
+
+	suite.Equal(http.StatusServiceUnavailable, status)
+	suite.Equal(health.StatusFail, report.Status)
+	suite.Equal(health.StatusFail, report.Checks["check"].Status)
+}
+
+func (suite *HealthHandlerTestSuite) TestGetReadyz_WhereChecksPass_ReturnsOK() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/readyz", "", nil)
+	suite.ReadinessCheckMock.On("Check").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	var report health.Report
+	common.AssertResponseOK(&suite.Suite, res, &report)
+
+	suite.Equal(health.StatusPass, report.Status)
+	suite.Equal(health.StatusPass, report.Checks["check"].Status)
+	suite.AuthenticatorMock.AssertNotCalled(suite.T(), "Authenticate", mock.Anything)
+}
+
+func TestHealthHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &HealthHandlerTestSuite{})
+}
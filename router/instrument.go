@@ -0,0 +1,55 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// statusResponseWriter is a response writer that remembers the status code written to it.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// instrument returns middleware that records the method, route, status, and duration of every request.
+// If the router has no metrics recorder, requests are not instrumented.
+func (h RouterFactory) instrument(route string) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		if h.MetricsRecorder == nil {
+			return next
+		}
+
+		return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+			start := time.Now()
+			sw := &statusResponseWriter{ResponseWriter: w}
+
+			defer func() {
+				//panics are recovered further down the pipeline, but any that escape it are recorded as internal errors
+				status := sw.status
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				info := recover()
+				if info != nil {
+					status = http.StatusInternalServerError
+				}
+
+				h.MetricsRecorder.ObserveRequest(req.Method, route, status, time.Since(start))
+
+				if info != nil {
+					panic(info)
+				}
+			}()
+
+			next(sw, req, params)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package router_test
+
+import (
+	"authserver/common"
+	"authserver/router"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsHandlerTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *MetricsHandlerTestSuite) TestGetMetrics_WithNoMetricsHandler_ReturnsNotFound() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/metrics", "", nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusNotFound, res.StatusCode)
+}
+
+func (suite *MetricsHandlerTestSuite) TestGetMetrics_WithMetricsHandler_ServesMetrics() {
+	//arrange
+	rf := router.RouterFactory{
+		MetricsHandler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte("metrics"))
+		}),
+	}
+
+	server := httptest.NewServer(rf.CreateRouter())
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/metrics", "", nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusOK, res.StatusCode)
+
+	body, err := ioutil.ReadAll(res.Body)
+	suite.Require().NoError(err)
+	suite.Equal("metrics", string(body))
+}
+
+func TestMetricsHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &MetricsHandlerTestSuite{})
+}
@@ -0,0 +1,118 @@
+package router
+
+import (
+	"authserver/audit"
+	requesterror "authserver/common/request_error"
+	"authserver/database/consistency"
+	"authserver/logger"
+	"authserver/models"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Middleware wraps a handle to add behaviour before or after it runs, such as logging or setting headers.
+// It may respond without calling the handle to reject the request.
+type Middleware func(next httprouter.Handle) httprouter.Handle
+
+// Chain composes the middleware into a single middleware. The first middleware is the outermost, so it runs first.
+func Chain(middleware ...Middleware) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			next = middleware[i](next)
+		}
+		return next
+	}
+}
+
+// RouteKey returns the key of the route with the method and path, such as "POST /token", which route middleware is configured by.
+func RouteKey(method string, path string) string {
+	return method + " " + path
+}
+
+// handle registers the handler for the method and path, wrapped in the middleware pipeline.
+// Requests pass through the built in middleware, including body limits and cors, then the global middleware, then the middleware the route requires, such as authentication,
+// and finally the middleware configured for the route, so only the route's configured middleware can read the authenticated token.
+func (rf RouterFactory) handle(r *httprouter.Router, method string, path string, handler httprouter.Handle, middleware ...Middleware) {
+	pipeline := []Middleware{rf.handleRequestID, rf.trackWrites, rf.instrument(path), rf.recoverPanic, rf.limitBody(method, path), rf.handleCORS}
+	pipeline = append(pipeline, rf.Middleware...)
+	pipeline = append(pipeline, middleware...)
+	pipeline = append(pipeline, rf.RouteMiddleware[RouteKey(method, path)]...)
+
+	r.Handle(method, path, Chain(pipeline...)(handler))
+}
+
+// trackWrites adds a consistency tracker to the request's context for the rest of the pipeline,
+// so once the request writes to the database, its later reads, including any made by later middleware, can be pinned to the primary.
+func (rf RouterFactory) trackWrites(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		next(w, req.WithContext(consistency.NewContext(req.Context(), consistency.CreateTracker())), params)
+	}
+}
+
+// recoverPanic recovers from panics in the rest of the pipeline, logging them with their stack trace and responding with an internal error.
+func (rf RouterFactory) recoverPanic(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		defer func() {
+			info := recover()
+			if info == nil {
+				return
+			}
+
+			logger.FromContext(req.Context()).With("stack", string(debug.Stack())).Error(fmt.Errorf("panic handling request: %v", info))
+			sendErrorResponse(w, req, requesterror.InternalError())
+		}()
+
+		next(w, req, params)
+	}
+}
+
+// authenticate authenticates the user making the request, adding their access token to the request's context for the rest of the pipeline.
+// Requests that fail to authenticate are rejected with a bearer challenge.
+func (rf RouterFactory) authenticate(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		token, err := rf.Authenticator.Authenticate(req)
+		if err != nil {
+			sendBearerError(w, req, err)
+			return
+		}
+
+		rf.restrictCORSToClient(w, req, token)
+		next(w, req.WithContext(NewTokenContext(req.Context(), token)), params)
+	}
+}
+
+// requireAdmin rejects requests whose access token does not belong to an admin, recording the attempt as an audit failure.
+// It must run after authenticate.
+func (rf RouterFactory) requireAdmin(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		token := TokenFromContext(req.Context())
+		if token == nil || !token.User.IsAdmin {
+			trail := audit.CreateTrail(getClientIP(req), req.UserAgent())
+			event := trail.NewEvent(models.AuditActionAdminAccess, models.AuditOutcomeFailure)
+			if token != nil {
+				event.ActorID = token.User.ID
+			}
+			event.Details = "admin access is required"
+			trail.AddFailure(event)
+			rf.saveAuditFailures(req.Context(), trail)
+
+			sendBearerError(w, req, requesterror.ForbiddenError("admin access is required"))
+			return
+		}
+
+		next(w, req, params)
+	}
+}
+
+// sendBearerError sends the error response to a request that requires a bearer token, along with its bearer challenge.
+func sendBearerError(w http.ResponseWriter, req *http.Request, err error) {
+	status, body := newErrorResponse(err)
+	if challenge := bearerChallenge(err, status, body); challenge != "" {
+		w.Header().Set("WWW-Authenticate", challenge)
+	}
+
+	sendNegotiatedResponse(w, req, status, body)
+}
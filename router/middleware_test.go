@@ -0,0 +1,221 @@
+package router_test
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/database/consistency"
+	"authserver/models"
+	"authserver/router"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewareTestSuite struct {
+	RouterTestSuite
+	Calls []string
+}
+
+func (suite *MiddlewareTestSuite) SetupTest() {
+	suite.RouterTestSuite.SetupTest()
+	suite.Calls = nil
+}
+
+// recordingMiddleware creates middleware that records its name when it runs, then calls the next handle.
+func (suite *MiddlewareTestSuite) recordingMiddleware(name string) router.Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+			suite.Calls = append(suite.Calls, name)
+			next(w, req, params)
+		}
+	}
+}
+
+func (suite *MiddlewareTestSuite) TestChain_RunsFirstMiddlewareOutermost() {
+	//arrange
+	handle := router.Chain(suite.recordingMiddleware("first"), suite.recordingMiddleware("second"))(func(http.ResponseWriter, *http.Request, httprouter.Params) {
+		suite.Calls = append(suite.Calls, "handle")
+	})
+
+	//act
+	handle(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), nil)
+
+	//assert
+	suite.Equal([]string{"first", "second", "handle"}, suite.Calls)
+}
+
+func (suite *MiddlewareTestSuite) TestCreateRouter_AppliesGlobalMiddlewareBeforeRouteMiddleware() {
+	//arrange
+	suite.RouterFactory.Middleware = []router.Middleware{suite.recordingMiddleware("global1"), suite.recordingMiddleware("global2")}
+	suite.RouterFactory.RouteMiddleware = map[string][]router.Middleware{
+		router.RouteKey(http.MethodGet, "/healthz"): {suite.recordingMiddleware("route")},
+		router.RouteKey(http.MethodGet, "/readyz"):  {suite.recordingMiddleware("other route")},
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/healthz", "", nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Equal([]string{"global1", "global2", "route"}, suite.Calls)
+}
+
+func (suite *MiddlewareTestSuite) TestCreateRouter_TracksWritesForWholeRequestIncludingAuthentication() {
+	//arrange
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/user", "", nil)
+
+	var authTracker *consistency.Tracker
+	var handlerTracker *consistency.Tracker
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Run(func(args mock.Arguments) {
+		authTracker = consistency.FromContext(args.Get(0).(*http.Request).Context())
+	}).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+	suite.ControllersMock.On("GetUser", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		handlerTracker = consistency.FromContext(args.Get(0).(context.Context))
+	}).Return(token.User, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusOK, res.StatusCode)
+	suite.Require().NotNil(authTracker)
+	suite.Same(authTracker, handlerTracker, "authentication and the handler should share the request's tracker")
+}
+
+func (suite *MiddlewareTestSuite) TestCreateRouter_RouteMiddlewareCanReadAuthenticatedToken() {
+	//arrange
+	token := &models.AccessToken{User: &models.User{IsAdmin: true}}
+
+	var contextToken *models.AccessToken
+	suite.RouterFactory.RouteMiddleware = map[string][]router.Middleware{
+		router.RouteKey(http.MethodGet, "/admin/audit-events"): {func(next httprouter.Handle) httprouter.Handle {
+			return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+				contextToken = router.TokenFromContext(req.Context())
+				next(w, req, params)
+			}
+		}},
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/audit-events", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	_, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Same(token, contextToken)
+}
+
+func (suite *MiddlewareTestSuite) TestCreateRouter_WithErrorAuthenticatingUser_DoesNotRunRouteMiddleware() {
+	//arrange
+	suite.RouterFactory.RouteMiddleware = map[string][]router.Middleware{
+		router.RouteKey(http.MethodDelete, "/user"): {suite.recordingMiddleware("route")},
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
+
+	message := "authenticate error"
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.UnauthorizedError(message))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Empty(suite.Calls)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusUnauthorized, message)
+}
+
+func (suite *MiddlewareTestSuite) TestCreateRouter_WhereUserIsNotAdmin_RejectsAdminRoutesBeforeRouteMiddleware() {
+	//arrange
+	suite.RouterFactory.RouteMiddleware = map[string][]router.Middleware{
+		router.RouteKey(http.MethodGet, "/admin/users"): {suite.recordingMiddleware("route")},
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{ID: uuid.New()}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/users", "", nil)
+
+	var event *models.AuditEvent
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.TransactionMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		event = args.Get(1).(*models.AuditEvent)
+	}).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Empty(suite.Calls)
+	suite.TransactionFactoryMock.AssertNumberOfCalls(suite.T(), "CreateTransaction", 1)
+	suite.Require().NotNil(event)
+	suite.Equal(models.AuditActionAdminAccess, event.Action)
+	suite.Equal(models.AuditOutcomeFailure, event.Outcome)
+	suite.Equal(token.User.ID, event.ActorID)
+	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
+	suite.Equal(`Bearer realm="authserver", error="insufficient_scope", error_description="admin access is required"`, res.Header.Get("WWW-Authenticate"))
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusForbidden, "admin access is required")
+}
+
+func (suite *MiddlewareTestSuite) TestCreateRouter_WherePanicIsTriggered_LogsStackTraceAndReturnsInternalServerError() {
+	//arrange
+	suite.RouterFactory.Middleware = []router.Middleware{func(next httprouter.Handle) httprouter.Handle {
+		return func(http.ResponseWriter, *http.Request, httprouter.Params) {
+			panic(errors.New("test panic"))
+		}
+	}}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/healthz", "", nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveRequest", http.MethodGet, "/healthz", http.StatusInternalServerError, mock.Anything)
+	common.AssertContainsSubstrings(&suite.Suite, suite.LogBuffer.String(), "panic handling request", "test panic", "stack", "request_id", "goroutine")
+}
+
+func TestMiddlewareTestSuite(t *testing.T) {
+	suite.Run(t, &MiddlewareTestSuite{})
+}
@@ -7,8 +7,6 @@ import (
 	http "net/http"
 
 	mock "github.com/stretchr/testify/mock"
-
-	requesterror "authserver/common/request_error"
 )
 
 // Authenticator is an autogenerated mock type for the Authenticator type
@@ -17,7 +15,7 @@ type Authenticator struct {
 }
 
 // Authenticate provides a mock function with given fields: req
-func (_m *Authenticator) Authenticate(req *http.Request) (*models.AccessToken, requesterror.RequestError) {
+func (_m *Authenticator) Authenticate(req *http.Request) (*models.AccessToken, error) {
 	ret := _m.Called(req)
 
 	var r0 *models.AccessToken
@@ -29,11 +27,11 @@ func (_m *Authenticator) Authenticate(req *http.Request) (*models.AccessToken, r
 		}
 	}
 
-	var r1 requesterror.RequestError
-	if rf, ok := ret.Get(1).(func(*http.Request) requesterror.RequestError); ok {
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*http.Request) error); ok {
 		r1 = rf(req)
 	} else {
-		r1 = ret.Get(1).(requesterror.RequestError)
+		r1 = ret.Error(1)
 	}
 
 	return r0, r1
@@ -3,46 +3,52 @@ package router
 import (
 	"authserver/common"
 	requesterror "authserver/common/request_error"
+	"authserver/database/consistency"
+	"authserver/logger"
 	"authserver/models"
-	"log"
 	"net/http"
-	"strings"
-
-	"github.com/google/uuid"
 )
 
 // OAuthAuthenticator is an OAuth implementation of the Authenticator interface.
 type OAuthAuthenticator struct {
 	CRUD models.AccessTokenCRUD
+
+	// AllowFormParameter determines if the bearer token may be sent in the access_token form parameter instead of the authorization header.
+	AllowFormParameter bool
 }
 
 // Authenticate attempts to create an access token from the given http request.
-func (a OAuthAuthenticator) Authenticate(req *http.Request) (*models.AccessToken, requesterror.RequestError) {
-	//extract the token string from the authorization header
-	splitTokens := strings.Split(req.Header.Get("Authorization"), "Bearer ")
-	if len(splitTokens) != 2 {
-		return nil, requesterror.ClientError("no bearer token provided")
-	}
-
-	//parse the token
-	tokenID, err := uuid.Parse(splitTokens[1])
+// The token is read from a read replica if the database has any, unless the request has already written and its reads are pinned to the primary.
+// If the replica doesn't have the token, such as a new token it hasn't replicated yet, it is read again from the primary.
+func (a OAuthAuthenticator) Authenticate(req *http.Request) (*models.AccessToken, error) {
+	//parse the token id from the authorization header
+	tokenID, err := parseBearerToken(req, a.AllowFormParameter)
 	if err != nil {
-		log.Println(common.ChainError("error parsing access token id", err))
-		return nil, requesterror.ClientError("bearer token was in invalid format")
+		return nil, err
 	}
 
 	//fetch the token
-	token, err := a.CRUD.GetAccessTokenByID(tokenID)
+	ctx := req.Context()
+	token, err := a.CRUD.GetAccessTokenByID(ctx, tokenID)
 	if err != nil {
-		log.Println(common.ChainError("error getting access token by id", err))
+		logger.FromContext(ctx).Error(common.ChainError("error getting access token by id", err))
 		return nil, requesterror.InternalError()
 	}
 
+	//a lagging replica may not have a new token yet, so check the primary before rejecting it
+	if token == nil && !consistency.RequiresPrimary(ctx) {
+		token, err = a.CRUD.GetAccessTokenByID(consistency.WithPrimary(ctx), tokenID)
+		if err != nil {
+			logger.FromContext(ctx).Error(common.ChainError("error getting access token by id from primary", err))
+			return nil, requesterror.InternalError()
+		}
+	}
+
 	// no token found
 	if token == nil {
-		return nil, requesterror.ClientError("invalid bearer token")
+		return nil, requesterror.UnauthorizedError("invalid bearer token")
 	}
 
 	// auth success
-	return token, requesterror.NoError()
+	return token, nil
 }
@@ -3,10 +3,14 @@ package router_test
 import (
 	"authserver/common"
 	requesterror "authserver/common/request_error"
+	"authserver/database/consistency"
 	databasemocks "authserver/database/mocks"
+	"authserver/models"
 	"authserver/router"
+	"context"
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -38,7 +42,7 @@ func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithNoBearerToken_Ret
 		suite.Nil(token)
 
 		common.AssertError(&suite.Suite, rerr, "no bearer token")
-		suite.Equal(requesterror.ErrorTypeClient, rerr.Type)
+		suite.True(errors.Is(rerr, requesterror.ErrUnauthorized))
 	}
 
 	req = common.CreateRequest(&suite.Suite, "", "", "", nil)
@@ -46,6 +50,84 @@ func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithNoBearerToken_Ret
 
 	req.Header.Set("Authorization", "invalid")
 	suite.Run("AuthorizationHeaderDoesNotContainBearerToken", testCase)
+
+	req.Header.Set("Authorization", "Basic "+uuid.New().String())
+	suite.Run("AuthorizationHeaderUsesOtherScheme", testCase)
+
+	req.Header.Set("Authorization", "xBearer "+uuid.New().String())
+	suite.Run("AuthorizationHeaderSchemeHasPrefix", testCase)
+
+	req = suite.createFormRequest(http.MethodPost, "access_token="+uuid.New().String())
+	suite.Run("FormParameterNotAllowed", testCase)
+
+	suite.OAuthAuthenticator.AllowFormParameter = true
+	req = suite.createFormRequest(http.MethodGet, "access_token="+uuid.New().String())
+	suite.Run("FormParameterInGetRequest", testCase)
+}
+
+func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithMalformedBearerToken_ReturnsInvalidRequestError() {
+	var req *http.Request
+
+	testCase := func() {
+		//act
+		token, rerr := suite.OAuthAuthenticator.Authenticate(req)
+
+		//assert
+		suite.Nil(token)
+		suite.True(errors.Is(rerr, requesterror.ErrInvalidRequest))
+		suite.CRUDMock.AssertNotCalled(suite.T(), "GetAccessTokenByID", mock.Anything, mock.Anything)
+	}
+
+	req = common.CreateRequest(&suite.Suite, "", "", "", nil)
+	req.Header.Set("Authorization", "Bearer")
+	suite.Run("MissingToken", testCase)
+
+	req.Header.Set("Authorization", "Bearer "+uuid.New().String()+" extra")
+	suite.Run("TokenContainsSpace", testCase)
+
+	req.Header.Set("Authorization", "Bearer "+uuid.New().String()+"\"")
+	suite.Run("TokenContainsInvalidCharacter", testCase)
+
+	req.Header.Set("Authorization", "Bearer "+uuid.New().String())
+	req.Header.Add("Authorization", "Bearer "+uuid.New().String())
+	suite.Run("MultipleAuthorizationHeaders", testCase)
+
+	suite.OAuthAuthenticator.AllowFormParameter = true
+	req = suite.createFormRequest(http.MethodPost, "access_token="+uuid.New().String())
+	req.Header.Set("Authorization", "Bearer "+uuid.New().String())
+	suite.Run("HeaderAndFormParameter", testCase)
+
+	req = suite.createFormRequest(http.MethodPost, "access_token="+uuid.New().String()+"&access_token="+uuid.New().String())
+	suite.Run("RepeatedFormParameter", testCase)
+}
+
+func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithValidBearerToken_FetchesAccessTokenByID() {
+	var req *http.Request
+	var id uuid.UUID
+
+	testCase := func() {
+		//arrange
+		suite.CRUDMock = databasemocks.CRUDOperations{}
+		suite.CRUDMock.On("GetAccessTokenByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+		//act
+		suite.OAuthAuthenticator.Authenticate(req)
+
+		//assert
+		suite.CRUDMock.AssertCalled(suite.T(), "GetAccessTokenByID", mock.Anything, id)
+	}
+
+	id = uuid.New()
+	req = common.CreateRequest(&suite.Suite, "", "", "", nil)
+	req.Header.Set("Authorization", "bearer "+id.String())
+	suite.Run("LowercaseScheme", testCase)
+
+	req.Header.Set("Authorization", "BEARER   "+id.String())
+	suite.Run("UppercaseSchemeWithMultipleSpaces", testCase)
+
+	suite.OAuthAuthenticator.AllowFormParameter = true
+	req = suite.createFormRequest(http.MethodPost, "access_token="+id.String())
+	suite.Run("FormParameter", testCase)
 }
 
 func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithBearerTokenInInvalidFormat_ReturnsClientRequestError() {
@@ -59,13 +141,13 @@ func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithBearerTokenInInva
 	suite.Nil(token)
 
 	common.AssertError(&suite.Suite, rerr, "bearer token", "invalid format")
-	suite.Equal(requesterror.ErrorTypeClient, rerr.Type)
+	suite.True(errors.Is(rerr, requesterror.ErrUnauthorized))
 }
 
 func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithErrorFetchingAccessTokenByID_ReturnsInternalServerRequestError() {
 	//arrange
 	req := common.CreateRequest(&suite.Suite, "", "", uuid.New().String(), nil)
-	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything).Return(nil, errors.New(""))
+	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything, mock.Anything).Return(nil, errors.New(""))
 
 	//act
 	token, rerr := suite.OAuthAuthenticator.Authenticate(req)
@@ -74,13 +156,13 @@ func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithErrorFetchingAcce
 	suite.Nil(token)
 
 	common.AssertInternalError(&suite.Suite, rerr)
-	suite.Equal(requesterror.ErrorTypeInternal, rerr.Type)
+	suite.True(errors.Is(rerr, requesterror.ErrInternal))
 }
 
 func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WhereAccessTokenWithIDisNotFound_ReturnsClientRequestError() {
 	//arrange
 	req := common.CreateRequest(&suite.Suite, "", "", uuid.New().String(), nil)
-	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything).Return(nil, nil)
+	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything, mock.Anything).Return(nil, nil)
 
 	//act
 	token, rerr := suite.OAuthAuthenticator.Authenticate(req)
@@ -89,7 +171,52 @@ func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WhereAccessTokenWithI
 	suite.Nil(token)
 
 	common.AssertError(&suite.Suite, rerr, "bearer token", "invalid")
-	suite.Equal(requesterror.ErrorTypeClient, rerr.Type)
+	suite.True(errors.Is(rerr, requesterror.ErrUnauthorized))
+}
+
+func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WithTokenOnReplica_FetchesAccessTokenFromReplica() {
+	//arrange
+	req := common.CreateRequest(&suite.Suite, "", "", uuid.New().String(), nil)
+	token := &models.AccessToken{ID: uuid.New()}
+
+	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything, mock.Anything).Return(token, nil)
+
+	//act
+	result, err := suite.OAuthAuthenticator.Authenticate(req)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Same(token, result)
+
+	suite.CRUDMock.AssertNumberOfCalls(suite.T(), "GetAccessTokenByID", 1)
+	suite.CRUDMock.AssertCalled(suite.T(), "GetAccessTokenByID", mock.MatchedBy(func(ctx context.Context) bool {
+		return !consistency.RequiresPrimary(ctx)
+	}), mock.Anything)
+}
+
+func (suite *OAuthAuthenticatorTestSuite) TestAuthenticate_WhereReplicaDoesNotHaveToken_FetchesAccessTokenFromPrimary() {
+	//arrange
+	req := common.CreateRequest(&suite.Suite, "", "", uuid.New().String(), nil)
+	token := &models.AccessToken{ID: uuid.New()}
+
+	suite.CRUDMock.On("GetAccessTokenByID", mock.MatchedBy(consistency.RequiresPrimary), mock.Anything).Return(token, nil)
+	suite.CRUDMock.On("GetAccessTokenByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+	//act
+	result, err := suite.OAuthAuthenticator.Authenticate(req)
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Same(token, result)
+	suite.CRUDMock.AssertNumberOfCalls(suite.T(), "GetAccessTokenByID", 2)
+}
+
+func (suite *OAuthAuthenticatorTestSuite) createFormRequest(method string, body string) *http.Request {
+	req, err := http.NewRequest(method, "", strings.NewReader(body))
+	suite.Require().NoError(err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
 }
 
 func TestOAuthAuthenticatorTestSuite(t *testing.T) {
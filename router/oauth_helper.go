@@ -2,12 +2,166 @@ package router
 
 import (
 	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+
+	"github.com/julienschmidt/httprouter"
 )
 
+// realm is the protection space clients authenticate to.
+const realm = "authserver"
+
 func sendOAuthErrorResponse(w http.ResponseWriter, status int, err string, description string) {
 	sendResponse(w, status, common.OAuthErrorResponse{
 		Error:            err,
 		ErrorDescription: description,
 	})
 }
+
+// getClientCertificate returns the verified certificate the client presented during the tls handshake, or nil if there isn't one.
+func getClientCertificate(req *http.Request) *x509.Certificate {
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+
+	return req.TLS.PeerCertificates[0]
+}
+
+// handleOAuthEndpoint is middleware for oauth endpoints, such as "/token", so their responses are never cached, as required by the oauth spec.
+func (h RouterFactory) handleOAuthEndpoint(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Pragma", "no-cache")
+		next(w, req, params)
+	}
+}
+
+// parseOAuthBody parses the body of a request to an oauth endpoint into v, choosing the format using the request's content type.
+// Form encoded bodies are parsed as defined by the oauth spec, using the json tags of v's fields as the parameter names.
+// Json bodies, or bodies without a content type, are parsed as json. Unknown parameters are ignored, as required by the oauth spec.
+func parseOAuthBody(req *http.Request, v interface{}) error {
+	mediaType, err := parseMediaType(req)
+	if err != nil {
+		return err
+	}
+
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		return parseFormBody(req, v)
+	case "", "application/json":
+		return decodeJSON(req.Body, v, false)
+	default:
+		return requesterror.UnsupportedMediaTypeError("content type must be application/x-www-form-urlencoded or application/json")
+	}
+}
+
+func parseFormBody(req *http.Request, v interface{}) error {
+	err := req.ParseForm()
+	if errors.Is(err, errBodyTooLarge) {
+		return requesterror.PayloadTooLargeError("request body is too large")
+	}
+	if err != nil {
+		return requesterror.InvalidRequestError("request body is not valid form data")
+	}
+
+	//the oauth spec doesn't allow parameters to be repeated
+	params := make(map[string]string, len(req.PostForm))
+	for name, values := range req.PostForm {
+		if len(values) > 1 {
+			return requesterror.InvalidFieldError(name, name+" parameter was included more than once")
+		}
+		params[name] = values[0]
+	}
+
+	//reuse the json tags of v's fields as the parameter names
+	data, err := json.Marshal(params)
+	if err != nil {
+		return common.ChainError("error encoding form parameters", err)
+	}
+
+	return decodeJSON(bytes.NewReader(data), v, false)
+}
+
+// newOAuthRequestError returns the error parsing a request to an oauth endpoint as an oauth invalid_request error, keeping its status and message.
+func newOAuthRequestError(err error) error {
+	var rerr *requesterror.RequestError
+	if !errors.As(err, &rerr) {
+		return err
+	}
+
+	return &requesterror.RequestError{
+		Code:       rerr.Code,
+		Message:    rerr.Message,
+		Fields:     rerr.Fields,
+		OAuthError: "invalid_request",
+		Err:        err,
+	}
+}
+
+// resolveClientID returns the id of the client making the request to an oauth endpoint.
+// The client may identify itself using http basic authentication instead of the client_id parameter.
+// Clients don't have secrets, so a client that presents one fails to authenticate.
+func resolveClientID(req *http.Request, clientIDParam string) (string, error) {
+	username, password, ok := req.BasicAuth()
+	if !ok {
+		return clientIDParam, nil
+	}
+
+	//the client credentials are form encoded before being encoded as basic credentials
+	clientID, err := url.QueryUnescape(username)
+	if err != nil {
+		return "", requesterror.OAuthClientError(requesterror.OAuthErrorInvalidClient, "client credentials were in invalid format")
+	}
+	if password != "" {
+		return "", requesterror.OAuthClientError(requesterror.OAuthErrorInvalidClient, "invalid client credentials")
+	}
+
+	if clientIDParam != "" && clientIDParam != clientID {
+		return "", requesterror.OAuthClientError("invalid_request", "client_id parameter does not match the client credentials")
+	}
+
+	return clientID, nil
+}
+
+// requestCredentials are the fields of a request that identify who made it.
+type requestCredentials struct {
+	ClientID string `json:"client_id"`
+	Username string `json:"username"`
+}
+
+// peekRequestCredentials returns the client id and username in the request's body, or the client id in its basic credentials, before the handler runs.
+// The body is restored afterwards so the handler can parse it. Invalid bodies are left for the handler to reject.
+func peekRequestCredentials(req *http.Request) requestCredentials {
+	var creds requestCredentials
+	if req.Body == nil {
+		return creds
+	}
+
+	//restore the body followed by the rest of the original, so errors reading it, such as it being too large, are seen by the handler
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), req.Body))
+	if err != nil {
+		return creds
+	}
+
+	//parse a copy of the request so the original's form isn't parsed
+	peek := req.Clone(req.Context())
+	peek.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if parseOAuthBody(peek, &creds) != nil {
+		creds = requestCredentials{}
+	}
+
+	if clientID, err := resolveClientID(peek, creds.ClientID); err == nil {
+		creds.ClientID = clientID
+	}
+
+	return creds
+}
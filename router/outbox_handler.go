@@ -0,0 +1,65 @@
+package router
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/database"
+	"authserver/models"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// OutboxEventResponse is the struct outbox events are returned as
+type OutboxEventResponse struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error"`
+}
+
+// OutboxEventsResponse is the struct a page of outbox events is returned as
+type OutboxEventsResponse struct {
+	Events []OutboxEventResponse `json:"events"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+// getDeadLetteredWebhookEvents handles GET requests to "/admin/webhook-events/dead-letter"
+func (h RouterFactory) getDeadLetteredWebhookEvents(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the filter
+	filter := models.OutboxEventFilter{}
+	err := parsePagination(req.URL.Query(), &filter.Limit, &filter.Offset)
+	if err != nil {
+		return newErrorResponse(requesterror.InvalidRequestError(err.Error()))
+	}
+	filter.Normalize()
+
+	//get the events
+	events, rerr := h.Controllers.GetDeadLetteredOutboxEvents(req.Context(), tx, filter)
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	res := OutboxEventsResponse{
+		Events: make([]OutboxEventResponse, len(events)),
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for i, event := range events {
+		res.Events[i] = OutboxEventResponse{
+			ID:        event.ID.String(),
+			Type:      event.Type,
+			Payload:   json.RawMessage(event.Payload),
+			CreatedAt: event.CreatedAt,
+			Attempts:  event.Attempts,
+			LastError: event.LastError,
+		}
+	}
+
+	return common.NewSuccessDataResponse(res)
+}
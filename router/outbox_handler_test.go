@@ -0,0 +1,125 @@
+package router_test
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/models"
+	"authserver/router"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OutboxHandlerTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *OutboxHandlerTestSuite) TestGetDeadLetteredWebhookEvents_WhereUserIsNotAdmin_ReturnsForbidden() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/webhook-events/dead-letter", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.TransactionMock.On("SaveAuditEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNotCalled(suite.T(), "GetDeadLetteredOutboxEvents", mock.Anything, mock.Anything, mock.Anything)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusForbidden, "admin")
+}
+
+func (suite *OutboxHandlerTestSuite) TestGetDeadLetteredWebhookEvents_WithInvalidQuery_ReturnsBadRequest() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{IsAdmin: true}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/webhook-events/dead-letter?limit=abc", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "limit")
+}
+
+func (suite *OutboxHandlerTestSuite) TestGetDeadLetteredWebhookEvents_WithInternalErrorGettingEvents_ReturnsInternalServerError() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{IsAdmin: true}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/webhook-events/dead-letter", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetDeadLetteredOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InternalError())
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+}
+
+func (suite *OutboxHandlerTestSuite) TestGetDeadLetteredWebhookEvents_WithValidRequest_ReturnsEvents() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{ID: uuid.New(), IsAdmin: true}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/admin/webhook-events/dead-letter?limit=5&offset=10", "", nil)
+
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, `{"key":"value"}`)
+	event.Attempts = 8
+	event.LastError = "unexpected status code 500"
+	event.DeadLettered = true
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetDeadLetteredOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "GetDeadLetteredOutboxEvents", mock.Anything, &suite.TransactionMock, models.OutboxEventFilter{Limit: 5, Offset: 10})
+
+	var body struct {
+		Success bool                        `json:"success"`
+		Data    router.OutboxEventsResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &body)
+
+	suite.Equal(5, body.Data.Limit)
+	suite.Equal(10, body.Data.Offset)
+	suite.Require().Len(body.Data.Events, 1)
+	suite.Equal(event.ID.String(), body.Data.Events[0].ID)
+	suite.Equal(event.Type, body.Data.Events[0].Type)
+	suite.JSONEq(event.Payload, string(body.Data.Events[0].Payload))
+	suite.Equal(event.Attempts, body.Data.Events[0].Attempts)
+	suite.Equal(event.LastError, body.Data.Events[0].LastError)
+}
+
+func TestOutboxHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &OutboxHandlerTestSuite{})
+}
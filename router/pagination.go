@@ -0,0 +1,29 @@
+package router
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// parsePagination parses the limit and offset query parameters into the provided ints.
+// Missing parameters leave their int unchanged. Returns an error if either parameter is not a non-negative integer.
+func parsePagination(query url.Values, limit *int, offset *int) error {
+	ints := map[string]*int{
+		"limit":  limit,
+		"offset": offset,
+	}
+	for name, i := range ints {
+		if query.Get(name) == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(query.Get(name))
+		if err != nil || value < 0 {
+			return errors.New(name + " must be a non-negative integer")
+		}
+		*i = value
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package router
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/logger"
+	"authserver/ratelimit"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Names of the rate limited routes, which their rules are keyed by.
+const (
+	RateLimitRouteToken      = "token"
+	RateLimitRouteCreateUser = "create_user"
+)
+
+// limitRate returns middleware that rejects requests to the route once they exceed its rate limits.
+// The remaining quota is sent in the RateLimit headers. If the limits can't be checked, the request is allowed so the route stays available.
+func (h RouterFactory) limitRate(route string) Middleware {
+	return func(next httprouter.Handle) httprouter.Handle {
+		if h.RateLimiter == nil {
+			return next
+		}
+
+		return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+			res, err := h.RateLimiter.Allow(req.Context(), route, rateLimitKeys(req))
+			if err != nil {
+				logger.FromContext(req.Context()).Error(common.ChainError("error checking rate limits", err))
+				next(w, req, params)
+				return
+			}
+
+			if res != nil {
+				w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+				w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+				w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+
+				if !res.Allowed {
+					w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+					sendErrorResponse(w, req, requesterror.RateLimitedError("too many requests, try again later"))
+					return
+				}
+			}
+
+			next(w, req, params)
+		}
+	}
+}
+
+// rateLimitKeys returns the keys that identify who made the request: the client's ip, plus the client id and username in its body, if any.
+func rateLimitKeys(req *http.Request) ratelimit.Keys {
+	creds := peekRequestCredentials(req)
+
+	return ratelimit.Keys{
+		IP:       getClientIP(req),
+		ClientID: creds.ClientID,
+		Username: creds.Username,
+	}
+}
+
+// ceilSeconds returns the duration in whole seconds, rounded up.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
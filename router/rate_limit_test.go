@@ -0,0 +1,182 @@
+package router_test
+
+import (
+	"authserver/common"
+	"authserver/models"
+	"authserver/ratelimit"
+	ratelimitmocks "authserver/ratelimit/mocks"
+	"authserver/router"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *RateLimitTestSuite) setRules(route string, rules ratelimit.Rules) {
+	suite.RouterFactory.RateLimiter = &ratelimit.Limiter{
+		Store: ratelimit.CreateMemoryStore(),
+		Routes: map[string]ratelimit.Rules{
+			route: rules,
+		},
+	}
+	suite.Router = suite.RouterFactory.CreateRouter()
+}
+
+func (suite *RateLimitTestSuite) createFormTokenRequest(url string, form url.Values) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, url+"/token", strings.NewReader(form.Encode()))
+	suite.Require().NoError(err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func (suite *RateLimitTestSuite) TestPostUser_WhereIPLimitIsExceeded_ReturnsTooManyRequests() {
+	//arrange
+	suite.setRules(router.RateLimitRouteCreateUser, ratelimit.Rules{
+		IP: ratelimit.Limit{Requests: 1, Period: time.Minute},
+	})
+
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	res1, err := http.DefaultClient.Do(common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", nil))
+	suite.Require().NoError(err)
+
+	res2, err := http.DefaultClient.Do(common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", nil))
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionFactoryMock.AssertNumberOfCalls(suite.T(), "CreateTransaction", 1)
+
+	suite.Equal("1", res1.Header.Get("RateLimit-Limit"))
+	suite.Equal("0", res1.Header.Get("RateLimit-Remaining"))
+	suite.Equal("60", res1.Header.Get("RateLimit-Reset"))
+	common.AssertInternalServerErrorResponse(&suite.Suite, res1)
+
+	suite.Equal("60", res2.Header.Get("Retry-After"))
+	suite.Equal("1", res2.Header.Get("RateLimit-Limit"))
+	suite.Equal("0", res2.Header.Get("RateLimit-Remaining"))
+	common.AssertErrorResponse(&suite.Suite, res2, http.StatusTooManyRequests, "too many requests")
+}
+
+func (suite *RateLimitTestSuite) TestPostToken_WhereUsernameLimitIsExceeded_OnlyLimitsThatUsername() {
+	//arrange
+	suite.setRules(router.RateLimitRouteToken, ratelimit.Rules{
+		Username: ratelimit.Limit{Requests: 1, Period: time.Minute},
+	})
+
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	createRequest := func(username string) *http.Request {
+		return suite.createFormTokenRequest(server.URL, url.Values{
+			"grant_type": {"password"},
+			"username":   {username},
+			"password":   {"password"},
+			"client_id":  {uuid.New().String()},
+			"scope":      {"scope"},
+		})
+	}
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(models.CreateNewAccessToken(nil, nil, nil), nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res1, err := http.DefaultClient.Do(createRequest("username1"))
+	suite.Require().NoError(err)
+
+	res2, err := http.DefaultClient.Do(createRequest("username2"))
+	suite.Require().NoError(err)
+
+	res3, err := http.DefaultClient.Do(createRequest("username1"))
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal(http.StatusOK, res1.StatusCode)
+	suite.Equal(http.StatusOK, res2.StatusCode)
+
+	//the handler still receives the body after it is parsed for the keys
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateTokenFromPassword", mock.Anything, &suite.TransactionMock, "username1", "password", mock.Anything, (*x509.Certificate)(nil), "scope")
+	suite.ControllersMock.AssertNumberOfCalls(suite.T(), "CreateTokenFromPassword", 2)
+
+	suite.Equal("no-store", res3.Header.Get("Cache-Control"))
+	common.AssertErrorResponse(&suite.Suite, res3, http.StatusTooManyRequests, "too many requests")
+}
+
+func (suite *RateLimitTestSuite) TestPostToken_WhereClientIDLimitIsExceeded_LimitsBasicClientCredentials() {
+	//arrange
+	suite.setRules(router.RateLimitRouteToken, ratelimit.Rules{
+		ClientID: ratelimit.Limit{Requests: 1, Period: time.Minute},
+	})
+
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	clientID := uuid.New()
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	res1, err := http.DefaultClient.Do(suite.createFormTokenRequest(server.URL, url.Values{
+		"client_id": {clientID.String()},
+	}))
+	suite.Require().NoError(err)
+
+	req := suite.createFormTokenRequest(server.URL, url.Values{})
+	req.SetBasicAuth(clientID.String(), "")
+
+	res2, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertInternalServerErrorResponse(&suite.Suite, res1)
+	common.AssertErrorResponse(&suite.Suite, res2, http.StatusTooManyRequests, "too many requests")
+}
+
+func (suite *RateLimitTestSuite) TestPostUser_WithErrorCheckingRateLimits_AllowsRequest() {
+	//arrange
+	storeMock := &ratelimitmocks.Store{}
+	storeMock.On("Take", mock.Anything, mock.Anything, mock.Anything).Return(ratelimit.Result{}, errors.New(""))
+
+	suite.RouterFactory.RateLimiter = &ratelimit.Limiter{
+		Store: storeMock,
+		Routes: map[string]ratelimit.Rules{
+			router.RateLimitRouteCreateUser: {IP: ratelimit.Limit{Requests: 1, Period: time.Minute}},
+		},
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
+
+	//act
+	res, err := http.DefaultClient.Do(common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", nil))
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction", mock.Anything)
+	suite.Empty(res.Header.Get("RateLimit-Limit"))
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, &RateLimitTestSuite{})
+}
@@ -0,0 +1,264 @@
+package router
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/logger"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// errBodyTooLarge is returned when reading more of a request's body than its route allows.
+var errBodyTooLarge = errors.New("request body too large")
+
+// limitBody is middleware that limits the size of request bodies to the route's max body bytes, if any.
+// Reading past the limit fails with errBodyTooLarge, so the body is rejected by whatever parses it.
+func (rf RouterFactory) limitBody(method string, path string) Middleware {
+	limit := rf.MaxBodyBytes
+	if routeLimit, ok := rf.RouteMaxBodyBytes[RouteKey(method, path)]; ok {
+		limit = routeLimit
+	}
+
+	return func(next httprouter.Handle) httprouter.Handle {
+		if limit <= 0 {
+			return next
+		}
+
+		return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+			if req.Body != nil {
+				body := &maxBytesReader{ReadCloser: req.Body, remaining: limit}
+
+				//fail without reading the body if it is known to be too large
+				if req.ContentLength > limit {
+					body.err = errBodyTooLarge
+				}
+				req.Body = body
+			}
+
+			next(w, req, params)
+		}
+	}
+}
+
+// maxBytesReader reads at most remaining bytes from the body, failing with errBodyTooLarge if there are more.
+// Once it fails, every following read fails with the same error.
+type maxBytesReader struct {
+	io.ReadCloser
+	remaining int64
+	err       error
+}
+
+func (r *maxBytesReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	//read one byte more than remains to find out if there are too many
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+
+	n, err := r.ReadCloser.Read(p)
+	if int64(n) > r.remaining {
+		n = int(r.remaining)
+		r.remaining = 0
+		r.err = errBodyTooLarge
+		return n, r.err
+	}
+
+	r.remaining -= int64(n)
+	r.err = err
+	return n, err
+}
+
+// logBodyError logs the error parsing a request's body. Errors caused by the client, such as invalid json, are only logged at info level.
+func logBodyError(req *http.Request, message string, err error) {
+	var rerr *requesterror.RequestError
+	if errors.As(err, &rerr) && rerr.Code != requesterror.CodeInternal {
+		logger.FromContext(req.Context()).With("error", err.Error()).Info(message)
+		return
+	}
+
+	logger.FromContext(req.Context()).Error(common.ChainError(message, err))
+}
+
+// parseMediaType returns the media type of the request's content type, or an empty string if it doesn't have one.
+func parseMediaType(req *http.Request) (string, error) {
+	contentType := req.Header.Get("Content-Type")
+	if contentType == "" {
+		return "", nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", requesterror.UnsupportedMediaTypeError("invalid content type")
+	}
+
+	return mediaType, nil
+}
+
+// parseJSONBody strictly parses the request's json body into v. Bodies without a content type are parsed as json.
+// The body is rejected if it has fields v doesn't, includes a field more than once, or contains anything after the json value.
+// Errors are request errors describing why the body was rejected, except for errors reading the body.
+func parseJSONBody(req *http.Request, v interface{}) error {
+	mediaType, err := parseMediaType(req)
+	if err != nil {
+		return err
+	}
+	if mediaType != "" && mediaType != "application/json" {
+		return requesterror.UnsupportedMediaTypeError("content type must be application/json")
+	}
+
+	return decodeJSON(req.Body, v, true)
+}
+
+// decodeJSON decodes the single json value read from r into v, rejecting duplicate fields and unknown fields if strict.
+func decodeJSON(r io.Reader, v interface{}, strict bool) error {
+	data, err := ioutil.ReadAll(r)
+	if errors.Is(err, errBodyTooLarge) {
+		return requesterror.PayloadTooLargeError("request body is too large")
+	}
+	if err != nil {
+		return common.ChainError("error reading request body", err)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return requesterror.InvalidRequestError("request body is empty")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+
+	err = decoder.Decode(v)
+	if err != nil {
+		return newJSONDecodeError(err)
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return requesterror.InvalidRequestError("request body must contain a single json value")
+	}
+
+	//encoding/json silently keeps the last of any duplicate fields, so they have to be found separately
+	field, err := findDuplicateField(json.NewDecoder(bytes.NewReader(data)), "")
+	if err != nil {
+		return common.ChainError("error checking for duplicate fields", err)
+	}
+	if field != "" {
+		return requesterror.InvalidFieldError(field, field+" was included more than once")
+	}
+
+	return nil
+}
+
+// newJSONDecodeError returns a request error describing why decoding failed, naming the invalid field if there is one.
+func newJSONDecodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return requesterror.InvalidRequestError(fmt.Sprintf("request body is not valid json, syntax error at offset %d", syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return requesterror.InvalidRequestError("request body is not valid json, it ended unexpectedly")
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return requesterror.InvalidRequestError("request body must be a json " + jsonTypeName(typeErr.Type))
+		}
+		return requesterror.InvalidFieldError(typeErr.Field, typeErr.Field+" must be a json "+jsonTypeName(typeErr.Type))
+	}
+
+	//encoding/json doesn't export an error type for unknown fields
+	if name := strings.TrimPrefix(err.Error(), "json: unknown field "); name != err.Error() {
+		field, unquoteErr := strconv.Unquote(name)
+		if unquoteErr == nil {
+			return requesterror.InvalidFieldError(field, field+" is not a known field")
+		}
+	}
+
+	return requesterror.InvalidRequestError("request body is not valid json")
+}
+
+// jsonTypeName returns the name of the json type the go type is decoded from.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Ptr:
+		return jsonTypeName(t.Elem())
+	default:
+		return "value"
+	}
+}
+
+// findDuplicateField reads the next json value from the decoder, returning the path of the first field included more than once in an object, or an empty string if there aren't any.
+// Field names are compared ignoring case, like encoding/json matches them to struct fields.
+func findDuplicateField(decoder *json.Decoder, path string) (string, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return "", err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return "", nil
+	}
+
+	switch delim {
+	case '{':
+		seen := make(map[string]bool)
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return "", err
+			}
+
+			name, _ := token.(string)
+			field := name
+			if path != "" {
+				field = path + "." + name
+			}
+
+			if seen[strings.ToLower(name)] {
+				return field, nil
+			}
+			seen[strings.ToLower(name)] = true
+
+			if duplicate, err := findDuplicateField(decoder, field); duplicate != "" || err != nil {
+				return duplicate, err
+			}
+		}
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			if duplicate, err := findDuplicateField(decoder, fmt.Sprintf("%s[%d]", path, i)); duplicate != "" || err != nil {
+				return duplicate, err
+			}
+		}
+	}
+
+	//read the closing delimiter
+	_, err = decoder.Token()
+	return "", err
+}
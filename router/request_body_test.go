@@ -0,0 +1,211 @@
+package router_test
+
+import (
+	"authserver/common"
+	"authserver/ratelimit"
+	"authserver/router"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestBodyTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *RequestBodyTestSuite) SetupTest() {
+	suite.RouterTestSuite.SetupTest()
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+}
+
+func (suite *RequestBodyTestSuite) createRequest(url string, contentType string, body string) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	suite.Require().NoError(err)
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
+
+func (suite *RequestBodyTestSuite) TestPostUser_WithInvalidBody_ReturnsBadRequestDescribingField() {
+	bodies := map[string]string{
+		`{"username": "user", "password": "password", "extra": true}`:       "extra is not a known field",
+		`{"username": "user", "password": "password", "Username": "other"}`: "Username was included more than once",
+		`{"username": "user", "password": "password"} {}`:                   "request body must contain a single json value",
+		`{"username": ["user"], "password": "password"}`:                    "username must be a json string",
+		`{"username": "user", "password": `:                                 "request body is not valid json",
+		``:                                                                  "request body is empty",
+	}
+
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	for body, expectedError := range bodies {
+		//act
+		res, err := http.DefaultClient.Do(suite.createRequest(server.URL+"/user", "application/json", body))
+		suite.Require().NoError(err)
+
+		//assert
+		common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, expectedError)
+	}
+
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RequestBodyTestSuite) TestPostUser_WithUnsupportedContentType_ReturnsUnsupportedMediaType() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := suite.createRequest(server.URL+"/user", "text/plain", `{"username": "user", "password": "password"}`)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusUnsupportedMediaType, "content type must be application/json")
+}
+
+func (suite *RequestBodyTestSuite) TestPostUser_WithBodyOverRouteLimit_ReturnsPayloadTooLarge() {
+	//arrange
+	suite.RouterFactory.MaxBodyBytes = 1 << 20
+	suite.RouterFactory.RouteMaxBodyBytes = map[string]int64{
+		router.RouteKey(http.MethodPost, "/user"): 32,
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req := suite.createRequest(server.URL+"/user", "application/json", `{"username": "user", "password": "`+strings.Repeat("a", 64)+`"}`)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusRequestEntityTooLarge, "request body is too large")
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RequestBodyTestSuite) TestPostUser_WithRetriesAndBodyOverRouteLimit_ReturnsPayloadTooLarge() {
+	//arrange
+	suite.RouterFactory.TransactionRetryPolicy.MaxRetries = 3
+	suite.RouterFactory.MaxBodyBytes = 1 << 20
+	suite.RouterFactory.RouteMaxBodyBytes = map[string]int64{
+		router.RouteKey(http.MethodPost, "/user"): 32,
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	req := suite.createRequest(server.URL+"/user", "application/json", `{"username": "user", "password": "`+strings.Repeat("a", 64)+`"}`)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusRequestEntityTooLarge, "request body is too large")
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RequestBodyTestSuite) TestPostToken_WithRetriesAndBodyOverLimit_ReturnsOAuthPayloadTooLarge() {
+	//arrange
+	suite.RouterFactory.TransactionRetryPolicy.MaxRetries = 3
+	suite.RouterFactory.MaxBodyBytes = 32
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	form := url.Values{
+		"grant_type": {"password"},
+		"username":   {"username"},
+		"password":   {strings.Repeat("a", 64)},
+	}
+	req := suite.createRequest(server.URL+"/token", "application/x-www-form-urlencoded", form.Encode())
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusRequestEntityTooLarge, "invalid_request", "request body is too large")
+}
+
+func (suite *RequestBodyTestSuite) TestPostUser_WithStreamedBodyOverLimit_ReturnsPayloadTooLarge() {
+	//arrange
+	suite.RouterFactory.MaxBodyBytes = 32
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	//without a content length the body is only rejected once too much of it is read
+	body := io.MultiReader(strings.NewReader(`{"username": "` + strings.Repeat("a", 64) + `"}`))
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/user", body)
+	suite.Require().NoError(err)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusRequestEntityTooLarge, "request body is too large")
+}
+
+func (suite *RequestBodyTestSuite) TestPostToken_WithBodyOverLimit_ReturnsOAuthPayloadTooLarge() {
+	//arrange
+	suite.RouterFactory.MaxBodyBytes = 32
+
+	//rate limiting reads the body before the handler, which must still reject it
+	suite.RouterFactory.RateLimiter = &ratelimit.Limiter{
+		Store: ratelimit.CreateMemoryStore(),
+		Routes: map[string]ratelimit.Rules{
+			router.RateLimitRouteToken: {Username: ratelimit.Limit{Requests: 10, Period: time.Minute}},
+		},
+	}
+
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	form := url.Values{
+		"grant_type": {"password"},
+		"username":   {"username"},
+		"password":   {strings.Repeat("a", 64)},
+	}
+	req := suite.createRequest(server.URL+"/token", "application/x-www-form-urlencoded", form.Encode())
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusRequestEntityTooLarge, "invalid_request", "request body is too large")
+}
+
+func (suite *RequestBodyTestSuite) TestPostToken_WithUnknownParameter_IgnoresParameter() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := suite.createRequest(server.URL+"/token", "application/json", `{"unknown": "value"}`)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_request", "missing grant_type parameter")
+}
+
+func TestRequestBodyTestSuite(t *testing.T) {
+	suite.Run(t, &RequestBodyTestSuite{})
+}
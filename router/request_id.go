@@ -0,0 +1,54 @@
+package router
+
+import (
+	"authserver/logger"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+const requestIDHeader = "X-Request-ID"
+const requestIDMaxLength = 128
+
+// handleRequestID assigns the request an id, echoes it in the response headers,
+// and attaches a logger that includes the id to the request's context.
+// The id is taken from the request headers if valid, otherwise a new one is generated.
+func (rf RouterFactory) handleRequestID(handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, params httprouter.Params) {
+		requestID := req.Header.Get(requestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+
+		ctx := logger.NewContext(req.Context(), rf.requestLogger(requestID))
+		handler(w, req.WithContext(ctx), params)
+	}
+}
+
+// requestLogger returns a logger that includes the request id in every entry.
+func (rf RouterFactory) requestLogger(requestID string) logger.Logger {
+	l := rf.Logger
+	if l == nil {
+		l = logger.Default()
+	}
+
+	return l.With("request_id", requestID)
+}
+
+// isValidRequestID checks the id is not empty, not too long, and only contains visible ascii characters so it is safe to log.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > requestIDMaxLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,112 @@
+package router_test
+
+import (
+	"authserver/common"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestIDTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *RequestIDTestSuite) TestRequest_WithValidRequestID_EchoesRequestID() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/healthz", "", nil)
+	req.Header.Set("X-Request-ID", "request-id")
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal("request-id", res.Header.Get("X-Request-ID"))
+}
+
+func (suite *RequestIDTestSuite) TestRequest_WithMissingOrInvalidRequestID_GeneratesRequestID() {
+	var requestIDs = []string{
+		"",
+		"contains spaces",
+		"contains\ttabs",
+		strings.Repeat("a", 129),
+	}
+
+	for _, requestID := range requestIDs {
+		suite.Run(requestID, func() {
+			//arrange
+			server := httptest.NewServer(suite.Router)
+			defer server.Close()
+
+			req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/healthz", "", nil)
+			req.Header.Set("X-Request-ID", requestID)
+
+			//act
+			res, err := http.DefaultClient.Do(req)
+			suite.Require().NoError(err)
+
+			//assert
+			_, err = uuid.Parse(res.Header.Get("X-Request-ID"))
+			suite.NoError(err)
+		})
+	}
+}
+
+func (suite *RequestIDTestSuite) TestRequest_WithErrorCreatingTransaction_LogsErrorWithRequestID() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", nil)
+	req.Header.Set("X-Request-ID", "request-id")
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New("CreateTransaction mock error"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+	common.AssertContainsSubstrings(&suite.Suite, suite.LogBuffer.String(),
+		`"level":"error"`,
+		`CreateTransaction mock error`,
+		`"request_id":"request-id"`,
+	)
+}
+
+func (suite *RequestIDTestSuite) TestRequest_WithPasswordInBody_DoesNotLogPassword() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	password := "password-that-must-not-be-logged"
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", map[string]interface{}{
+		"username": 0,
+		"password": password,
+	})
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "username must be a json string")
+	suite.NotEmpty(suite.LogBuffer.String())
+	suite.NotContains(suite.LogBuffer.String(), password)
+}
+
+func TestRequestIDTestSuite(t *testing.T) {
+	suite.Run(t, &RequestIDTestSuite{})
+}
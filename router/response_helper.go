@@ -2,27 +2,22 @@ package router
 
 import (
 	"authserver/common"
+	requesterror "authserver/common/request_error"
 	"encoding/json"
 	"errors"
-	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
-func parseJSONBody(r io.Reader, v interface{}) error {
-	decoder := json.NewDecoder(r)
-	err := decoder.Decode(v)
-	if err != nil {
-		log.Println(err)
-		return errors.New("invalid request body")
-	}
-
-	return nil
-}
-
 func sendResponse(w http.ResponseWriter, status int, res interface{}) {
 	//set the header
-	w.Header().Set("Content-Type", "application/json")
+	contentType := "application/json"
+	if _, ok := res.(common.ProblemDetails); ok {
+		contentType = common.ProblemDetailsContentType
+	}
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(status)
 
 	//write the response
@@ -33,13 +28,85 @@ func sendResponse(w http.ResponseWriter, status int, res interface{}) {
 	}
 }
 
-func sendErrorResponse(w http.ResponseWriter, status int, messsage string) {
-	sendResponse(w, status, common.ErrorResponse{
+// newErrorResponse translates the error into the response returned to the client.
+// Request errors are responded to with the status of their code, as an oauth error response if they have an oauth error name.
+// Any other error is responded to as an internal error, so its details are never returned to the client.
+func newErrorResponse(err error) (int, interface{}) {
+	var rerr *requesterror.RequestError
+	if !errors.As(err, &rerr) || rerr.Code == requesterror.CodeInternal {
+		return common.NewInternalServerErrorResponse()
+	}
+
+	if rerr.OAuthError != "" {
+		return rerr.Status(), common.OAuthErrorResponse{
+			Error:            rerr.OAuthError,
+			ErrorDescription: rerr.Message,
+		}
+	}
+
+	return rerr.Status(), common.ErrorResponse{
 		Success: false,
-		Error:   messsage,
-	})
+		Code:    rerr.Code,
+		Error:   rerr.Message,
+		Details: rerr.Fields,
+		Scope:   rerr.Scope,
+	}
 }
 
-func sendInternalErrorResponse(w http.ResponseWriter) {
-	sendErrorResponse(w, http.StatusInternalServerError, "an internal error occurred")
+// sendNegotiatedResponse sends the response. Error responses are sent as problem details if the client accepts them.
+// Oauth error responses are always sent in the format defined by the oauth spec.
+func sendNegotiatedResponse(w http.ResponseWriter, req *http.Request, status int, res interface{}) {
+	switch r := res.(type) {
+	case common.ErrorResponse:
+		w.Header().Add("Vary", "Accept")
+		if acceptsProblemDetails(req) {
+			res = common.NewProblemDetails(status, r, req.URL.Path, w.Header().Get(requestIDHeader))
+		}
+	case common.OAuthErrorResponse:
+		//the oauth spec requires clients that fail to authenticate to be told how to authenticate
+		if status == http.StatusUnauthorized {
+			w.Header().Set("WWW-Authenticate", `Basic realm="`+realm+`"`)
+		}
+	}
+
+	sendResponse(w, status, res)
+}
+
+func sendErrorResponse(w http.ResponseWriter, req *http.Request, err error) {
+	status, res := newErrorResponse(err)
+	sendNegotiatedResponse(w, req, status, res)
+}
+
+// acceptsProblemDetails returns whether the request's accept header explicitly accepts problem details.
+// Wildcards are not enough, so clients that don't know about problem details keep getting the default error responses.
+func acceptsProblemDetails(req *http.Request) bool {
+	for _, header := range req.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(header, ",") {
+			params := strings.Split(mediaRange, ";")
+			if !strings.EqualFold(strings.TrimSpace(params[0]), common.ProblemDetailsContentType) {
+				continue
+			}
+
+			if !hasZeroQuality(params[1:]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasZeroQuality returns whether the media range parameters include a quality of zero, which means the media type is not acceptable.
+func hasZeroQuality(params []string) bool {
+	for _, param := range params {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) != 2 || !strings.EqualFold(kv[0], "q") {
+			continue
+		}
+
+		q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		return err == nil && q == 0
+	}
+
+	return false
 }
@@ -0,0 +1,106 @@
+package router_test
+
+import (
+	"authserver/common"
+	requesterror "authserver/common/request_error"
+	"authserver/router"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResponseHelperTestSuite struct {
+	RouterTestSuite
+}
+
+func (suite *ResponseHelperTestSuite) createPostUserRequest(url string, accept string) *http.Request {
+	body := router.PostUserBody{
+		Username: "",
+		Password: "password",
+	}
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, url+"/user", "", body)
+	req.Header.Set("X-Request-ID", "request-id")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InvalidFieldError("username", "username cannot be empty"))
+
+	return req
+}
+
+func (suite *ResponseHelperTestSuite) TestErrorResponse_WhereProblemDetailsAreAccepted_ReturnsProblemDetails() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := suite.createPostUserRequest(server.URL, "application/json, application/problem+json")
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	var problem common.ProblemDetails
+	status := common.ParseResponse(&suite.Suite, res, &problem)
+
+	suite.Equal(http.StatusBadRequest, status)
+	suite.Equal(common.ProblemDetailsContentType, res.Header.Get("Content-Type"))
+	suite.Equal(common.ProblemDetails{
+		Type:      common.ProblemDetailsTypePrefix + requesterror.CodeInvalidRequest,
+		Title:     "Bad Request",
+		Status:    http.StatusBadRequest,
+		Detail:    "username cannot be empty",
+		Instance:  "/user",
+		Code:      requesterror.CodeInvalidRequest,
+		Errors:    []requesterror.FieldError{{Field: "username", Message: "username cannot be empty"}},
+		RequestID: "request-id",
+	}, problem)
+}
+
+func (suite *ResponseHelperTestSuite) TestErrorResponse_WithoutProblemDetailsAccepted_ReturnsErrorResponse() {
+	accepts := []string{"", "*/*", "application/json", "application/problem+json;q=0"}
+
+	for _, accept := range accepts {
+		//arrange
+		server := httptest.NewServer(suite.Router)
+		req := suite.createPostUserRequest(server.URL, accept)
+
+		//act
+		res, err := http.DefaultClient.Do(req)
+		suite.Require().NoError(err)
+
+		//assert
+		suite.Equal("application/json", res.Header.Get("Content-Type"), accept)
+		common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "username cannot be empty")
+
+		server.Close()
+	}
+}
+
+func (suite *ResponseHelperTestSuite) TestOAuthErrorResponse_WhereProblemDetailsAreAccepted_ReturnsOAuthErrorResponse() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", map[string]string{})
+	req.Header.Set("Accept", "application/problem+json")
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.Equal("application/json", res.Header.Get("Content-Type"))
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_request", "grant_type")
+}
+
+func TestResponseHelperTestSuite(t *testing.T) {
+	suite.Run(t, &ResponseHelperTestSuite{})
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy determines how many times a request is retried when its transaction fails because of contention with other transactions,
+// and how long to wait before each retry.
+type RetryPolicy struct {
+	// MaxRetries is the max number of times a request is retried. Zero disables retries.
+	MaxRetries int
+
+	// BaseDelay is the max delay before the first retry. It doubles with each retry.
+	BaseDelay time.Duration
+
+	// MaxDelay is the max delay before any retry.
+	MaxDelay time.Duration
+}
+
+// Delay returns how long to wait before the retry with the given index, starting at zero.
+// The delay is random, up to the base delay doubled for each previous retry, so competing requests don't retry in lockstep.
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < retry && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// wait waits before the retry with the given index.
+// Returns false if the context is done before the retry is due, in which case the request should not be retried.
+func (p RetryPolicy) wait(ctx context.Context, retry int) bool {
+	timer := time.NewTimer(p.Delay(retry))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,56 @@
+package router_test
+
+import (
+	"authserver/router"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RetryPolicyTestSuite struct {
+	suite.Suite
+}
+
+func (suite *RetryPolicyTestSuite) TestDelay_IsAtMostBaseDelayDoubledForEachRetry() {
+	//arrange
+	policy := router.RetryPolicy{
+		BaseDelay: 10 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	for retry := 0; retry < 3; retry++ {
+		//act
+		delay := policy.Delay(retry)
+
+		//assert
+		suite.GreaterOrEqual(int64(delay), int64(0))
+		suite.LessOrEqual(int64(delay), int64(policy.BaseDelay<<retry))
+	}
+}
+
+func (suite *RetryPolicyTestSuite) TestDelay_IsAtMostMaxDelay() {
+	//arrange
+	policy := router.RetryPolicy{
+		BaseDelay: 10 * time.Millisecond,
+		MaxDelay:  15 * time.Millisecond,
+	}
+
+	//act
+	delay := policy.Delay(10)
+
+	//assert
+	suite.LessOrEqual(int64(delay), int64(policy.MaxDelay))
+}
+
+func (suite *RetryPolicyTestSuite) TestDelay_WithNoDelays_ReturnsZero() {
+	//act
+	delay := router.RetryPolicy{}.Delay(3)
+
+	//assert
+	suite.Zero(delay)
+}
+
+func TestRetryPolicyTestSuite(t *testing.T) {
+	suite.Run(t, &RetryPolicyTestSuite{})
+}
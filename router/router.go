@@ -3,6 +3,11 @@ package router
 import (
 	"authserver/controllers"
 	"authserver/database"
+	"authserver/health"
+	"authserver/logger"
+	"authserver/metrics"
+	"authserver/ratelimit"
+	"net/http"
 
 	"github.com/julienschmidt/httprouter"
 )
@@ -15,21 +20,82 @@ type RouterFactory struct {
 	Controllers        controllers.Controllers
 	Authenticator      Authenticator
 	TransactionFactory database.TransactionFactory
+
+	// LivenessChecker is the checker used to determine if the server is alive.
+	LivenessChecker health.Checker
+
+	// ReadinessChecker is the checker used to determine if the server is ready to handle requests.
+	ReadinessChecker health.Checker
+
+	// MetricsRecorder is used to record metrics about each request. Optional.
+	MetricsRecorder metrics.Recorder
+
+	// MetricsHandler is the handler that serves the recorded metrics. The metrics route is only added if it is set.
+	MetricsHandler http.Handler
+
+	// Logger is the base logger for request scoped loggers. The default logger is used if not set.
+	Logger logger.Logger
+
+	// TransactionRetryPolicy determines how requests to retryable routes are retried when their transaction fails because of contention. Retries are disabled if not set.
+	TransactionRetryPolicy RetryPolicy
+
+	// RateLimiter limits the rate of requests to the authentication routes. Rate limiting is disabled if not set.
+	RateLimiter *ratelimit.Limiter
+
+	// Middleware is applied to every route, after the built in request id, instrumentation, panic recovery, body limit and cors middleware. Optional.
+	Middleware []Middleware
+
+	// RouteMiddleware is applied to the routes it is keyed by, using their RouteKey, after any authentication. Optional.
+	RouteMiddleware map[string][]Middleware
+
+	// MaxBodyBytes is the max size of request bodies. Bodies are unlimited if zero.
+	MaxBodyBytes int64
+
+	// RouteMaxBodyBytes overrides MaxBodyBytes for the routes it is keyed by, using their RouteKey. Optional.
+	RouteMaxBodyBytes map[string]int64
+
+	// CORS is the policy for cross origin requests from browsers, including preflight requests to every route. CORS is disabled if not set.
+	CORS *CORSPolicy
 }
 
-// CreateRouter creates a new httprouter with the endpoints and panic handler configured.
+// CreateRouter creates a new httprouter with the endpoints configured, each wrapped in the middleware pipeline.
 func (rf RouterFactory) CreateRouter() *httprouter.Router {
 	r := httprouter.New()
-	r.PanicHandler = panicHandler
 
 	//user routes
-	r.POST("/user", rf.createHandler(rf.postUser, false))
-	r.DELETE("/user", rf.createHandler(rf.deleteUser, true))
-	r.PATCH("/user/password", rf.createHandler(rf.patchUserPassword, true))
+	rf.handle(r, http.MethodPost, "/user", rf.createHandler(rf.postUser), rf.limitRate(RateLimitRouteCreateUser))
+	rf.handle(r, http.MethodGet, "/user", rf.createRetryableHandler(rf.getUser), rf.authenticate)
+	rf.handle(r, http.MethodPatch, "/user", rf.createRetryableHandler(rf.patchUser), rf.authenticate)
+	rf.handle(r, http.MethodDelete, "/user", rf.createRetryableHandler(rf.deleteUser), rf.authenticate)
+	rf.handle(r, http.MethodPatch, "/user/password", rf.createHandler(rf.patchUserPassword), rf.authenticate)
 
 	//token routes
-	r.POST("/token", rf.createHandler(rf.postToken, false))
-	r.DELETE("/token", rf.createHandler(rf.deleteToken, true))
+	rf.handle(r, http.MethodPost, "/token", rf.createHandler(rf.postToken), rf.handleOAuthEndpoint, rf.limitRate(RateLimitRouteToken))
+	rf.handle(r, http.MethodDelete, "/token", rf.createRetryableHandler(rf.deleteToken), rf.authenticate)
+
+	//admin routes
+	rf.handle(r, http.MethodGet, "/admin/users", rf.createRetryableHandler(rf.getAdminUsers), rf.authenticate, rf.requireAdmin)
+	rf.handle(r, http.MethodGet, "/admin/users/:id", rf.createRetryableHandler(rf.getAdminUser), rf.authenticate, rf.requireAdmin)
+	rf.handle(r, http.MethodPatch, "/admin/users/:id", rf.createRetryableHandler(rf.patchAdminUser), rf.authenticate, rf.requireAdmin)
+	rf.handle(r, http.MethodDelete, "/admin/users/:id", rf.createRetryableHandler(rf.deleteAdminUser), rf.authenticate, rf.requireAdmin)
+	rf.handle(r, http.MethodPost, "/admin/users/:id/password", rf.createHandler(rf.postAdminUserPassword), rf.authenticate, rf.requireAdmin)
+	rf.handle(r, http.MethodGet, "/admin/audit-events", rf.createRetryableHandler(rf.getAuditEvents), rf.authenticate, rf.requireAdmin)
+	rf.handle(r, http.MethodGet, "/admin/webhook-events/dead-letter", rf.createRetryableHandler(rf.getDeadLetteredWebhookEvents), rf.authenticate, rf.requireAdmin)
+
+	//health routes
+	rf.handle(r, http.MethodGet, "/healthz", rf.getHealthz)
+	rf.handle(r, http.MethodGet, "/readyz", rf.getReadyz)
+
+	//metrics routes
+	if rf.MetricsHandler != nil {
+		r.Handler(http.MethodGet, "/metrics", rf.MetricsHandler)
+	}
+
+	//preflight requests to every route
+	preflight := Chain(rf.handleRequestID, rf.recoverPanic)(rf.handlePreflight)
+	r.GlobalOPTIONS = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		preflight(w, req, nil)
+	})
 
 	return r
 }
@@ -3,10 +3,16 @@ package router_test
 import (
 	controllermocks "authserver/controllers/mocks"
 	databasemocks "authserver/database/mocks"
+	"authserver/health"
+	healthmocks "authserver/health/mocks"
+	"authserver/logger"
+	metricsmocks "authserver/metrics/mocks"
 	"authserver/router"
 	"authserver/router/mocks"
+	"bytes"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -16,6 +22,10 @@ type RouterTestSuite struct {
 	AuthenticatorMock      mocks.Authenticator
 	TransactionFactoryMock databasemocks.TransactionFactory
 	TransactionMock        databasemocks.Transaction
+	ReadinessCheckMock     healthmocks.Check
+	MetricsRecorderMock    metricsmocks.Recorder
+	LogBuffer              *bytes.Buffer
+	RouterFactory          router.RouterFactory
 	Router                 *httprouter.Router
 }
 
@@ -24,13 +34,27 @@ func (suite *RouterTestSuite) SetupTest() {
 	suite.AuthenticatorMock = mocks.Authenticator{}
 	suite.TransactionFactoryMock = databasemocks.TransactionFactory{}
 	suite.TransactionMock = databasemocks.Transaction{}
+	suite.ReadinessCheckMock = healthmocks.Check{}
+	suite.MetricsRecorderMock = metricsmocks.Recorder{}
+	suite.LogBuffer = &bytes.Buffer{}
+
+	suite.MetricsRecorderMock.On("ObserveRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.MetricsRecorderMock.On("ObserveTokenGrant", mock.Anything, mock.Anything)
 
 	suite.TransactionMock.On("RollbackTransaction")
+	suite.TransactionMock.On("RetryableError").Return(nil)
 
-	rf := router.RouterFactory{
+	suite.RouterFactory = router.RouterFactory{
 		Controllers:        &suite.ControllersMock,
 		Authenticator:      &suite.AuthenticatorMock,
 		TransactionFactory: &suite.TransactionFactoryMock,
+		ReadinessChecker: health.Checker{
+			Checks: map[string]health.Check{
+				"check": &suite.ReadinessCheckMock,
+			},
+		},
+		MetricsRecorder: &suite.MetricsRecorderMock,
+		Logger:          logger.CreateStandardLogger(suite.LogBuffer, logger.FormatJSON, logger.LevelDebug),
 	}
-	suite.Router = rf.CreateRouter()
+	suite.Router = suite.RouterFactory.CreateRouter()
 }
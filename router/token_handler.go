@@ -4,8 +4,8 @@ import (
 	"authserver/common"
 	requesterror "authserver/common/request_error"
 	"authserver/database"
+	"authserver/logger"
 	"authserver/models"
-	"log"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -31,69 +31,101 @@ func (h RouterFactory) postToken(req *http.Request, _ httprouter.Params, _ *mode
 	var body PostTokenBody
 
 	//parse the body
-	err := parseJSONBody(req.Body, &body)
+	err := parseOAuthBody(req, &body)
 	if err != nil {
-		log.Println(common.ChainError("error parsing PostToken request body", err))
-		return common.NewOAuthErrorResponse("invalid_request", "invalid json body")
+		logBodyError(req, "error parsing PostToken request body", err)
+		return newErrorResponse(newOAuthRequestError(err))
 	}
 
 	//validate grant type is present
 	if body.GrantType == "" {
-		return common.NewOAuthErrorResponse("invalid_request", "missing grant_type parameter")
+		return newErrorResponse(requesterror.OAuthClientError("invalid_request", "missing grant_type parameter"))
 	}
 
 	//choose the workflow based on the grant type
 	switch body.GrantType {
 	case "password":
-		return h.handlePasswordGrant(body.PostTokenPasswordGrantBody, tx)
+		status, res := h.handlePasswordGrant(req, body.PostTokenPasswordGrantBody, tx)
+
+		//the token is only issued once its transaction is committed
+		outcome := getTokenGrantOutcome(res)
+		if outcome == "success" {
+			afterCommit(req.Context(), func() {
+				h.observeTokenGrant(body.GrantType, outcome)
+			})
+		} else {
+			h.observeTokenGrant(body.GrantType, outcome)
+		}
+		return status, res
 	default:
-		return common.NewOAuthErrorResponse("unsupported_grant_type", "")
+		h.observeTokenGrant("unsupported", "unsupported_grant_type")
+		return newErrorResponse(requesterror.OAuthClientError("unsupported_grant_type", ""))
 	}
 }
 
-func (h RouterFactory) handlePasswordGrant(body PostTokenPasswordGrantBody, tx database.Transaction) (int, interface{}) {
+// getTokenGrantOutcome determines the outcome of a token grant from its response.
+func getTokenGrantOutcome(res interface{}) string {
+	switch r := res.(type) {
+	case common.AccessTokenResponse:
+		return "success"
+	case common.OAuthErrorResponse:
+		return r.Error
+	default:
+		return "internal_error"
+	}
+}
+
+// observeTokenGrant records the token grant if the router has a metrics recorder.
+func (h RouterFactory) observeTokenGrant(grantType string, outcome string) {
+	if h.MetricsRecorder != nil {
+		h.MetricsRecorder.ObserveTokenGrant(grantType, outcome)
+	}
+}
+
+func (h RouterFactory) handlePasswordGrant(req *http.Request, body PostTokenPasswordGrantBody, tx database.Transaction) (int, interface{}) {
+	//resolve the client id from the client credentials if there are any
+	var err error
+	body.ClientID, err = resolveClientID(req, body.ClientID)
+	if err != nil {
+		return newErrorResponse(err)
+	}
+
 	//validate parameters
 	if body.Username == "" {
-		return common.NewOAuthErrorResponse("invalid_request", "missing username parameter")
+		return newErrorResponse(requesterror.OAuthClientError("invalid_request", "missing username parameter"))
 	}
 	if body.Password == "" {
-		return common.NewOAuthErrorResponse("invalid_request", "missing password parameter")
+		return newErrorResponse(requesterror.OAuthClientError("invalid_request", "missing password parameter"))
 	}
 	if body.ClientID == "" {
-		return common.NewOAuthErrorResponse("invalid_request", "missing client_id parameter")
+		return newErrorResponse(requesterror.OAuthClientError("invalid_request", "missing client_id parameter"))
 	}
 	if body.Scope == "" {
-		return common.NewOAuthErrorResponse("invalid_request", "missing scope parameter")
+		return newErrorResponse(requesterror.OAuthClientError("invalid_request", "missing scope parameter"))
 	}
 
 	//parse the client id
 	clientID, err := uuid.Parse(body.ClientID)
 	if err != nil {
-		log.Println(common.ChainError("error parsing client id", err))
-		return common.NewOAuthErrorResponse("invalid_client", "client_id was in invalid format")
+		logger.FromContext(req.Context()).With("error", err.Error()).Info("error parsing client id")
+		return newErrorResponse(requesterror.OAuthClientError(requesterror.OAuthErrorInvalidClient, "client_id was in invalid format"))
 	}
 
 	//create the token
-	token, rerr := h.Controllers.CreateTokenFromPassword(tx, body.Username, body.Password, clientID, body.Scope)
-	if rerr.Type == requesterror.ErrorTypeClient {
-		return common.NewOAuthErrorResponse(rerr.ErrorName, rerr.Error())
-	}
-	if rerr.Type == requesterror.ErrorTypeInternal {
-		return common.NewInternalServerErrorResponse()
+	token, rerr := h.Controllers.CreateTokenFromPassword(req.Context(), tx, body.Username, body.Password, clientID, getClientCertificate(req), body.Scope)
+	if rerr != nil {
+		return newErrorResponse(rerr)
 	}
 
 	return common.NewAccessTokenResponse(token.ID.String())
 }
 
 // DeleteToken handles DELETE requests to "/token"
-func (h RouterFactory) deleteToken(_ *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+func (h RouterFactory) deleteToken(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
 	//delete the token
-	rerr := h.Controllers.DeleteToken(tx, token)
-	if rerr.Type == requesterror.ErrorTypeClient {
-		return common.NewBadRequestResponse(rerr.Error())
-	}
-	if rerr.Type == requesterror.ErrorTypeInternal {
-		return common.NewInternalServerErrorResponse()
+	rerr := h.Controllers.DeleteToken(req.Context(), tx, token)
+	if rerr != nil {
+		return newErrorResponse(rerr)
 	}
 
 	return common.NewSuccessResponse()
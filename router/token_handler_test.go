@@ -5,9 +5,12 @@ import (
 	requesterror "authserver/common/request_error"
 	"authserver/models"
 	"authserver/router"
+	"crypto/x509"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -26,7 +29,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithErrorCreatingTransaction_R
 
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", nil)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(nil, errors.New(""))
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -43,7 +46,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithInvalidJSONBody_ReturnsInv
 
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", "invalid")
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -51,7 +54,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithInvalidJSONBody_ReturnsInv
 
 	//assert
 	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
-	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_request", "invalid json body")
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_request", "request body must be a json object")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_WithMissingGrantType_ReturnsInvalidRequest() {
@@ -62,7 +65,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithMissingGrantType_ReturnsIn
 	body := router.PostTokenBody{}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -83,7 +86,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithUnsupportedGrantType_Retur
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -92,6 +95,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithUnsupportedGrantType_Retur
 	//assert
 	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
 	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "unsupported_grant_type", "")
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveTokenGrant", "unsupported", "unsupported_grant_type")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithMissingParameters_ReturnsInvalidRequest() {
@@ -109,7 +113,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithMissingParam
 		}
 		req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-		suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+		suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 		//act
 		res, err := http.DefaultClient.Do(req)
@@ -169,7 +173,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithErrorParsing
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -177,7 +181,8 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithErrorParsing
 
 	//assert
 	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
-	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_client", "client_id", "invalid format")
+	suite.Equal(`Basic realm="authserver"`, res.Header.Get("WWW-Authenticate"))
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusUnauthorized, "invalid_client", "client_id", "invalid format")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithClientErrorCreatingTokenFromPassword_ReturnsInvalidClient() {
@@ -196,11 +201,11 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithClientErrorC
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	errorName := "error_name"
 	message := "create token error"
-	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, requesterror.OAuthClientError(errorName, message))
 
 	//act
@@ -210,6 +215,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithClientErrorC
 	//assert
 	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
 	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, errorName, message)
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveTokenGrant", "password", errorName)
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithInternalErrorCreatingTokenFromPassword_ReturnsInternalServerError() {
@@ -228,9 +234,9 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithInternalErro
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(nil, requesterror.OAuthInternalError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, requesterror.InternalError())
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -260,9 +266,9 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithErrorCommitingTransaction_
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(token, requesterror.OAuthNoError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(token, nil)
 	suite.TransactionMock.On("CommitTransaction").Return(errors.New(""))
 
 	//act
@@ -271,6 +277,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_WithErrorCommitingTransaction_
 
 	//assert
 	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+	suite.MetricsRecorderMock.AssertNotCalled(suite.T(), "ObserveTokenGrant", "password", "success")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithValidRequest_ReturnsAccessToken() {
@@ -292,9 +299,9 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithValidRequest
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(token, requesterror.OAuthNoError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(token, nil)
 	suite.TransactionMock.On("CommitTransaction").Return(nil)
 
 	//act
@@ -303,11 +310,173 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithValidRequest
 
 	//assert
 	suite.AuthenticatorMock.AssertNotCalled(suite.T(), "Authenticate", mock.Anything)
-	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction")
-	suite.ControllersMock.AssertCalled(suite.T(), "CreateTokenFromPassword", &suite.TransactionMock, body.Username, body.Password, clientID, body.Scope)
+	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction", mock.Anything)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateTokenFromPassword", mock.Anything, &suite.TransactionMock, body.Username, body.Password, clientID, (*x509.Certificate)(nil), body.Scope)
 	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
 	suite.TransactionMock.AssertNotCalled(suite.T(), "RollbackTransaction")
 	common.AssertAccessTokenResponse(&suite.Suite, res, token.ID.String())
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveTokenGrant", "password", "success")
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveRequest", http.MethodPost, "/token", http.StatusOK, mock.Anything)
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithNoMetricsRecorder_ReturnsAccessToken() {
+	//arrange
+	suite.RouterFactory.MetricsRecorder = nil
+	server := httptest.NewServer(suite.RouterFactory.CreateRouter())
+	defer server.Close()
+
+	token := models.CreateNewAccessToken(nil, nil, nil)
+
+	body := router.PostTokenBody{
+		GrantType: "password",
+		PostTokenPasswordGrantBody: router.PostTokenPasswordGrantBody{
+			Username: "username",
+			Password: "password",
+			ClientID: uuid.New().String(),
+			Scope:    "scope",
+		},
+	}
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(token, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertAccessTokenResponse(&suite.Suite, res, token.ID.String())
+}
+
+func (suite *TokenHandlerTestSuite) createFormTokenRequest(url string, form url.Values) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, url+"/token", strings.NewReader(form.Encode()))
+	suite.Require().NoError(err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithFormBodyAndBasicClientCredentials_ReturnsAccessToken() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	clientID := uuid.New()
+	token := models.CreateNewAccessToken(nil, nil, nil)
+
+	req := suite.createFormTokenRequest(server.URL, url.Values{
+		"grant_type": {"password"},
+		"username":   {"username"},
+		"password":   {"password"},
+		"scope":      {"scope"},
+	})
+	req.SetBasicAuth(clientID.String(), "")
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(token, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateTokenFromPassword", mock.Anything, &suite.TransactionMock, "username", "password", clientID, (*x509.Certificate)(nil), "scope")
+	suite.Equal("no-store", res.Header.Get("Cache-Control"))
+	suite.Equal("no-cache", res.Header.Get("Pragma"))
+	common.AssertAccessTokenResponse(&suite.Suite, res, token.ID.String())
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithBasicClientSecret_ReturnsUnauthorizedInvalidClient() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := suite.createFormTokenRequest(server.URL, url.Values{
+		"grant_type": {"password"},
+		"username":   {"username"},
+		"password":   {"password"},
+		"scope":      {"scope"},
+	})
+	req.SetBasicAuth(uuid.New().String(), "secret")
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNotCalled(suite.T(), "CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.Equal(`Basic realm="authserver"`, res.Header.Get("WWW-Authenticate"))
+	suite.Equal("no-store", res.Header.Get("Cache-Control"))
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusUnauthorized, "invalid_client", "client credentials")
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithClientIDNotMatchingBasicClientCredentials_ReturnsInvalidRequest() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := suite.createFormTokenRequest(server.URL, url.Values{
+		"grant_type": {"password"},
+		"username":   {"username"},
+		"password":   {"password"},
+		"client_id":  {uuid.New().String()},
+		"scope":      {"scope"},
+	})
+	req.SetBasicAuth(uuid.New().String(), "")
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_request", "client_id", "client credentials")
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_WithRepeatedFormParameter_ReturnsInvalidRequest() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := suite.createFormTokenRequest(server.URL, url.Values{
+		"grant_type": {"password", "password"},
+	})
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid_request", "grant_type parameter was included more than once")
+}
+
+func (suite *TokenHandlerTestSuite) TestPostToken_WithUnsupportedContentType_ReturnsUnsupportedMediaType() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/token", strings.NewReader("grant_type=password"))
+	suite.Require().NoError(err)
+	req.Header.Set("Content-Type", "text/plain")
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	common.AssertOAuthErrorResponse(&suite.Suite, res, http.StatusUnsupportedMediaType, "invalid_request", "content type must be")
 }
 
 func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithPanicTriggered_ReturnsInternalServerError() {
@@ -326,8 +495,8 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithPanicTrigger
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/token", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateTokenFromPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
 		panic("test panic handler")
 	})
 
@@ -337,6 +506,7 @@ func (suite *TokenHandlerTestSuite) TestPostToken_PasswordGrant_WithPanicTrigger
 
 	//assert
 	common.AssertInternalServerErrorResponse(&suite.Suite, res)
+	suite.MetricsRecorderMock.AssertCalled(suite.T(), "ObserveRequest", http.MethodPost, "/token", http.StatusInternalServerError, mock.Anything)
 }
 
 func (suite *TokenHandlerTestSuite) TestDeleteToken_WithClientErrorAuthenticatingUser_ReturnsUnauthorized() {
@@ -347,7 +517,7 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithClientErrorAuthenticatin
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/token", "", nil)
 
 	message := "authenticate error"
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.ClientError(message))
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.UnauthorizedError(message))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -382,7 +552,7 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithErrorCreatingTransaction
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/token", "", nil)
 
 	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.InternalError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(nil, errors.New(""))
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -401,9 +571,9 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithClientErrorDeletingToken
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/token", "", nil)
 
 	message := "delete token error"
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything).Return(requesterror.ClientError(message))
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InvalidRequestError(message))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -422,9 +592,9 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithInternalErrorDeletingTok
 	token := &models.AccessToken{}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/token", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything).Return(requesterror.InternalError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InternalError())
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -443,9 +613,9 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithErrorCommitingTransactio
 	token := &models.AccessToken{}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/token", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything).Return(requesterror.NoError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	suite.TransactionMock.On("CommitTransaction").Return(errors.New(""))
 
 	//act
@@ -464,9 +634,9 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithValidRequest_ReturnsSucc
 	token := &models.AccessToken{}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/token", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything).Return(requesterror.NoError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	suite.TransactionMock.On("CommitTransaction").Return(nil)
 
 	//act
@@ -475,8 +645,8 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithValidRequest_ReturnsSucc
 
 	//assert
 	suite.AuthenticatorMock.AssertCalled(suite.T(), "Authenticate", mock.Anything)
-	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction")
-	suite.ControllersMock.AssertCalled(suite.T(), "DeleteToken", &suite.TransactionMock, token)
+	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction", mock.Anything)
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteToken", mock.Anything, &suite.TransactionMock, token)
 	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
 	suite.TransactionMock.AssertNotCalled(suite.T(), "RollbackTransaction")
 	common.AssertSuccessResponse(&suite.Suite, res)
@@ -490,9 +660,9 @@ func (suite *TokenHandlerTestSuite) TestDeleteToken_WithPanicTriggered_ReturnsIn
 	token := &models.AccessToken{}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/token", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteToken", mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
 		panic("test panic handler")
 	})
 
@@ -1,11 +1,9 @@
 package router
 
 import (
-	"log"
 	"net/http"
 
 	"authserver/common"
-	requesterror "authserver/common/request_error"
 	"authserver/database"
 	"authserver/models"
 
@@ -22,33 +20,81 @@ type PostUserBody struct {
 func (h RouterFactory) postUser(req *http.Request, _ httprouter.Params, _ *models.AccessToken, tx database.Transaction) (int, interface{}) {
 	//parse the body
 	var body PostUserBody
-	err := parseJSONBody(req.Body, &body)
+	err := parseJSONBody(req, &body)
 	if err != nil {
-		log.Println(common.ChainError("error parsing PostUser request body", err))
-		return common.NewBadRequestResponse("invalid json body")
+		logBodyError(req, "error parsing PostUser request body", err)
+		return newErrorResponse(err)
 	}
 
 	//create the user
-	_, rerr := h.Controllers.CreateUser(tx, body.Username, body.Password)
-	if rerr.Type == requesterror.ErrorTypeClient {
-		return common.NewBadRequestResponse(rerr.Error())
-	}
-	if rerr.Type == requesterror.ErrorTypeInternal {
-		return common.NewInternalServerErrorResponse()
+	_, rerr := h.Controllers.CreateUser(req.Context(), tx, body.Username, body.Password)
+	if rerr != nil {
+		return newErrorResponse(rerr)
 	}
 
 	return common.NewSuccessResponse()
 }
 
+// UserResponse is the struct a user's profile is returned as
+type UserResponse struct {
+	ID         string `json:"id"`
+	Username   string `json:"username"`
+	IsAdmin    bool   `json:"is_admin"`
+	IsDisabled bool   `json:"is_disabled"`
+}
+
+func newUserResponse(user *models.User) UserResponse {
+	return UserResponse{
+		ID:         user.ID.String(),
+		Username:   user.Username,
+		IsAdmin:    user.IsAdmin,
+		IsDisabled: user.IsDisabled,
+	}
+}
+
+// GetUser handles GET requests to "/user"
+func (h RouterFactory) getUser(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//get the user
+	user, rerr := h.Controllers.GetUser(req.Context(), tx, token.User)
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	return common.NewSuccessDataResponse(newUserResponse(user))
+}
+
+// PatchUserBody is the struct the body of requests to PatchUser should be parsed into. Omitted fields are left unchanged.
+type PatchUserBody struct {
+	Username *string `json:"username"`
+}
+
+// PatchUser handles PATCH requests to "/user"
+func (h RouterFactory) patchUser(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+	//parse the body
+	var body PatchUserBody
+	err := parseJSONBody(req, &body)
+	if err != nil {
+		logBodyError(req, "error parsing PatchUser request body", err)
+		return newErrorResponse(err)
+	}
+
+	//update the user
+	user, rerr := h.Controllers.UpdateUser(req.Context(), tx, token.User, models.UserUpdate{
+		Username: body.Username,
+	})
+	if rerr != nil {
+		return newErrorResponse(rerr)
+	}
+
+	return common.NewSuccessDataResponse(newUserResponse(user))
+}
+
 // DeleteUser handles DELETE requests to "/user"
-func (h RouterFactory) deleteUser(_ *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
+func (h RouterFactory) deleteUser(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
 	//delete the user
-	rerr := h.Controllers.DeleteUser(tx, token.User)
-	if rerr.Type == requesterror.ErrorTypeClient {
-		return common.NewBadRequestResponse(rerr.Error())
-	}
-	if rerr.Type == requesterror.ErrorTypeInternal {
-		return common.NewInternalServerErrorResponse()
+	rerr := h.Controllers.DeleteUser(req.Context(), tx, token.User)
+	if rerr != nil {
+		return newErrorResponse(rerr)
 	}
 
 	return common.NewSuccessResponse()
@@ -64,28 +110,22 @@ type PatchUserPasswordBody struct {
 func (h RouterFactory) patchUserPassword(req *http.Request, _ httprouter.Params, token *models.AccessToken, tx database.Transaction) (int, interface{}) {
 	//parse the body
 	var body PatchUserPasswordBody
-	err := parseJSONBody(req.Body, &body)
+	err := parseJSONBody(req, &body)
 	if err != nil {
-		log.Println(common.ChainError("error parsing PatchUserPassword request body", err))
-		return common.NewBadRequestResponse("invalid json body")
+		logBodyError(req, "error parsing PatchUserPassword request body", err)
+		return newErrorResponse(err)
 	}
 
 	//update the password
-	rerr := h.Controllers.UpdateUserPassword(tx, token.User, body.OldPassword, body.NewPassword)
-	if rerr.Type == requesterror.ErrorTypeClient {
-		return common.NewBadRequestResponse(rerr.Error())
-	}
-	if rerr.Type == requesterror.ErrorTypeInternal {
-		return common.NewInternalServerErrorResponse()
+	rerr := h.Controllers.UpdateUserPassword(req.Context(), tx, token.User, body.OldPassword, body.NewPassword)
+	if rerr != nil {
+		return newErrorResponse(rerr)
 	}
 
 	//delete all other user access tokens
-	rerr = h.Controllers.DeleteAllOtherUserTokens(tx, token)
-	if rerr.Type == requesterror.ErrorTypeClient {
-		return common.NewBadRequestResponse(rerr.Error())
-	}
-	if rerr.Type == requesterror.ErrorTypeInternal {
-		return common.NewInternalServerErrorResponse()
+	rerr = h.Controllers.DeleteAllOtherUserTokens(req.Context(), tx, token)
+	if rerr != nil {
+		return newErrorResponse(rerr)
 	}
 
 	return common.NewSuccessResponse()
@@ -25,7 +25,7 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithErrorCreatingTransaction_Ret
 
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", nil)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(nil, errors.New(""))
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -42,7 +42,7 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithInvalidJSONBody_ReturnsBadRe
 
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", "invalid")
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -50,7 +50,7 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithInvalidJSONBody_ReturnsBadRe
 
 	//assert
 	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
-	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid json body")
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "request body must be a json object")
 }
 
 func (suite *UserHandlerTestSuite) TestPostUser_WithClientErrorCreatingUser_ReturnsBadRequest() {
@@ -65,8 +65,8 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithClientErrorCreatingUser_Retu
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", body)
 
 	message := "create user error"
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.ClientError(message))
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InvalidRequestError(message))
 
 	//actFSuc
 	res, err := http.DefaultClient.Do(req)
@@ -77,6 +77,62 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithClientErrorCreatingUser_Retu
 	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, message)
 }
 
+func (suite *UserHandlerTestSuite) TestPostUser_WithConflictErrorCreatingUser_ReturnsConflict() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	body := router.PostUserBody{
+		Username: "username",
+		Password: "password",
+	}
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", body)
+
+	message := "username is already taken"
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.ConflictError(message))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	var errRes common.ErrorResponse
+	status := common.ParseResponse(&suite.Suite, res, &errRes)
+
+	suite.Equal(http.StatusConflict, status)
+	suite.Equal(requesterror.CodeConflict, errRes.Code)
+	suite.Equal(message, errRes.Error)
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+}
+
+func (suite *UserHandlerTestSuite) TestPostUser_WithInvalidFieldErrorCreatingUser_ReturnsFieldDetails() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	body := router.PostUserBody{
+		Username: "",
+		Password: "password",
+	}
+	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", body)
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InvalidFieldError("username", "username cannot be empty"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	var errRes common.ErrorResponse
+	status := common.ParseResponse(&suite.Suite, res, &errRes)
+
+	suite.Equal(http.StatusBadRequest, status)
+	suite.Equal(requesterror.CodeInvalidRequest, errRes.Code)
+	suite.Equal([]requesterror.FieldError{{Field: "username", Message: "username cannot be empty"}}, errRes.Details)
+}
+
 func (suite *UserHandlerTestSuite) TestPostUser_WithInternalErrorCreatingUser_ReturnsInternalServerError() {
 	//arrange
 	server := httptest.NewServer(suite.Router)
@@ -88,8 +144,8 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithInternalErrorCreatingUser_Re
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InternalError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InternalError())
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -111,8 +167,8 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithErrorCommitingTransaction_Re
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.NoError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 	suite.TransactionMock.On("CommitTransaction").Return(errors.New(""))
 
 	//act
@@ -134,8 +190,8 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithValidRequest_ReturnsSuccess(
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.NoError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 	suite.TransactionMock.On("CommitTransaction").Return(nil)
 
 	//act
@@ -144,8 +200,8 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithValidRequest_ReturnsSuccess(
 
 	//assert
 	suite.AuthenticatorMock.AssertNotCalled(suite.T(), "Authenticate", mock.Anything)
-	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction")
-	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", &suite.TransactionMock, body.Username, body.Password)
+	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction", mock.Anything)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", mock.Anything, &suite.TransactionMock, body.Username, body.Password)
 	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
 	suite.TransactionMock.AssertNotCalled(suite.T(), "RollbackTransaction")
 	common.AssertSuccessResponse(&suite.Suite, res)
@@ -162,8 +218,8 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithPanicTriggered_ReturnsIntern
 	}
 	req := common.CreateRequest(&suite.Suite, http.MethodPost, server.URL+"/user", "", body)
 
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
 		panic("test panic handler")
 	})
 
@@ -175,6 +231,151 @@ func (suite *UserHandlerTestSuite) TestPostUser_WithPanicTriggered_ReturnsIntern
 	common.AssertInternalServerErrorResponse(&suite.Suite, res)
 }
 
+func (suite *UserHandlerTestSuite) TestGetUser_WithClientErrorAuthenticatingUser_ReturnsUnauthorized() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/user", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.UnauthorizedError("authentication error"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionFactoryMock.AssertNotCalled(suite.T(), "CreateTransaction", mock.Anything)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusUnauthorized, "authentication error")
+}
+
+func (suite *UserHandlerTestSuite) TestGetUser_WithErrorGettingUser_ReturnsError() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/user", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.NotFoundError("user not found"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusNotFound, "user not found")
+}
+
+func (suite *UserHandlerTestSuite) TestGetUser_WithValidRequest_ReturnsUser() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	user := models.CreateNewUser("username", nil)
+	req := common.CreateRequest(&suite.Suite, http.MethodGet, server.URL+"/user", "", nil)
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("GetUser", mock.Anything, mock.Anything, mock.Anything).Return(user, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "GetUser", mock.Anything, &suite.TransactionMock, token.User)
+
+	var body struct {
+		Data router.UserResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &body)
+	suite.Equal(router.UserResponse{ID: user.ID.String(), Username: "username"}, body.Data)
+}
+
+func (suite *UserHandlerTestSuite) TestPatchUser_WithInvalidJSONBody_ReturnsBadRequest() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user", "", map[string]string{
+		"is_admin": "true",
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertNotCalled(suite.T(), "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "is_admin is not a known field")
+}
+
+func (suite *UserHandlerTestSuite) TestPatchUser_WithConflictErrorUpdatingUser_ReturnsConflict() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user", "", map[string]string{
+		"username": "taken",
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.ConflictError("username is already taken"))
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusConflict, "username is already taken")
+}
+
+func (suite *UserHandlerTestSuite) TestPatchUser_WithValidRequest_ReturnsUpdatedUser() {
+	//arrange
+	server := httptest.NewServer(suite.Router)
+	defer server.Close()
+
+	token := &models.AccessToken{User: &models.User{}}
+	user := models.CreateNewUser("new username", nil)
+	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user", "", map[string]string{
+		"username": "new username",
+	})
+
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(user, nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	res, err := http.DefaultClient.Do(req)
+	suite.Require().NoError(err)
+
+	//assert
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, &suite.TransactionMock, token.User, mock.MatchedBy(func(update models.UserUpdate) bool {
+		return update.Username != nil && *update.Username == "new username"
+	}))
+	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
+
+	var body struct {
+		Data router.UserResponse `json:"data"`
+	}
+	common.AssertResponseOK(&suite.Suite, res, &body)
+	suite.Equal(router.UserResponse{ID: user.ID.String(), Username: "new username"}, body.Data)
+}
+
 func (suite *UserHandlerTestSuite) TestDeleteUser_WithClientErrorAuthenticatingUser_ReturnsUnauthorized() {
 	//arrange
 	server := httptest.NewServer(suite.Router)
@@ -183,7 +384,7 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithClientErrorAuthenticatingU
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
 
 	message := "authenticate error"
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.ClientError(message))
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.UnauthorizedError(message))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -218,8 +419,8 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithErrorCreatingTransaction_R
 	token := &models.AccessToken{User: &models.User{}}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(nil, errors.New(""))
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -238,9 +439,9 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithClientErrorDeletingUser_Re
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
 
 	message := "delete user error"
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything).Return(requesterror.ClientError(message))
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InvalidRequestError(message))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -259,9 +460,9 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithInternalDeletingUser_Retur
 	token := &models.AccessToken{User: &models.User{}}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything).Return(requesterror.InternalError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InternalError())
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -280,9 +481,9 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithErrorCommitingTransaction_
 	token := &models.AccessToken{User: &models.User{}}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything).Return(requesterror.NoError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	suite.TransactionMock.On("CommitTransaction").Return(errors.New(""))
 
 	//act
@@ -301,9 +502,9 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithValidRequest_ReturnsSucces
 	token := &models.AccessToken{User: &models.User{}}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything).Return(requesterror.NoError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	suite.TransactionMock.On("CommitTransaction").Return(nil)
 
 	//act
@@ -312,8 +513,8 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithValidRequest_ReturnsSucces
 
 	//assert
 	suite.AuthenticatorMock.AssertCalled(suite.T(), "Authenticate", mock.Anything)
-	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction")
-	suite.ControllersMock.AssertCalled(suite.T(), "DeleteUser", &suite.TransactionMock, token.User)
+	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction", mock.Anything)
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteUser", mock.Anything, &suite.TransactionMock, token.User)
 	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
 	suite.TransactionMock.AssertNotCalled(suite.T(), "RollbackTransaction")
 	common.AssertSuccessResponse(&suite.Suite, res)
@@ -327,9 +528,9 @@ func (suite *UserHandlerTestSuite) TestDeleteUser_WithPanicTriggered_ReturnsInte
 	token := &models.AccessToken{User: &models.User{}}
 	req := common.CreateRequest(&suite.Suite, http.MethodDelete, server.URL+"/user", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("DeleteUser", mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
 		panic("test panic handler")
 	})
 
@@ -349,7 +550,7 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithClientErrorAuthent
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", nil)
 
 	message := "authenticate error"
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.ClientError(message))
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(nil, requesterror.UnauthorizedError(message))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -384,8 +585,8 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithErrorCreatingTrans
 	token := &models.AccessToken{User: &models.User{}}
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", nil)
 
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(nil, errors.New(""))
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(""))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -403,8 +604,8 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithInvalidJSONBody_Re
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", "invalid")
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -412,7 +613,7 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithInvalidJSONBody_Re
 
 	//assert
 	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
-	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "invalid json body")
+	common.AssertErrorResponse(&suite.Suite, res, http.StatusBadRequest, "request body must be a json object")
 }
 
 func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithClientErrorUpdatingUserPassword_ReturnsBadRequest() {
@@ -427,11 +628,11 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithClientErrorUpdatin
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", body)
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	message := "update user password error"
-	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.ClientError(message))
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InvalidRequestError(message))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -454,9 +655,9 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithInternalErrorUpdat
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", body)
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InternalError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InternalError())
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -479,12 +680,12 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithClientErrorDeletin
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", body)
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	message := "update user password error"
-	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.NoError())
-	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything).Return(requesterror.ClientError(message))
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InvalidRequestError(message))
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -507,10 +708,10 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithInternalErrorDelet
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", body)
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.NoError())
-	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything).Return(requesterror.InternalError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything, mock.Anything).Return(requesterror.InternalError())
 
 	//act
 	res, err := http.DefaultClient.Do(req)
@@ -533,10 +734,10 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithErrorCommitingTran
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", body)
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.NoError())
-	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything).Return(requesterror.NoError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	suite.TransactionMock.On("CommitTransaction").Return(errors.New(""))
 
 	//act
@@ -559,10 +760,10 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithValidRequest_Retur
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", body)
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(requesterror.NoError())
-	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything).Return(requesterror.NoError())
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.ControllersMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	suite.TransactionMock.On("CommitTransaction").Return(nil)
 
 	//act
@@ -571,9 +772,9 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithValidRequest_Retur
 
 	//assert
 	suite.AuthenticatorMock.AssertCalled(suite.T(), "Authenticate", mock.Anything)
-	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction")
-	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserPassword", &suite.TransactionMock, token.User, body.OldPassword, body.NewPassword)
-	suite.ControllersMock.AssertCalled(suite.T(), "DeleteAllOtherUserTokens", &suite.TransactionMock, token)
+	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction", mock.Anything)
+	suite.ControllersMock.AssertCalled(suite.T(), "UpdateUserPassword", mock.Anything, &suite.TransactionMock, token.User, body.OldPassword, body.NewPassword)
+	suite.ControllersMock.AssertCalled(suite.T(), "DeleteAllOtherUserTokens", mock.Anything, &suite.TransactionMock, token)
 	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
 	suite.TransactionMock.AssertNotCalled(suite.T(), "RollbackTransaction")
 	common.AssertSuccessResponse(&suite.Suite, res)
@@ -591,9 +792,9 @@ func (suite *UserHandlerTestSuite) TestUpdateUserPassword_WithPanicTriggered_Ret
 	req := common.CreateRequest(&suite.Suite, http.MethodPatch, server.URL+"/user/password", "", body)
 
 	token := &models.AccessToken{User: &models.User{}}
-	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, requesterror.NoError())
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+	suite.AuthenticatorMock.On("Authenticate", mock.Anything).Return(token, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("UpdateUserPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
 		panic("test panic handler")
 	})
 
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"authserver/common"
+	"authserver/logger"
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// CertificateReloader loads a tls certificate from a cert and key file, and reloads it whenever either file changes.
+type CertificateReloader struct {
+	certFile string
+	keyFile  string
+
+	mutex       sync.Mutex
+	cert        *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// CreateCertificateReloader creates a new CertificateReloader and loads the initial certificate.
+// Returns the reloader and any errors.
+func CreateCertificateReloader(certFile string, keyFile string) (*CertificateReloader, error) {
+	r := &CertificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+
+	certModTime, keyModTime, err := r.getModTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.load(certModTime, keyModTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate returns the current certificate, reloading it first if the cert or key file has changed.
+// If reloading fails, the previous certificate continues to be used.
+// Matches the signature of tls.Config's GetCertificate.
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	certModTime, keyModTime, err := r.getModTimes()
+	if err != nil {
+		logger.Default().Error(common.ChainError("error checking certificate files for changes", err))
+		return r.cert, nil
+	}
+
+	//reload the certificate if either file has changed
+	if !certModTime.Equal(r.certModTime) || !keyModTime.Equal(r.keyModTime) {
+		err = r.load(certModTime, keyModTime)
+		if err != nil {
+			logger.Default().Error(common.ChainError("error reloading certificate", err))
+		}
+	}
+
+	return r.cert, nil
+}
+
+func (r *CertificateReloader) load(certModTime time.Time, keyModTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return common.ChainError("error loading certificate key pair", err)
+	}
+
+	r.cert = &cert
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+
+	return nil
+}
+
+func (r *CertificateReloader) getModTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, common.ChainError("error reading cert file info", err)
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, common.ChainError("error reading key file info", err)
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -0,0 +1,139 @@
+package server_test
+
+import (
+	"authserver/common"
+	"authserver/server"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CertificateReloaderTestSuite struct {
+	suite.Suite
+	Dir      string
+	CertFile string
+	KeyFile  string
+}
+
+func (suite *CertificateReloaderTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "certificate_reloader_test")
+	suite.Require().NoError(err)
+
+	suite.Dir = dir
+	suite.CertFile = path.Join(dir, "cert.pem")
+	suite.KeyFile = path.Join(dir, "key.pem")
+}
+
+func (suite *CertificateReloaderTestSuite) TearDownTest() {
+	os.RemoveAll(suite.Dir)
+}
+
+func (suite *CertificateReloaderTestSuite) WriteCertificate(commonName string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	suite.Require().NoError(err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	suite.Require().NoError(err)
+
+	err = ioutil.WriteFile(suite.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	suite.Require().NoError(err)
+
+	err = ioutil.WriteFile(suite.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	suite.Require().NoError(err)
+
+	//set the mod times explicitly so the test does not depend on the file system's timestamp resolution
+	suite.Require().NoError(os.Chtimes(suite.CertFile, modTime, modTime))
+	suite.Require().NoError(os.Chtimes(suite.KeyFile, modTime, modTime))
+}
+
+func (suite *CertificateReloaderTestSuite) GetCommonName(reloader *server.CertificateReloader) string {
+	cert, err := reloader.GetCertificate(nil)
+	suite.Require().NoError(err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	suite.Require().NoError(err)
+
+	return leaf.Subject.CommonName
+}
+
+func (suite *CertificateReloaderTestSuite) TestCreateCertificateReloader_WithMissingFiles_ReturnsError() {
+	//act
+	reloader, err := server.CreateCertificateReloader(suite.CertFile, suite.KeyFile)
+
+	//assert
+	suite.Nil(reloader)
+	common.AssertError(&suite.Suite, err, "cert file")
+}
+
+func (suite *CertificateReloaderTestSuite) TestGetCertificate_WithUnchangedFiles_ReturnsLoadedCertificate() {
+	//arrange
+	suite.WriteCertificate("first", time.Now())
+
+	reloader, err := server.CreateCertificateReloader(suite.CertFile, suite.KeyFile)
+	suite.Require().NoError(err)
+
+	//act
+	commonName := suite.GetCommonName(reloader)
+
+	//assert
+	suite.Equal("first", commonName)
+}
+
+func (suite *CertificateReloaderTestSuite) TestGetCertificate_WithChangedFiles_ReturnsReloadedCertificate() {
+	//arrange
+	modTime := time.Now()
+	suite.WriteCertificate("first", modTime)
+
+	reloader, err := server.CreateCertificateReloader(suite.CertFile, suite.KeyFile)
+	suite.Require().NoError(err)
+
+	suite.WriteCertificate("second", modTime.Add(time.Minute))
+
+	//act
+	commonName := suite.GetCommonName(reloader)
+
+	//assert
+	suite.Equal("second", commonName)
+}
+
+func (suite *CertificateReloaderTestSuite) TestGetCertificate_WithErrorReloadingCertificate_ReturnsPreviousCertificate() {
+	//arrange
+	modTime := time.Now()
+	suite.WriteCertificate("first", modTime)
+
+	reloader, err := server.CreateCertificateReloader(suite.CertFile, suite.KeyFile)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(ioutil.WriteFile(suite.CertFile, []byte("invalid"), 0600))
+	suite.Require().NoError(os.Chtimes(suite.CertFile, modTime.Add(time.Minute), modTime.Add(time.Minute)))
+
+	//act
+	commonName := suite.GetCommonName(reloader)
+
+	//assert
+	suite.Equal("first", commonName)
+}
+
+func TestCertificateReloaderTestSuite(t *testing.T) {
+	suite.Run(t, &CertificateReloaderTestSuite{})
+}
@@ -1,24 +1,43 @@
 package server
 
 import (
+	"authserver/common"
+	"authserver/config"
 	"authserver/database"
+	"authserver/logger"
 	"authserver/router"
-	"fmt"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
 	"net/http"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 // HTTPServer is a wrapper for an http server that implements the server interface.
 type HTTPServer struct {
 	http.Server
+
+	// TLSSettings is the config used to serve over tls.
+	TLSSettings config.TLSConfig
 }
 
-// CreateHTTPServerRunner creates a server runner using an http server.
+// CreateHTTPServerRunner creates a server runner using an http server configured with the fields from the server config.
 func CreateHTTPServerRunner(DBConnection database.DBConnection, routerFactory router.IRouterFactory) Runner {
+	serverConfig := viper.Get("server").(config.ServerConfig)
+
 	server := &HTTPServer{
 		Server: http.Server{
-			Addr:    ":8080",
-			Handler: routerFactory.CreateRouter(),
+			Addr:           serverConfig.Addr,
+			Handler:        routerFactory.CreateRouter(),
+			ReadTimeout:    time.Duration(serverConfig.ReadTimeout) * time.Millisecond,
+			WriteTimeout:   time.Duration(serverConfig.WriteTimeout) * time.Millisecond,
+			IdleTimeout:    time.Duration(serverConfig.IdleTimeout) * time.Millisecond,
+			MaxHeaderBytes: serverConfig.MaxHeaderBytes,
 		},
+		TLSSettings: serverConfig.TLS,
 	}
 
 	return Runner{
@@ -27,11 +46,63 @@ func CreateHTTPServerRunner(DBConnection database.DBConnection, routerFactory ro
 	}
 }
 
-// Start starts the http server. Always returns a non-nil error.
+// Start starts the http server, serving over tls if a cert file is configured.
+// Returns a nil error if the server was stopped by calling Shutdown or Close.
 func (s *HTTPServer) Start() error {
-	fmt.Println("Server is running on port", s.Addr)
-	return s.ListenAndServe()
+	var err error
+
+	if s.TLSSettings.CertFile == "" {
+		logger.Default().With("addr", s.Addr).Info("server is running")
+		err = s.ListenAndServe()
+	} else {
+		s.Server.TLSConfig, err = s.createTLSConfig()
+		if err != nil {
+			return common.ChainError("error creating tls config", err)
+		}
+
+		logger.Default().With("addr", s.Addr).Info("server is running with tls")
+		err = s.ListenAndServeTLS("", "")
+	}
+
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+// Close immediately closes the http server and all of its active connections.
+func (s *HTTPServer) Close() {
+	s.Server.Close()
 }
 
-// Close does nothing but exists to satisfy the server interface.
-func (*HTTPServer) Close() {}
+func (s *HTTPServer) createTLSConfig() (*tls.Config, error) {
+	reloader, err := CreateCertificateReloader(s.TLSSettings.CertFile, s.TLSSettings.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	//enable mutual tls if a client ca is provided
+	if s.TLSSettings.ClientCAFile != "" {
+		caCerts, err := ioutil.ReadFile(s.TLSSettings.ClientCAFile)
+		if err != nil {
+			return nil, common.ChainError("error reading client ca file", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCerts) {
+			return nil, errors.New("no valid certificates found in client ca file")
+		}
+
+		//clients without certificates can still connect, but any provided certificates must be valid
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
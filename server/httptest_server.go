@@ -3,6 +3,7 @@ package server
 import (
 	"authserver/database"
 	"authserver/router"
+	"context"
 	"net/http/httptest"
 )
 
@@ -28,3 +29,9 @@ func (s *HTTPTestServer) Start() error {
 	s.Server.Start()
 	return nil
 }
+
+// Shutdown closes the server, blocking until all outstanding requests have completed. Always returns a nil error.
+func (s *HTTPTestServer) Shutdown(context.Context) error {
+	s.Server.Close()
+	return nil
+}
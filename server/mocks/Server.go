@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Server is an autogenerated mock type for the Server type
 type Server struct {
@@ -14,6 +18,20 @@ func (_m *Server) Close() {
 	_m.Called()
 }
 
+// Shutdown provides a mock function with given fields: ctx
+func (_m *Server) Shutdown(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields:
 func (_m *Server) Start() error {
 	ret := _m.Called()
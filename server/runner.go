@@ -2,14 +2,27 @@ package server
 
 import (
 	"authserver/common"
+	"authserver/config"
 	"authserver/database"
+	"authserver/health"
+	"authserver/logger"
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 // Server is an interface for starting and closing a server.
 type Server interface {
 	// Start starts the server and returns any errors encountered while it is running.
+	// Returns a nil error if the server was stopped by calling Shutdown or Close.
 	Start() error
 
+	// Shutdown gracefully shuts down the server, waiting for in-flight requests to finish or the context to expire.
+	// Returns any errors.
+	Shutdown(ctx context.Context) error
+
 	// Close closes the server.
 	Close()
 }
@@ -18,6 +31,12 @@ type Server interface {
 type Runner struct {
 	DBConnection database.DBConnection
 	Server       Server
+
+	// Workers are the background workers that run alongside the server.
+	Workers []Worker
+
+	// ShutdownCheck is notified when the server begins shutting down so it can report as not ready. Optional.
+	ShutdownCheck *health.ShutdownCheck
 }
 
 // Run runs the server and returns any errors.
@@ -30,9 +49,96 @@ func (s Runner) Run() error {
 
 	err = s.DBConnection.Ping()
 	if err != nil {
+		s.closeDBConnection()
 		return common.ChainError("error reaching database", err)
 	}
 
-	//start the server
-	return s.Server.Start()
+	//start the background workers
+	for _, worker := range s.Workers {
+		worker.Start()
+	}
+
+	//start the server, cleaning up if it fails so nothing is left running
+	err = s.Server.Start()
+	if err != nil {
+		s.stopWorkers()
+		s.closeDBConnection()
+		return err
+	}
+
+	return nil
+}
+
+// stopWorkers stops the background workers.
+func (s Runner) stopWorkers() {
+	for _, worker := range s.Workers {
+		worker.Stop()
+	}
+}
+
+// closeDBConnection closes the database connection after a failed run, logging any errors.
+func (s Runner) closeDBConnection() {
+	err := s.DBConnection.CloseConnection()
+	if err != nil {
+		logger.Default().Error(common.ChainError("error closing database connection", err))
+	}
+}
+
+// RunUntilSignaled runs the server until a signal is received on the signals channel, then gracefully shuts it down.
+// Returns any errors encountered while running or shutting down the server.
+func (s Runner) RunUntilSignaled(signals <-chan os.Signal) error {
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- s.Run()
+	}()
+
+	select {
+	case err := <-runErr:
+		if err != nil {
+			return err
+		}
+	case sig := <-signals:
+		logger.Default().With("signal", sig.String()).Info("received signal, shutting down server")
+	}
+
+	return s.Shutdown()
+}
+
+// Shutdown gracefully shuts down the server. The server reports as not ready and keeps serving for the configured shutdown delay,
+// then waits up to the configured shutdown timeout for in-flight requests to finish.
+// The server is forcibly closed if the timeout is exceeded.
+// Once the server has stopped, the workers are stopped and the database connection is closed.
+// Returns any errors.
+func (s Runner) Shutdown() error {
+	serverConfig := viper.Get("server").(config.ServerConfig)
+
+	//report as not ready so no new requests are routed to the server
+	if s.ShutdownCheck != nil {
+		s.ShutdownCheck.BeginShutdown()
+	}
+
+	//keep serving while load balancers notice the server is no longer ready
+	time.Sleep(time.Duration(serverConfig.ShutdownDelay) * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(serverConfig.ShutdownTimeout)*time.Millisecond)
+	defer cancel()
+
+	//drain the server, forcibly closing it if it takes too long
+	var shutdownErr error
+	err := s.Server.Shutdown(ctx)
+	if err != nil {
+		s.Server.Close()
+		shutdownErr = common.ChainError("error shutting down server", err)
+	}
+
+	//stop the background workers
+	s.stopWorkers()
+
+	//close the database connection now that nothing is using it
+	err = s.DBConnection.CloseConnection()
+	if err != nil {
+		return common.ChainError("error closing database connection", err)
+	}
+
+	return shutdownErr
 }
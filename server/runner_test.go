@@ -2,12 +2,19 @@ package server_test
 
 import (
 	"authserver/common"
+	"authserver/config"
 	databasemocks "authserver/database/mocks"
+	"authserver/health"
 	"authserver/server"
 	"authserver/server/mocks"
 	"errors"
+	"os"
+	"syscall"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -15,17 +22,28 @@ type RunnerTestSuite struct {
 	suite.Suite
 	DBConnectionMock databasemocks.DBConnection
 	ServerMock       mocks.Server
+	WorkerMock       mocks.Worker
 	Runner           *server.Runner
 }
 
 func (suite *RunnerTestSuite) SetupTest() {
 	suite.DBConnectionMock = databasemocks.DBConnection{}
 	suite.ServerMock = mocks.Server{}
+	suite.WorkerMock = mocks.Worker{}
+
+	suite.WorkerMock.On("Start")
+	suite.WorkerMock.On("Stop")
 
 	suite.Runner = &server.Runner{
-		DBConnection: &suite.DBConnectionMock,
-		Server:       &suite.ServerMock,
+		DBConnection:  &suite.DBConnectionMock,
+		Server:        &suite.ServerMock,
+		Workers:       []server.Worker{&suite.WorkerMock},
+		ShutdownCheck: &health.ShutdownCheck{},
 	}
+
+	viper.Set("server", config.ServerConfig{
+		ShutdownTimeout: 1000,
+	})
 }
 
 func (suite *RunnerTestSuite) TestRun_WithErrorOpeningDBConnection_ReturnsError() {
@@ -46,12 +64,15 @@ func (suite *RunnerTestSuite) TestRun_WithPingingDatabase_ReturnsError() {
 
 	suite.DBConnectionMock.On("OpenConnection").Return(nil)
 	suite.DBConnectionMock.On("Ping").Return(errors.New(message))
+	suite.DBConnectionMock.On("CloseConnection").Return(nil)
 
 	//act
 	err := suite.Runner.Run()
 
 	//assert
 	common.AssertError(&suite.Suite, err, message)
+	suite.DBConnectionMock.AssertCalled(suite.T(), "CloseConnection")
+	suite.WorkerMock.AssertNotCalled(suite.T(), "Start")
 }
 
 func (suite *RunnerTestSuite) TestRun_WithErrorStartingServer_ReturnsError() {
@@ -60,6 +81,7 @@ func (suite *RunnerTestSuite) TestRun_WithErrorStartingServer_ReturnsError() {
 
 	suite.DBConnectionMock.On("OpenConnection").Return(nil)
 	suite.DBConnectionMock.On("Ping").Return(nil)
+	suite.DBConnectionMock.On("CloseConnection").Return(nil)
 	suite.ServerMock.On("Start").Return(errors.New(message))
 
 	//act
@@ -67,6 +89,8 @@ func (suite *RunnerTestSuite) TestRun_WithErrorStartingServer_ReturnsError() {
 
 	//assert
 	common.AssertError(&suite.Suite, err, message)
+	suite.WorkerMock.AssertCalled(suite.T(), "Stop")
+	suite.DBConnectionMock.AssertCalled(suite.T(), "CloseConnection")
 }
 
 func (suite *RunnerTestSuite) TestRun_StartsServer() {
@@ -83,7 +107,144 @@ func (suite *RunnerTestSuite) TestRun_StartsServer() {
 
 	suite.DBConnectionMock.AssertCalled(suite.T(), "OpenConnection")
 	suite.DBConnectionMock.AssertCalled(suite.T(), "Ping")
+	suite.WorkerMock.AssertCalled(suite.T(), "Start")
 	suite.ServerMock.AssertCalled(suite.T(), "Start")
+	suite.DBConnectionMock.AssertNotCalled(suite.T(), "CloseConnection")
+}
+
+func (suite *RunnerTestSuite) TestRunUntilSignaled_WithErrorRunningServer_ReturnsError() {
+	//arrange
+	message := "Start mock error"
+
+	suite.DBConnectionMock.On("OpenConnection").Return(nil)
+	suite.DBConnectionMock.On("Ping").Return(nil)
+	suite.DBConnectionMock.On("CloseConnection").Return(nil)
+	suite.ServerMock.On("Start").Return(errors.New(message))
+
+	//act
+	err := suite.Runner.RunUntilSignaled(make(chan os.Signal))
+
+	//assert
+	common.AssertError(&suite.Suite, err, message)
+	suite.ServerMock.AssertNotCalled(suite.T(), "Shutdown", mock.Anything)
+}
+
+func (suite *RunnerTestSuite) TestRunUntilSignaled_WhenSignalReceived_ShutsDownServer() {
+	//arrange
+	stopped := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+
+	suite.DBConnectionMock.On("OpenConnection").Return(nil)
+	suite.DBConnectionMock.On("Ping").Return(nil)
+	suite.DBConnectionMock.On("CloseConnection").Return(nil)
+
+	//only signal once the server is running, so the runner has finished with the mocks before the next test replaces them
+	suite.ServerMock.On("Start").Run(func(_ mock.Arguments) {
+		signals <- syscall.SIGTERM
+		<-stopped
+	}).Return(nil)
+	suite.ServerMock.On("Shutdown", mock.Anything).Run(func(_ mock.Arguments) {
+		close(stopped)
+	}).Return(nil)
+
+	//act
+	err := suite.Runner.RunUntilSignaled(signals)
+
+	//assert
+	suite.Require().NoError(err)
+
+	suite.ServerMock.AssertCalled(suite.T(), "Shutdown", mock.Anything)
+	suite.WorkerMock.AssertCalled(suite.T(), "Stop")
+	suite.DBConnectionMock.AssertCalled(suite.T(), "CloseConnection")
+}
+
+func (suite *RunnerTestSuite) TestShutdown_WithErrorShuttingDownServer_ClosesServerAndReturnsError() {
+	//arrange
+	message := "Shutdown mock error"
+
+	suite.ServerMock.On("Shutdown", mock.Anything).Return(errors.New(message))
+	suite.ServerMock.On("Close")
+	suite.DBConnectionMock.On("CloseConnection").Return(nil)
+
+	//act
+	err := suite.Runner.Shutdown()
+
+	//assert
+	common.AssertError(&suite.Suite, err, message)
+
+	suite.ServerMock.AssertCalled(suite.T(), "Close")
+	suite.WorkerMock.AssertCalled(suite.T(), "Stop")
+	suite.DBConnectionMock.AssertCalled(suite.T(), "CloseConnection")
+}
+
+func (suite *RunnerTestSuite) TestShutdown_WithErrorClosingDBConnection_ReturnsError() {
+	//arrange
+	message := "CloseConnection mock error"
+
+	suite.ServerMock.On("Shutdown", mock.Anything).Return(nil)
+	suite.DBConnectionMock.On("CloseConnection").Return(errors.New(message))
+
+	//act
+	err := suite.Runner.Shutdown()
+
+	//assert
+	common.AssertError(&suite.Suite, err, message)
+}
+
+func (suite *RunnerTestSuite) TestShutdown_ShutsDownServerThenStopsWorkersThenClosesDBConnection() {
+	//arrange
+	var calls []string
+
+	suite.ServerMock.On("Shutdown", mock.Anything).Run(func(_ mock.Arguments) {
+		calls = append(calls, "Shutdown")
+	}).Return(nil)
+
+	suite.WorkerMock = mocks.Worker{}
+	suite.WorkerMock.On("Stop").Run(func(_ mock.Arguments) {
+		calls = append(calls, "Stop")
+	})
+
+	suite.DBConnectionMock.On("CloseConnection").Run(func(_ mock.Arguments) {
+		calls = append(calls, "CloseConnection")
+	}).Return(nil)
+
+	//act
+	err := suite.Runner.Shutdown()
+
+	//assert
+	suite.Require().NoError(err)
+
+	suite.ServerMock.AssertNotCalled(suite.T(), "Close")
+	suite.Equal([]string{"Shutdown", "Stop", "CloseConnection"}, calls)
+	suite.Error(suite.Runner.ShutdownCheck.Check(), "server should report as not ready")
+}
+
+func (suite *RunnerTestSuite) TestShutdown_ReportsNotReadyForShutdownDelayBeforeShuttingDownServer() {
+	//arrange
+	delay := 50 * time.Millisecond
+	viper.Set("server", config.ServerConfig{
+		ShutdownDelay:   int(delay / time.Millisecond),
+		ShutdownTimeout: 1000,
+	})
+
+	start := time.Now()
+	var elapsed time.Duration
+	var checkErr error
+
+	suite.ServerMock.On("Shutdown", mock.Anything).Run(func(_ mock.Arguments) {
+		elapsed = time.Since(start)
+		checkErr = suite.Runner.ShutdownCheck.Check()
+	}).Return(nil)
+	suite.DBConnectionMock.On("CloseConnection").Return(nil)
+
+	//act
+	err := suite.Runner.Shutdown()
+
+	//assert
+	suite.Require().NoError(err)
+
+	suite.GreaterOrEqual(int64(elapsed), int64(delay))
+	suite.Error(checkErr, "server should report as not ready before it is shut down")
 }
 
 func TestRunnerTestSuite(t *testing.T) {
@@ -1,11 +1,14 @@
 package server_test
 
 import (
+	"authserver/config"
 	databasemocks "authserver/database/mocks"
 	routermocks "authserver/router/mocks"
 	"authserver/server"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -18,6 +21,18 @@ type ServerTestSuite struct {
 func (suite *ServerTestSuite) SetupTest() {
 	suite.DBConnectionMock = databasemocks.DBConnection{}
 	suite.RouterFactoryMock = routermocks.IRouterFactory{}
+
+	viper.Set("server", config.ServerConfig{
+		Addr:           ":1234",
+		ReadTimeout:    1000,
+		WriteTimeout:   2000,
+		IdleTimeout:    3000,
+		MaxHeaderBytes: 4000,
+		TLS: config.TLSConfig{
+			CertFile: "cert.pem",
+			KeyFile:  "key.pem",
+		},
+	})
 }
 
 func (suite *ServerTestSuite) TestCreateHTTPServerRunner_CreatesRunnerUsingHTTPServer() {
@@ -26,11 +41,19 @@ func (suite *ServerTestSuite) TestCreateHTTPServerRunner_CreatesRunnerUsingHTTPS
 
 	//act
 	runner := server.CreateHTTPServerRunner(&suite.DBConnectionMock, &suite.RouterFactoryMock)
-	_, ok := runner.Server.(*server.HTTPServer)
+	httpServer, ok := runner.Server.(*server.HTTPServer)
 
 	//assert
 	suite.RouterFactoryMock.AssertCalled(suite.T(), "CreateRouter")
-	suite.True(ok, "Runner's server should be an http server")
+	suite.Require().True(ok, "Runner's server should be an http server")
+
+	suite.Equal(":1234", httpServer.Addr)
+	suite.Equal(time.Second, httpServer.ReadTimeout)
+	suite.Equal(2*time.Second, httpServer.WriteTimeout)
+	suite.Equal(3*time.Second, httpServer.IdleTimeout)
+	suite.Equal(4000, httpServer.MaxHeaderBytes)
+	suite.Equal("cert.pem", httpServer.TLSSettings.CertFile)
+	suite.Equal("key.pem", httpServer.TLSSettings.KeyFile)
 }
 
 func (suite *ServerTestSuite) TestCreateHTTPTestServerRunner_CreatesRunnerUsingHTTPTestServer() {
@@ -0,0 +1,10 @@
+package server
+
+// Worker is an interface for a background process that runs alongside the server.
+type Worker interface {
+	// Start starts the worker. Should not block.
+	Start()
+
+	// Stop stops the worker, blocking until any work in progress has finished.
+	Stop()
+}
@@ -0,0 +1,58 @@
+package tokencache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Invalidation identifies the cached tokens that must be removed because they were revoked or their user changed.
+// An invalidation with neither id set removes every cached token.
+type Invalidation struct {
+	// TokenID is the id of the token to remove. Optional.
+	TokenID uuid.UUID `json:"token_id"`
+
+	// UserID is the id of the user whose tokens should all be removed. Optional.
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// Backplane is an interface for sharing invalidations between every cache, including the caches of other application replicas.
+type Backplane interface {
+	// Publish delivers the invalidation to every subscriber.
+	// Subscribers in this process have received the invalidation by the time it returns. Returns any errors.
+	Publish(ctx context.Context, inv Invalidation) error
+
+	// Subscribe registers the handler to be called with every invalidation published to the backplane.
+	Subscribe(handler func(Invalidation))
+}
+
+// LocalBackplane is an in-process implementation of the Backplane interface.
+// It is only suitable when a single replica of the application is running.
+type LocalBackplane struct {
+	mutex    sync.RWMutex
+	handlers []func(Invalidation)
+}
+
+// Publish calls every subscribed handler with the invalidation. Never returns an error.
+func (b *LocalBackplane) Publish(_ context.Context, inv Invalidation) error {
+	b.notify(inv)
+	return nil
+}
+
+// Subscribe registers the handler to be called with every invalidation published to the backplane.
+func (b *LocalBackplane) Subscribe(handler func(Invalidation)) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.handlers = append(b.handlers, handler)
+}
+
+func (b *LocalBackplane) notify(inv Invalidation) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for _, handler := range b.handlers {
+		handler(inv)
+	}
+}
@@ -0,0 +1,96 @@
+package tokencache_test
+
+import (
+	"authserver/tokencache"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalBackplaneTestSuite struct {
+	suite.Suite
+	Backplane *tokencache.LocalBackplane
+}
+
+func (suite *LocalBackplaneTestSuite) SetupTest() {
+	suite.Backplane = &tokencache.LocalBackplane{}
+}
+
+func (suite *LocalBackplaneTestSuite) TestPublish_CallsEverySubscribedHandler() {
+	//arrange
+	inv := tokencache.Invalidation{TokenID: uuid.New()}
+
+	var received1, received2 []tokencache.Invalidation
+	suite.Backplane.Subscribe(func(i tokencache.Invalidation) {
+		received1 = append(received1, i)
+	})
+	suite.Backplane.Subscribe(func(i tokencache.Invalidation) {
+		received2 = append(received2, i)
+	})
+
+	//act
+	err := suite.Backplane.Publish(context.Background(), inv)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]tokencache.Invalidation{inv}, received1)
+	suite.Equal([]tokencache.Invalidation{inv}, received2)
+}
+
+func (suite *LocalBackplaneTestSuite) TestPublish_WithNoSubscribers_ReturnsNoError() {
+	//act
+	err := suite.Backplane.Publish(context.Background(), tokencache.Invalidation{})
+
+	//assert
+	suite.NoError(err)
+}
+
+func TestLocalBackplaneTestSuite(t *testing.T) {
+	suite.Run(t, &LocalBackplaneTestSuite{})
+}
+
+type PostgresBackplaneTestSuite struct {
+	suite.Suite
+	Backplane *tokencache.PostgresBackplane
+}
+
+func (suite *PostgresBackplaneTestSuite) SetupTest() {
+	suite.Backplane = &tokencache.PostgresBackplane{}
+}
+
+func (suite *PostgresBackplaneTestSuite) TestPublish_BeforeStart_OnlyCallsLocalHandlers() {
+	//arrange
+	inv := tokencache.Invalidation{UserID: uuid.New()}
+
+	var received []tokencache.Invalidation
+	suite.Backplane.Subscribe(func(i tokencache.Invalidation) {
+		received = append(received, i)
+	})
+
+	//act
+	err := suite.Backplane.Publish(context.Background(), inv)
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]tokencache.Invalidation{inv}, received)
+}
+
+func (suite *PostgresBackplaneTestSuite) TestStop_BeforeStart_DoesNothing() {
+	//act
+	suite.Backplane.Stop()
+}
+
+func (suite *PostgresBackplaneTestSuite) TestStop_WhereDatabaseIsUnreachable_StopsListening() {
+	//arrange
+	suite.Backplane.ConnectionString = "postgres://localhost:1/authserver?sslmode=disable&connect_timeout=1"
+	suite.Backplane.Start()
+
+	//act
+	suite.Backplane.Stop()
+}
+
+func TestPostgresBackplaneTestSuite(t *testing.T) {
+	suite.Run(t, &PostgresBackplaneTestSuite{})
+}
@@ -0,0 +1,186 @@
+package tokencache
+
+import (
+	"authserver/models"
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cache is a bounded, least recently used cache of access tokens.
+// Entries expire after the ttl so changes made without an invalidation, such as by another application, are eventually seen.
+// The cache is safe for concurrent use.
+type Cache struct {
+	size int
+	ttl  time.Duration
+
+	mutex      sync.Mutex
+	entries    *list.List
+	tokens     map[uuid.UUID]*list.Element
+	users      map[uuid.UUID]map[uuid.UUID]*list.Element
+	generation uint64
+}
+
+type entry struct {
+	token     models.AccessToken
+	expiresAt time.Time
+}
+
+// CreateCache creates a new empty cache that holds at most size tokens, each for at most the ttl.
+func CreateCache(size int, ttl time.Duration) *Cache {
+	return &Cache{
+		size:    size,
+		ttl:     ttl,
+		entries: list.New(),
+		tokens:  map[uuid.UUID]*list.Element{},
+		users:   map[uuid.UUID]map[uuid.UUID]*list.Element{},
+	}
+}
+
+// Get returns a copy of the cached token with the id, or nil if it is not cached or has expired.
+func (c *Cache) Get(ID uuid.UUID) *models.AccessToken {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.tokens[ID]
+	if !ok {
+		return nil
+	}
+
+	e := elem.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		c.remove(elem)
+		return nil
+	}
+
+	c.entries.MoveToFront(elem)
+	return copyToken(&e.token)
+}
+
+// Add caches a copy of the token, evicting the least recently used token if the cache is full.
+func (c *Cache) Add(token *models.AccessToken) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.add(token)
+}
+
+// Generation returns the cache's generation, which every invalidation increments.
+// Callers that fetch a token to cache get the generation before the fetch, then cache the token with AddFromGeneration.
+func (c *Cache) Generation() uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.generation
+}
+
+// AddFromGeneration caches a copy of the token like Add, unless the cache has been invalidated since the generation,
+// since the token may have been revoked after it was fetched. Returns whether the token was cached.
+func (c *Cache) AddFromGeneration(token *models.AccessToken, generation uint64) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.generation != generation {
+		return false
+	}
+
+	c.add(token)
+	return true
+}
+
+// add caches a copy of the token. The cache's mutex must be held.
+func (c *Cache) add(token *models.AccessToken) {
+	if c.size <= 0 {
+		return
+	}
+
+	//replace any existing entry for the token
+	if elem, ok := c.tokens[token.ID]; ok {
+		c.remove(elem)
+	}
+
+	//evict the least recently used token if full
+	if c.entries.Len() >= c.size {
+		c.remove(c.entries.Back())
+	}
+
+	elem := c.entries.PushFront(&entry{
+		token:     *copyToken(token),
+		expiresAt: time.Now().Add(c.ttl),
+	})
+	c.tokens[token.ID] = elem
+
+	userID := token.User.ID
+	if c.users[userID] == nil {
+		c.users[userID] = map[uuid.UUID]*list.Element{}
+	}
+	c.users[userID][token.ID] = elem
+}
+
+// Invalidate removes the tokens matching the invalidation from the cache, and increments its generation
+// so tokens fetched before the invalidation are not cached.
+func (c *Cache) Invalidate(inv Invalidation) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+
+	//clear everything if the invalidation has no target
+	if inv.TokenID == uuid.Nil && inv.UserID == uuid.Nil {
+		c.entries.Init()
+		c.tokens = map[uuid.UUID]*list.Element{}
+		c.users = map[uuid.UUID]map[uuid.UUID]*list.Element{}
+		return
+	}
+
+	if elem, ok := c.tokens[inv.TokenID]; ok {
+		c.remove(elem)
+	}
+
+	for _, elem := range c.users[inv.UserID] {
+		c.remove(elem)
+	}
+}
+
+// Len returns the number of tokens in the cache, including any that have expired but not yet been removed.
+func (c *Cache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.entries.Len()
+}
+
+// remove removes the element from the list and indexes. The cache's mutex must be held.
+func (c *Cache) remove(elem *list.Element) {
+	e := c.entries.Remove(elem).(*entry)
+	delete(c.tokens, e.token.ID)
+
+	userID := e.token.User.ID
+	delete(c.users[userID], e.token.ID)
+	if len(c.users[userID]) == 0 {
+		delete(c.users, userID)
+	}
+}
+
+// copyToken copies the token and the models it references, so callers can't modify the cached models.
+func copyToken(token *models.AccessToken) *models.AccessToken {
+	tokenCopy := *token
+
+	if token.User != nil {
+		user := *token.User
+		user.PasswordHash = append([]byte(nil), token.User.PasswordHash...)
+		tokenCopy.User = &user
+	}
+	if token.Client != nil {
+		client := *token.Client
+		tokenCopy.Client = &client
+	}
+	if token.Scope != nil {
+		scope := *token.Scope
+		tokenCopy.Scope = &scope
+	}
+
+	return &tokenCopy
+}
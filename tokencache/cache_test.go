@@ -0,0 +1,224 @@
+package tokencache_test
+
+import (
+	"authserver/models"
+	"authserver/tokencache"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheTestSuite struct {
+	suite.Suite
+	Cache *tokencache.Cache
+}
+
+func (suite *CacheTestSuite) SetupTest() {
+	suite.Cache = tokencache.CreateCache(2, time.Minute)
+}
+
+func createToken(user *models.User) *models.AccessToken {
+	return models.CreateNewAccessToken(
+		user,
+		models.CreateNewClient(),
+		models.CreateNewScope("name"),
+	)
+}
+
+func (suite *CacheTestSuite) TestGet_WhereTokenIsNotCached_ReturnsNil() {
+	//arrange
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+
+	//act
+	result := suite.Cache.Get(token.ID)
+
+	//assert
+	suite.Nil(result)
+}
+
+func (suite *CacheTestSuite) TestGet_WhereTokenIsCached_ReturnsCopyOfToken() {
+	//arrange
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+	suite.Cache.Add(token)
+
+	//act
+	result := suite.Cache.Get(token.ID)
+
+	//assert
+	suite.Require().NotNil(result)
+	suite.Equal(token, result)
+	suite.NotSame(token, result)
+	suite.NotSame(token.User, result.User)
+}
+
+func (suite *CacheTestSuite) TestGet_ModifyingTheResult_DoesNotModifyTheCachedToken() {
+	//arrange
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+	suite.Cache.Add(token)
+
+	result := suite.Cache.Get(token.ID)
+	suite.Require().NotNil(result)
+
+	//act
+	result.User.PasswordHash[0] = 'x'
+	result.User.Username = "changed"
+
+	//assert
+	cached := suite.Cache.Get(token.ID)
+	suite.Require().NotNil(cached)
+	suite.Equal("username", cached.User.Username)
+	suite.Equal([]byte("password"), cached.User.PasswordHash)
+}
+
+func (suite *CacheTestSuite) TestGet_WhereTokenHasExpired_ReturnsNilAndRemovesToken() {
+	//arrange
+	suite.Cache = tokencache.CreateCache(2, time.Millisecond)
+
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+	suite.Cache.Add(token)
+
+	time.Sleep(5 * time.Millisecond)
+
+	//act
+	result := suite.Cache.Get(token.ID)
+
+	//assert
+	suite.Nil(result)
+	suite.Zero(suite.Cache.Len())
+}
+
+func (suite *CacheTestSuite) TestAdd_WhereCacheIsFull_EvictsLeastRecentlyUsedToken() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	token1 := createToken(user)
+	token2 := createToken(user)
+	token3 := createToken(user)
+
+	suite.Cache.Add(token1)
+	suite.Cache.Add(token2)
+
+	//use the first token so the second is the least recently used
+	suite.Cache.Get(token1.ID)
+
+	//act
+	suite.Cache.Add(token3)
+
+	//assert
+	suite.Equal(2, suite.Cache.Len())
+	suite.NotNil(suite.Cache.Get(token1.ID))
+	suite.Nil(suite.Cache.Get(token2.ID))
+	suite.NotNil(suite.Cache.Get(token3.ID))
+}
+
+func (suite *CacheTestSuite) TestAdd_WhereTokenIsAlreadyCached_ReplacesToken() {
+	//arrange
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+	suite.Cache.Add(token)
+
+	token.User.Username = "changed"
+
+	//act
+	suite.Cache.Add(token)
+
+	//assert
+	suite.Equal(1, suite.Cache.Len())
+
+	result := suite.Cache.Get(token.ID)
+	suite.Require().NotNil(result)
+	suite.Equal("changed", result.User.Username)
+}
+
+func (suite *CacheTestSuite) TestAdd_WithZeroSize_DoesNotCacheToken() {
+	//arrange
+	suite.Cache = tokencache.CreateCache(0, time.Minute)
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+
+	//act
+	suite.Cache.Add(token)
+
+	//assert
+	suite.Nil(suite.Cache.Get(token.ID))
+}
+
+func (suite *CacheTestSuite) TestInvalidate_WithTokenID_RemovesOnlyThatToken() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	token1 := createToken(user)
+	token2 := createToken(user)
+
+	suite.Cache.Add(token1)
+	suite.Cache.Add(token2)
+
+	//act
+	suite.Cache.Invalidate(tokencache.Invalidation{TokenID: token1.ID})
+
+	//assert
+	suite.Nil(suite.Cache.Get(token1.ID))
+	suite.NotNil(suite.Cache.Get(token2.ID))
+}
+
+func (suite *CacheTestSuite) TestInvalidate_WithUserID_RemovesAllOfTheUsersTokens() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	otherUser := models.CreateNewUser("other", []byte("password"))
+	token1 := createToken(user)
+	token2 := createToken(otherUser)
+
+	suite.Cache = tokencache.CreateCache(3, time.Minute)
+	suite.Cache.Add(token1)
+	suite.Cache.Add(createToken(user))
+	suite.Cache.Add(token2)
+
+	//act
+	suite.Cache.Invalidate(tokencache.Invalidation{UserID: user.ID})
+
+	//assert
+	suite.Equal(1, suite.Cache.Len())
+	suite.Nil(suite.Cache.Get(token1.ID))
+	suite.NotNil(suite.Cache.Get(token2.ID))
+}
+
+func (suite *CacheTestSuite) TestInvalidate_WithNoIDs_RemovesAllTokens() {
+	//arrange
+	suite.Cache.Add(createToken(models.CreateNewUser("username", []byte("password"))))
+	suite.Cache.Add(createToken(models.CreateNewUser("other", []byte("password"))))
+
+	//act
+	suite.Cache.Invalidate(tokencache.Invalidation{})
+
+	//assert
+	suite.Zero(suite.Cache.Len())
+}
+
+func (suite *CacheTestSuite) TestAddFromGeneration_WithCurrentGeneration_CachesToken() {
+	//arrange
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+	generation := suite.Cache.Generation()
+
+	//act
+	added := suite.Cache.AddFromGeneration(token, generation)
+
+	//assert
+	suite.True(added)
+	suite.NotNil(suite.Cache.Get(token.ID))
+}
+
+func (suite *CacheTestSuite) TestAddFromGeneration_WhereCacheWasInvalidatedSinceGeneration_DoesNotCacheToken() {
+	//arrange
+	token := createToken(models.CreateNewUser("username", []byte("password")))
+	generation := suite.Cache.Generation()
+
+	suite.Cache.Invalidate(tokencache.Invalidation{TokenID: token.ID})
+
+	//act
+	added := suite.Cache.AddFromGeneration(token, generation)
+
+	//assert
+	suite.False(added)
+	suite.Nil(suite.Cache.Get(token.ID))
+}
+
+func TestCacheTestSuite(t *testing.T) {
+	suite.Run(t, &CacheTestSuite{})
+}
@@ -0,0 +1,117 @@
+package tokencache
+
+import (
+	"authserver/common"
+	"authserver/database"
+	"authserver/logger"
+	"authserver/models"
+	"context"
+)
+
+// InvalidatingTransactionFactory is an implementation of the TransactionFactory interface
+// whose transactions publish invalidations for the tokens they revoke once they are committed.
+// Tokens are revoked when they are deleted, and when their user is deleted or updated, such as when their password changes.
+type InvalidatingTransactionFactory struct {
+	// TransactionFactory is the factory that creates the underlying transactions.
+	TransactionFactory database.TransactionFactory
+
+	// Backplane is used to publish the invalidations.
+	Backplane Backplane
+}
+
+type invalidatingTransaction struct {
+	database.Transaction
+
+	ctx           context.Context
+	backplane     Backplane
+	invalidations []Invalidation
+}
+
+// CreateTransaction creates a new transaction using the underlying factory. Returns any errors.
+func (f InvalidatingTransactionFactory) CreateTransaction(ctx context.Context) (database.Transaction, error) {
+	tx, err := f.TransactionFactory.CreateTransaction(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &invalidatingTransaction{
+		Transaction: tx,
+		ctx:         ctx,
+		backplane:   f.Backplane,
+	}, nil
+}
+
+// CommitTransaction commits the underlying transaction then publishes its invalidations.
+// Errors publishing are only logged since the transaction has already been committed.
+// Returns any errors committing.
+func (tx *invalidatingTransaction) CommitTransaction() error {
+	err := tx.Transaction.CommitTransaction()
+	if err != nil {
+		return err
+	}
+
+	for _, inv := range tx.invalidations {
+		err = tx.backplane.Publish(tx.ctx, inv)
+		if err != nil {
+			logger.FromContext(tx.ctx).Error(common.ChainError("error publishing token invalidation", err))
+		}
+	}
+
+	return nil
+}
+
+// DeleteAccessToken deletes the token and queues its invalidation. Returns any errors.
+func (tx *invalidatingTransaction) DeleteAccessToken(ctx context.Context, token *models.AccessToken) error {
+	err := tx.Transaction.DeleteAccessToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	tx.invalidations = append(tx.invalidations, Invalidation{TokenID: token.ID})
+	return nil
+}
+
+// DeleteAllOtherUserTokens deletes the user's other tokens and queues the invalidation of all of the user's tokens.
+// The provided token is also removed from the caches, but is fetched again the next time it is used. Returns any errors.
+func (tx *invalidatingTransaction) DeleteAllOtherUserTokens(ctx context.Context, token *models.AccessToken) error {
+	err := tx.Transaction.DeleteAllOtherUserTokens(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	tx.invalidations = append(tx.invalidations, Invalidation{UserID: token.User.ID})
+	return nil
+}
+
+// DeleteAllUserTokens deletes all of the user's tokens and queues their invalidation. Returns any errors.
+func (tx *invalidatingTransaction) DeleteAllUserTokens(ctx context.Context, user *models.User) error {
+	err := tx.Transaction.DeleteAllUserTokens(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	tx.invalidations = append(tx.invalidations, Invalidation{UserID: user.ID})
+	return nil
+}
+
+// UpdateUser updates the user and queues the invalidation of the user's tokens, since they hold a copy of the user. Returns any errors.
+func (tx *invalidatingTransaction) UpdateUser(ctx context.Context, user *models.User) error {
+	err := tx.Transaction.UpdateUser(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	tx.invalidations = append(tx.invalidations, Invalidation{UserID: user.ID})
+	return nil
+}
+
+// DeleteUser deletes the user and queues the invalidation of the user's tokens. Returns any errors.
+func (tx *invalidatingTransaction) DeleteUser(ctx context.Context, user *models.User) error {
+	err := tx.Transaction.DeleteUser(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	tx.invalidations = append(tx.invalidations, Invalidation{UserID: user.ID})
+	return nil
+}
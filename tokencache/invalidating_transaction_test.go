@@ -0,0 +1,157 @@
+package tokencache_test
+
+import (
+	"authserver/common"
+	"authserver/database"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"authserver/tokencache"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvalidatingTransactionFactoryTestSuite struct {
+	suite.Suite
+	TransactionFactoryMock databasemocks.TransactionFactory
+	TransactionMock        databasemocks.Transaction
+	Backplane              *tokencache.LocalBackplane
+	Invalidations          []tokencache.Invalidation
+	Tx                     database.Transaction
+}
+
+func (suite *InvalidatingTransactionFactoryTestSuite) SetupTest() {
+	suite.TransactionFactoryMock = databasemocks.TransactionFactory{}
+	suite.TransactionMock = databasemocks.Transaction{}
+	suite.Backplane = &tokencache.LocalBackplane{}
+	suite.Invalidations = nil
+
+	suite.Backplane.Subscribe(func(inv tokencache.Invalidation) {
+		suite.Invalidations = append(suite.Invalidations, inv)
+	})
+
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+
+	factory := tokencache.InvalidatingTransactionFactory{
+		TransactionFactory: &suite.TransactionFactoryMock,
+		Backplane:          suite.Backplane,
+	}
+
+	var err error
+	suite.Tx, err = factory.CreateTransaction(context.Background())
+	suite.Require().NoError(err)
+}
+
+func (suite *InvalidatingTransactionFactoryTestSuite) TestCreateTransaction_WithErrorCreatingTransaction_ReturnsError() {
+	//arrange
+	factoryMock := databasemocks.TransactionFactory{}
+	factoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New("test error"))
+
+	factory := tokencache.InvalidatingTransactionFactory{
+		TransactionFactory: &factoryMock,
+		Backplane:          suite.Backplane,
+	}
+
+	//act
+	tx, err := factory.CreateTransaction(context.Background())
+
+	//assert
+	suite.Nil(tx)
+	common.AssertError(&suite.Suite, err, "test error")
+}
+
+func (suite *InvalidatingTransactionFactoryTestSuite) TestCommitTransaction_PublishesInvalidationsForRevokedTokens() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	token := models.CreateNewAccessToken(user, models.CreateNewClient(), models.CreateNewScope("name"))
+	otherUser := models.CreateNewUser("other", []byte("password"))
+	updatedUser := models.CreateNewUser("updated", []byte("password"))
+	disabledUser := models.CreateNewUser("disabled", []byte("password"))
+
+	suite.TransactionMock.On("DeleteAccessToken", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("DeleteAllOtherUserTokens", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("DeleteAllUserTokens", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("DeleteUser", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	suite.Require().NoError(suite.Tx.DeleteAccessToken(context.Background(), token))
+	suite.Require().NoError(suite.Tx.DeleteAllOtherUserTokens(context.Background(), token))
+	suite.Require().NoError(suite.Tx.DeleteUser(context.Background(), otherUser))
+	suite.Require().NoError(suite.Tx.UpdateUser(context.Background(), updatedUser))
+	suite.Require().NoError(suite.Tx.DeleteAllUserTokens(context.Background(), disabledUser))
+
+	//act
+	err := suite.Tx.CommitTransaction()
+
+	//assert
+	suite.NoError(err)
+	suite.Equal([]tokencache.Invalidation{
+		{TokenID: token.ID},
+		{UserID: user.ID},
+		{UserID: otherUser.ID},
+		{UserID: updatedUser.ID},
+		{UserID: disabledUser.ID},
+	}, suite.Invalidations)
+
+	suite.TransactionMock.AssertCalled(suite.T(), "DeleteAccessToken", mock.Anything, token)
+	suite.TransactionMock.AssertCalled(suite.T(), "DeleteAllOtherUserTokens", mock.Anything, token)
+	suite.TransactionMock.AssertCalled(suite.T(), "DeleteUser", mock.Anything, otherUser)
+	suite.TransactionMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, updatedUser)
+	suite.TransactionMock.AssertCalled(suite.T(), "DeleteAllUserTokens", mock.Anything, disabledUser)
+}
+
+func (suite *InvalidatingTransactionFactoryTestSuite) TestOperations_BeforeCommit_DoNotPublishInvalidations() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+	suite.TransactionMock.On("DeleteUser", mock.Anything, mock.Anything).Return(nil)
+
+	//act
+	err := suite.Tx.DeleteUser(context.Background(), user)
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(suite.Invalidations)
+}
+
+func (suite *InvalidatingTransactionFactoryTestSuite) TestCommitTransaction_WithErrorCommitting_ReturnsErrorAndPublishesNothing() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+
+	suite.TransactionMock.On("DeleteUser", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(errors.New("test error"))
+
+	suite.Require().NoError(suite.Tx.DeleteUser(context.Background(), user))
+
+	//act
+	err := suite.Tx.CommitTransaction()
+
+	//assert
+	common.AssertError(&suite.Suite, err, "test error")
+	suite.Empty(suite.Invalidations)
+}
+
+func (suite *InvalidatingTransactionFactoryTestSuite) TestCommitTransaction_WhereOperationFailed_DoesNotPublishItsInvalidation() {
+	//arrange
+	user := models.CreateNewUser("username", []byte("password"))
+
+	suite.TransactionMock.On("DeleteUser", mock.Anything, mock.Anything).Return(errors.New("test error"))
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	err := suite.Tx.DeleteUser(context.Background(), user)
+	common.AssertError(&suite.Suite, err, "test error")
+
+	//act
+	err = suite.Tx.CommitTransaction()
+
+	//assert
+	suite.NoError(err)
+	suite.Empty(suite.Invalidations)
+}
+
+func TestInvalidatingTransactionFactoryTestSuite(t *testing.T) {
+	suite.Run(t, &InvalidatingTransactionFactoryTestSuite{})
+}
@@ -0,0 +1,165 @@
+package tokencache
+
+import (
+	"authserver/common"
+	"authserver/logger"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// PostgresBackplaneChannel is the postgres notification channel invalidations are published on.
+const PostgresBackplaneChannel = "authserver_token_invalidations"
+
+// Reconnect intervals used by the postgres backplane's listener.
+const (
+	postgresBackplaneMinReconnectInterval = 10 * time.Second
+	postgresBackplaneMaxReconnectInterval = time.Minute
+)
+
+// PostgresBackplane is an implementation of the Backplane interface that shares invalidations using postgres LISTEN/NOTIFY,
+// so every application replica connected to the same database receives them.
+// It is also a worker that must be started to receive invalidations from other replicas.
+// If the listener's connection is lost or it fails to listen, subscribers are sent an invalidation that clears everything since invalidations may have been missed.
+type PostgresBackplane struct {
+	local LocalBackplane
+
+	// ConnectionString is the connection string of the postgres database.
+	ConnectionString string
+
+	db       *sql.DB
+	listener *pq.Listener
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Publish delivers the invalidation to the subscribers in this process, then notifies the other replicas. Returns any errors.
+func (b *PostgresBackplane) Publish(ctx context.Context, inv Invalidation) error {
+	b.local.notify(inv)
+
+	if b.db == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return common.ChainError("error encoding invalidation", err)
+	}
+
+	_, err = b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", PostgresBackplaneChannel, string(payload))
+	if err != nil {
+		return common.ChainError("error sending notification", err)
+	}
+
+	return nil
+}
+
+// Subscribe registers the handler to be called with every invalidation published to the backplane.
+func (b *PostgresBackplane) Subscribe(handler func(Invalidation)) {
+	b.local.Subscribe(handler)
+}
+
+// Start connects to the database and starts listening for invalidations in the background.
+func (b *PostgresBackplane) Start() {
+	db, err := sql.Open("postgres", b.ConnectionString)
+	if err != nil {
+		logger.Default().Error(common.ChainError("error opening token invalidation connection", err))
+	} else {
+		b.db = db
+	}
+
+	b.listener = pq.NewListener(b.ConnectionString, postgresBackplaneMinReconnectInterval, postgresBackplaneMaxReconnectInterval, b.logEvent)
+	b.stop = make(chan struct{})
+	b.done = make(chan struct{})
+
+	go b.run()
+}
+
+// Stop stops listening for invalidations and closes the backplane's connections.
+func (b *PostgresBackplane) Stop() {
+	if b.stop == nil {
+		return
+	}
+
+	close(b.stop)
+	b.listener.Close()
+	<-b.done
+
+	if b.db != nil {
+		b.db.Close()
+		b.db = nil
+	}
+}
+
+func (b *PostgresBackplane) run() {
+	defer close(b.done)
+
+	if !b.listen() {
+		return
+	}
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case notification, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+			b.handleNotification(notification)
+		}
+	}
+}
+
+// listen starts listening on the invalidation channel, retrying until it succeeds or the backplane is stopped.
+// Every failed attempt clears everything, since invalidations are missed until it succeeds. Returns whether it is listening.
+func (b *PostgresBackplane) listen() bool {
+	for {
+		//blocks until the listener connects or is closed
+		err := b.listener.Listen(PostgresBackplaneChannel)
+		if err == nil {
+			return true
+		}
+
+		select {
+		case <-b.stop:
+			return false
+		default:
+		}
+
+		logger.Default().Error(common.ChainError("error listening for token invalidations, retrying", err))
+		b.local.notify(Invalidation{})
+
+		select {
+		case <-b.stop:
+			return false
+		case <-time.After(postgresBackplaneMinReconnectInterval):
+		}
+	}
+}
+
+func (b *PostgresBackplane) handleNotification(notification *pq.Notification) {
+	//the listener reconnected, so clear everything in case invalidations were missed
+	if notification == nil {
+		b.local.notify(Invalidation{})
+		return
+	}
+
+	var inv Invalidation
+	err := json.Unmarshal([]byte(notification.Extra), &inv)
+	if err != nil {
+		logger.Default().Error(common.ChainError("error decoding token invalidation", err))
+		return
+	}
+
+	b.local.notify(inv)
+}
+
+func (b *PostgresBackplane) logEvent(event pq.ListenerEventType, err error) {
+	if err != nil {
+		logger.Default().With("event", int(event)).Error(common.ChainError("token invalidation listener error", err))
+	}
+}
@@ -2,12 +2,12 @@ package main
 
 import (
 	"authserver/common"
-	requesterror "authserver/common/request_error"
 	"authserver/config"
 	"authserver/controllers"
 	"authserver/database"
 	"authserver/dependencies"
 	"authserver/models"
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -53,17 +53,27 @@ func Run(db database.DBConnection, c controllers.UserController, tf database.Tra
 		return nil, common.ChainError("could not reach database", err)
 	}
 
+	ctx := context.Background()
+
 	//create a new transaction
-	tx, err := tf.CreateTransaction()
+	tx, err := tf.CreateTransaction(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	//save the user, rollback transaction on error
-	user, rerr := c.CreateUser(tx, username, password)
-	if rerr.Type != requesterror.ErrorTypeNone {
+	user, err := c.CreateUser(ctx, tx, username, password)
+	if err != nil {
+		tx.RollbackTransaction()
+		return nil, err
+	}
+
+	//mark the user as an admin, rollback transaction on error
+	user.IsAdmin = true
+	err = tx.UpdateUser(ctx, user)
+	if err != nil {
 		tx.RollbackTransaction()
-		return nil, rerr
+		return nil, common.ChainError("error updating user", err)
 	}
 
 	//commit the transaction
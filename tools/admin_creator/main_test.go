@@ -79,7 +79,7 @@ func (suite *AdminCreatorTestSuite) TestRun_WithErrorCreatingTransaction_Returns
 	suite.DBConnectionMock.On("Ping").Return(nil)
 
 	message := "create transaction error"
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(nil, errors.New(message))
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New(message))
 
 	//act
 	user, err := admincreator.Run(&suite.DBConnectionMock, &suite.ControllersMock, &suite.TransactionFactoryMock, username, password)
@@ -100,10 +100,36 @@ func (suite *AdminCreatorTestSuite) TestRun_WithErrorCreatingUser_ReturnsError()
 	suite.DBConnectionMock.On("OpenConnection").Return(nil)
 	suite.DBConnectionMock.On("CloseConnection").Return(nil)
 	suite.DBConnectionMock.On("Ping").Return(nil)
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
 
 	message := "create user error"
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.ClientError(message))
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, requesterror.InvalidRequestError(message))
+
+	//act
+	user, err := admincreator.Run(&suite.DBConnectionMock, &suite.ControllersMock, &suite.TransactionFactoryMock, username, password)
+
+	//assert
+	suite.DBConnectionMock.AssertCalled(suite.T(), "CloseConnection")
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+
+	suite.Nil(user)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), message)
+}
+
+func (suite *AdminCreatorTestSuite) TestRun_WithErrorUpdatingUser_ReturnsError() {
+	//arrange
+	username := "username"
+	password := "password"
+
+	suite.DBConnectionMock.On("OpenConnection").Return(nil)
+	suite.DBConnectionMock.On("CloseConnection").Return(nil)
+	suite.DBConnectionMock.On("Ping").Return(nil)
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, nil)
+
+	message := "update user error"
+	suite.TransactionMock.On("UpdateUser", mock.Anything, mock.Anything).Return(errors.New(message))
 
 	//act
 	user, err := admincreator.Run(&suite.DBConnectionMock, &suite.ControllersMock, &suite.TransactionFactoryMock, username, password)
@@ -125,8 +151,9 @@ func (suite *AdminCreatorTestSuite) TestRun_WithErrorCommitingTransaction_Return
 	suite.DBConnectionMock.On("OpenConnection").Return(nil)
 	suite.DBConnectionMock.On("CloseConnection").Return(nil)
 	suite.DBConnectionMock.On("Ping").Return(nil)
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, requesterror.NoError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, nil)
+	suite.TransactionMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
 
 	message := "commit transaction error"
 	suite.TransactionMock.On("CommitTransaction").Return(errors.New(message))
@@ -150,8 +177,9 @@ func (suite *AdminCreatorTestSuite) TestRun_WithNoErrors_ReturnsNoErrors() {
 	suite.DBConnectionMock.On("OpenConnection").Return(nil)
 	suite.DBConnectionMock.On("CloseConnection").Return(nil)
 	suite.DBConnectionMock.On("Ping").Return(nil)
-	suite.TransactionFactoryMock.On("CreateTransaction").Return(&suite.TransactionMock, nil)
-	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, requesterror.NoError())
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.ControllersMock.On("CreateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&models.User{}, nil)
+	suite.TransactionMock.On("UpdateUser", mock.Anything, mock.Anything).Return(nil)
 	suite.TransactionMock.On("CommitTransaction").Return(nil)
 
 	//act
@@ -160,8 +188,11 @@ func (suite *AdminCreatorTestSuite) TestRun_WithNoErrors_ReturnsNoErrors() {
 	//assert
 	suite.DBConnectionMock.AssertCalled(suite.T(), "OpenConnection")
 	suite.DBConnectionMock.AssertCalled(suite.T(), "Ping")
-	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction")
-	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", mock.Anything, username, password)
+	suite.TransactionFactoryMock.AssertCalled(suite.T(), "CreateTransaction", mock.Anything)
+	suite.ControllersMock.AssertCalled(suite.T(), "CreateUser", mock.Anything, mock.Anything, username, password)
+	suite.TransactionMock.AssertCalled(suite.T(), "UpdateUser", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+		return u.IsAdmin
+	}))
 	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
 	suite.TransactionMock.AssertNotCalled(suite.T(), "RollbackTransaction")
 	suite.DBConnectionMock.AssertCalled(suite.T(), "CloseConnection")
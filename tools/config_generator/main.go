@@ -42,12 +42,41 @@ func Run(name string) error {
 	cfg := config.Config{
 		RootDir: rootDir,
 		AppID:   uuid.New().String(),
+		ServerConfig: config.ServerConfig{
+			Addr:            ":8080",
+			ReadTimeout:     5000,
+			WriteTimeout:    10000,
+			IdleTimeout:     60000,
+			MaxHeaderBytes:  1 << 20,
+			ShutdownDelay:   5000,
+			ShutdownTimeout: 10000,
+			MaxBodyBytes:    1 << 20,
+			RouteMaxBodyBytes: map[string]int64{
+				"POST /user":                     4096,
+				"PATCH /user":                    4096,
+				"PATCH /user/password":           4096,
+				"POST /token":                    4096,
+				"PATCH /admin/users/:id":         4096,
+				"POST /admin/users/:id/password": 4096,
+			},
+		},
+		LoggingConfig: config.LoggingConfig{
+			Level:  "info",
+			Format: "json",
+		},
 		DatabaseConfig: config.DatabaseConfig{
+			Adapter: config.DatabaseAdapterSQL,
+			Driver:  config.DatabaseDriverPostgres,
 			ConnectionStrings: map[string]string{
 				"core":        "",
 				"integration": "",
 			},
-			Timeout: 3000,
+			ReplicaConnectionStrings: map[string][]string{},
+			PinToPrimaryAfterWrite:   true,
+			Timeout:                  3000,
+			MaxOpenConns:             25,
+			MaxIdleConns:             25,
+			ConnMaxLifetime:          300000,
 		},
 		PasswordCriteriaConfig: config.PasswordCriteriaConfig{
 			MinLength:        8,
@@ -56,6 +85,39 @@ func Run(name string) error {
 			RequireDigit:     true,
 			RequireSymbol:    true,
 		},
+		WebhookConfig: config.WebhookConfig{
+			Endpoints:      []config.WebhookEndpointConfig{},
+			PollInterval:   1000,
+			BatchSize:      20,
+			Timeout:        5000,
+			MaxAttempts:    8,
+			RetryBaseDelay: 1000,
+			RetryMaxDelay:  3600000,
+		},
+		TokenCacheConfig: config.TokenCacheConfig{
+			Size:      10000,
+			TTL:       5000,
+			Backplane: config.TokenCacheBackplaneLocal,
+		},
+		RateLimitConfig: config.RateLimitConfig{
+			Store: config.RateLimitStoreMemory,
+			Token: config.RateLimitRulesConfig{
+				IP:       config.RateLimitRuleConfig{Requests: 60, Period: 60000},
+				ClientID: config.RateLimitRuleConfig{Requests: 600, Period: 60000},
+				Username: config.RateLimitRuleConfig{Requests: 10, Period: 60000},
+			},
+			CreateUser: config.RateLimitRulesConfig{
+				IP: config.RateLimitRuleConfig{Requests: 10, Period: 3600000},
+			},
+		},
+		CORSConfig: config.CORSConfig{
+			AllowedOrigins: []string{},
+			Clients:        []config.CORSClientConfig{},
+			AllowedMethods: []string{},
+			AllowedHeaders: []string{"Authorization", "Content-Type", "X-Request-ID"},
+			ExposedHeaders: []string{"X-Request-ID", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "WWW-Authenticate"},
+			MaxAge:         600000,
+		},
 	}
 
 	//marshal into yaml format
@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"authserver/common"
+	"authserver/config"
+	"authserver/database"
+	"authserver/logger"
+	"authserver/models"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults used in place of zero values in the webhook config.
+const (
+	DefaultPollInterval   = 1000
+	DefaultBatchSize      = 20
+	DefaultTimeout        = 5000
+	DefaultMaxAttempts    = 8
+	DefaultRetryBaseDelay = 1000
+	DefaultRetryMaxDelay  = 3600000
+)
+
+// Dispatcher is a worker that delivers pending outbox events to the configured webhooks.
+// Failed deliveries are retried with exponential backoff until the max attempts is reached, after which the event is dead lettered.
+// An event is retried for every endpoint if any of them fail, so endpoints may receive the same event more than once.
+// Events are claimed before they are delivered, so deliveries don't hold any database locks. Events whose delivery is interrupted
+// are retried once their claim expires.
+type Dispatcher struct {
+	TransactionFactory database.TransactionFactory
+	Client             *http.Client
+	Config             config.WebhookConfig
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// CreateDispatcher creates a new Dispatcher using the provided transaction factory and webhook config.
+// Zero values in the config are replaced with their defaults.
+func CreateDispatcher(tf database.TransactionFactory, cfg config.WebhookConfig) *Dispatcher {
+	defaults := []struct {
+		value        *int
+		defaultValue int
+	}{
+		{&cfg.PollInterval, DefaultPollInterval},
+		{&cfg.BatchSize, DefaultBatchSize},
+		{&cfg.Timeout, DefaultTimeout},
+		{&cfg.MaxAttempts, DefaultMaxAttempts},
+		{&cfg.RetryBaseDelay, DefaultRetryBaseDelay},
+		{&cfg.RetryMaxDelay, DefaultRetryMaxDelay},
+	}
+	for _, d := range defaults {
+		if *d.value <= 0 {
+			*d.value = d.defaultValue
+		}
+	}
+
+	return &Dispatcher{
+		TransactionFactory: tf,
+		Client: &http.Client{
+			Timeout: time.Duration(cfg.Timeout) * time.Millisecond,
+		},
+		Config: cfg,
+	}
+}
+
+// Start starts polling for pending events in the background.
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go d.run(ctx)
+}
+
+// Stop stops polling for pending events, cancelling any deliveries in progress and blocking until the worker has exited.
+func (d *Dispatcher) Stop() {
+	if d.cancel == nil {
+		return
+	}
+
+	d.cancel()
+	<-d.done
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(time.Duration(d.Config.PollInterval) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := d.DispatchPending(ctx)
+			if err != nil && ctx.Err() == nil {
+				logger.Default().Error(common.ChainError("error dispatching outbox events", err))
+			}
+		}
+	}
+}
+
+// DispatchPending claims a batch of the events that are due for an attempt, delivers them and records the result of each attempt.
+// Events are left pending if there are no endpoints configured. Returns the number of events attempted and any errors.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	if len(d.Config.Endpoints) == 0 {
+		return 0, nil
+	}
+
+	events, err := d.claimPending(ctx, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	for i, event := range events {
+		err = d.deliver(ctx, event)
+
+		//the dispatcher is stopping, so leave the event to be retried once its claim expires
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+
+		d.recordAttempt(event, time.Now().UTC(), err)
+
+		err = d.saveAttempt(ctx, event)
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(events), nil
+}
+
+// claimPending gets a batch of the events that are due for an attempt at the given time,
+// and delays their next attempt until they have had time to be delivered so other workers don't attempt them as well.
+// The claim is made in its own transaction so its locks are only held briefly. Returns the events and any errors.
+func (d *Dispatcher) claimPending(ctx context.Context, now time.Time) ([]*models.OutboxEvent, error) {
+	tx, err := d.TransactionFactory.CreateTransaction(ctx)
+	if err != nil {
+		return nil, common.ChainError("error creating transaction", err)
+	}
+
+	events, err := tx.GetPendingOutboxEvents(ctx, now, d.Config.BatchSize)
+	if err != nil {
+		tx.RollbackTransaction()
+		return nil, common.ChainError("error getting pending outbox events", err)
+	}
+
+	//every event in the batch may wait on every endpoint to time out before it is delivered
+	claimedUntil := now.Add(time.Duration(d.Config.Timeout) * time.Millisecond * time.Duration(len(events)*len(d.Config.Endpoints)+1))
+	for _, event := range events {
+		event.NextAttemptAt = claimedUntil
+
+		err = tx.UpdateOutboxEvent(ctx, event)
+		if err != nil {
+			tx.RollbackTransaction()
+			return nil, common.ChainError("error claiming outbox event", err)
+		}
+	}
+
+	err = tx.CommitTransaction()
+	if err != nil {
+		return nil, common.ChainError("error committing transaction", err)
+	}
+
+	return events, nil
+}
+
+// saveAttempt saves the event's delivery state in its own transaction. Returns any errors.
+func (d *Dispatcher) saveAttempt(ctx context.Context, event *models.OutboxEvent) error {
+	tx, err := d.TransactionFactory.CreateTransaction(ctx)
+	if err != nil {
+		return common.ChainError("error creating transaction", err)
+	}
+
+	err = tx.UpdateOutboxEvent(ctx, event)
+	if err != nil {
+		tx.RollbackTransaction()
+		return common.ChainError("error updating outbox event", err)
+	}
+
+	err = tx.CommitTransaction()
+	if err != nil {
+		return common.ChainError("error committing transaction", err)
+	}
+
+	return nil
+}
+
+// recordAttempt updates the event's delivery state with the result of an attempt.
+func (d *Dispatcher) recordAttempt(event *models.OutboxEvent, now time.Time, err error) {
+	event.Attempts++
+	log := logger.Default().With("event_id", event.ID.String()).With("attempts", event.Attempts)
+
+	//mark the event as delivered
+	if err == nil {
+		event.DeliveredAt = now
+		event.LastError = ""
+		return
+	}
+
+	event.LastError = common.TruncateString(err.Error(), models.OutboxEventLastErrorMaxLength)
+
+	//dead letter the event if it is out of attempts, otherwise schedule a retry
+	if event.Attempts >= d.Config.MaxAttempts {
+		event.DeadLettered = true
+		log.Error(common.ChainError("outbox event dead lettered", err))
+	} else {
+		event.NextAttemptAt = now.Add(d.retryDelay(event.Attempts))
+		log.With("error", err.Error()).Warn("error delivering outbox event, retry scheduled")
+	}
+}
+
+// retryDelay returns the time to wait before the next attempt, doubling the base delay for each attempt made.
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := time.Duration(d.Config.RetryBaseDelay) * time.Millisecond
+	maxDelay := time.Duration(d.Config.RetryMaxDelay) * time.Millisecond
+
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// deliver posts the event to every endpoint subscribed to its type. Returns the first error encountered.
+func (d *Dispatcher) deliver(ctx context.Context, event *models.OutboxEvent) error {
+	for _, endpoint := range d.Config.Endpoints {
+		if !isSubscribed(endpoint, event.Type) {
+			continue
+		}
+
+		err := d.post(ctx, endpoint, event)
+		if err != nil {
+			return common.ChainError(fmt.Sprint("error delivering to ", endpoint.URL), err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) post(ctx context.Context, endpoint config.WebhookEndpointConfig, event *models.OutboxEvent) error {
+	body := []byte(event.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return common.ChainError("error creating request", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IDHeader, event.ID.String())
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, timestamp, body))
+
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return common.ChainError("error sending request", err)
+	}
+
+	//drain the body so the connection can be reused
+	io.Copy(ioutil.Discard, res.Body)
+	res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return errors.New(fmt.Sprint("unexpected status code ", res.StatusCode))
+	}
+
+	return nil
+}
+
+func isSubscribed(endpoint config.WebhookEndpointConfig, eventType string) bool {
+	if len(endpoint.Events) == 0 {
+		return true
+	}
+
+	for _, t := range endpoint.Events {
+		if t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
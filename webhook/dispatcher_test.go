@@ -0,0 +1,370 @@
+package webhook_test
+
+import (
+	"authserver/config"
+	databasemocks "authserver/database/mocks"
+	"authserver/models"
+	"authserver/webhook"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type receivedRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+type DispatcherTestSuite struct {
+	suite.Suite
+	TransactionFactoryMock databasemocks.TransactionFactory
+	TransactionMock        databasemocks.Transaction
+	Receiver               *httptest.Server
+	ReceiverStatus         int
+	Received               []receivedRequest
+	Mutex                  sync.Mutex
+	Config                 config.WebhookConfig
+	Dispatcher             *webhook.Dispatcher
+}
+
+func (suite *DispatcherTestSuite) SetupTest() {
+	suite.TransactionFactoryMock = databasemocks.TransactionFactory{}
+	suite.TransactionMock = databasemocks.Transaction{}
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(&suite.TransactionMock, nil)
+	suite.TransactionMock.On("RollbackTransaction")
+
+	suite.ReceiverStatus = http.StatusOK
+	suite.Received = nil
+	suite.Receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+
+		suite.Mutex.Lock()
+		suite.Received = append(suite.Received, receivedRequest{Header: req.Header, Body: body})
+		suite.Mutex.Unlock()
+
+		w.WriteHeader(suite.ReceiverStatus)
+	}))
+
+	suite.Config = config.WebhookConfig{
+		Endpoints: []config.WebhookEndpointConfig{
+			{URL: suite.Receiver.URL, Secret: "secret"},
+		},
+		PollInterval:   10,
+		BatchSize:      5,
+		Timeout:        1000,
+		MaxAttempts:    3,
+		RetryBaseDelay: 1000,
+		RetryMaxDelay:  1500,
+	}
+	suite.Dispatcher = webhook.CreateDispatcher(&suite.TransactionFactoryMock, suite.Config)
+}
+
+func (suite *DispatcherTestSuite) TearDownTest() {
+	suite.Receiver.Close()
+}
+
+func (suite *DispatcherTestSuite) TestCreateDispatcher_WithZeroConfigValues_UsesDefaults() {
+	//act
+	dispatcher := webhook.CreateDispatcher(&suite.TransactionFactoryMock, config.WebhookConfig{})
+
+	//assert
+	suite.Equal(webhook.DefaultPollInterval, dispatcher.Config.PollInterval)
+	suite.Equal(webhook.DefaultBatchSize, dispatcher.Config.BatchSize)
+	suite.Equal(webhook.DefaultTimeout, dispatcher.Config.Timeout)
+	suite.Equal(webhook.DefaultMaxAttempts, dispatcher.Config.MaxAttempts)
+	suite.Equal(webhook.DefaultRetryBaseDelay, dispatcher.Config.RetryBaseDelay)
+	suite.Equal(webhook.DefaultRetryMaxDelay, dispatcher.Config.RetryMaxDelay)
+	suite.Equal(time.Duration(webhook.DefaultTimeout)*time.Millisecond, dispatcher.Client.Timeout)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithErrorCreatingTransaction_ReturnsError() {
+	//arrange
+	suite.TransactionFactoryMock = databasemocks.TransactionFactory{}
+	suite.TransactionFactoryMock.On("CreateTransaction", mock.Anything).Return(nil, errors.New("create transaction error"))
+
+	//act
+	count, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.Zero(count)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "create transaction error")
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithErrorGettingPendingEvents_ReturnsError() {
+	//arrange
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("get events error"))
+
+	//act
+	count, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+
+	suite.Zero(count)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "get events error")
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithNoEndpoints_LeavesEventsPending() {
+	//arrange
+	suite.Config.Endpoints = nil
+	suite.Dispatcher = webhook.CreateDispatcher(&suite.TransactionFactoryMock, suite.Config)
+
+	//act
+	count, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.NoError(err)
+	suite.Zero(count)
+	suite.TransactionFactoryMock.AssertNotCalled(suite.T(), "CreateTransaction", mock.Anything)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithErrorClaimingEvent_ReturnsErrorWithoutDelivering() {
+	//arrange
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(errors.New("update event error"))
+
+	//act
+	count, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	suite.TransactionMock.AssertNotCalled(suite.T(), "CommitTransaction")
+	suite.Empty(suite.Received)
+
+	suite.Zero(count)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "update event error")
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_CommitsClaimBeforeDeliveringAndRecordsAttemptSeparately() {
+	//arrange
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+
+	var steps []string
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		suite.Mutex.Lock()
+		defer suite.Mutex.Unlock()
+		steps = append(steps, fmt.Sprint("update attempts=", args.Get(1).(*models.OutboxEvent).Attempts))
+	})
+	suite.TransactionMock.On("CommitTransaction").Return(nil).Run(func(mock.Arguments) {
+		suite.Mutex.Lock()
+		defer suite.Mutex.Unlock()
+		steps = append(steps, fmt.Sprint("commit received=", len(suite.Received)))
+	})
+
+	//act
+	_, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal([]string{"update attempts=0", "commit received=0", "update attempts=1", "commit received=1"}, steps)
+	suite.TransactionFactoryMock.AssertNumberOfCalls(suite.T(), "CreateTransaction", 2)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithErrorRecordingAttempt_ReturnsError() {
+	//arrange
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil).Once()
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(errors.New("update event error"))
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	count, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "RollbackTransaction")
+	suite.Len(suite.Received, 1)
+
+	suite.Zero(count)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "update event error")
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithSuccessfulDelivery_SendsSignedEventAndMarksDelivered() {
+	//arrange
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, `{"key":"value"}`)
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	count, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Equal(1, count)
+
+	suite.TransactionMock.AssertCalled(suite.T(), "GetPendingOutboxEvents", mock.Anything, mock.Anything, suite.Config.BatchSize)
+	suite.TransactionMock.AssertCalled(suite.T(), "UpdateOutboxEvent", mock.Anything, event)
+	suite.TransactionMock.AssertCalled(suite.T(), "CommitTransaction")
+
+	suite.Require().Len(suite.Received, 1)
+	req := suite.Received[0]
+	suite.Equal(event.Payload, string(req.Body))
+	suite.Equal(event.ID.String(), req.Header.Get(webhook.IDHeader))
+	suite.Equal(event.Type, req.Header.Get(webhook.EventHeader))
+	suite.Equal("application/json", req.Header.Get("Content-Type"))
+
+	timestamp, err := strconv.ParseInt(req.Header.Get(webhook.TimestampHeader), 10, 64)
+	suite.Require().NoError(err)
+	suite.True(webhook.Verify("secret", timestamp, req.Body, req.Header.Get(webhook.SignatureHeader)))
+
+	suite.Equal(1, event.Attempts)
+	suite.False(event.DeliveredAt.IsZero())
+	suite.Empty(event.LastError)
+	suite.False(event.DeadLettered)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_SkipsEndpointsNotSubscribedToEventType() {
+	//arrange
+	suite.Config.Endpoints[0].Events = []string{models.OutboxEventTypeUserDeleted}
+	suite.Dispatcher = webhook.CreateDispatcher(&suite.TransactionFactoryMock, suite.Config)
+
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	_, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.Require().NoError(err)
+	suite.Empty(suite.Received)
+	suite.False(event.DeliveredAt.IsZero())
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithFailedDelivery_SchedulesRetryWithBackoff() {
+	//arrange
+	suite.ReceiverStatus = http.StatusInternalServerError
+
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+	event.Attempts = 1
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	_, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.Require().NoError(err)
+
+	//the second attempt doubles the base delay, capped at the max delay
+	suite.Equal(2, event.Attempts)
+	suite.WithinDuration(time.Now().Add(1500*time.Millisecond), event.NextAttemptAt, time.Second)
+	suite.Contains(event.LastError, "500")
+	suite.True(event.DeliveredAt.IsZero())
+	suite.False(event.DeadLettered)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchPending_WithFailedDeliveryOnLastAttempt_DeadLettersEvent() {
+	//arrange
+	suite.ReceiverStatus = http.StatusBadRequest
+
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+	event.Attempts = suite.Config.MaxAttempts - 1
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	_, err := suite.Dispatcher.DispatchPending(context.Background())
+
+	//assert
+	suite.Require().NoError(err)
+
+	suite.Equal(suite.Config.MaxAttempts, event.Attempts)
+	suite.True(event.DeadLettered)
+	suite.Contains(event.LastError, "400")
+	suite.True(event.DeliveredAt.IsZero())
+}
+
+func (suite *DispatcherTestSuite) TestStartAndStop_DeliversPendingEventsUntilStopped() {
+	//arrange
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil).Once()
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	//act
+	suite.Dispatcher.Start()
+	suite.Eventually(func() bool {
+		suite.Mutex.Lock()
+		defer suite.Mutex.Unlock()
+		return len(suite.Received) == 1
+	}, time.Second, 10*time.Millisecond)
+	suite.Dispatcher.Stop()
+
+	//assert
+	suite.TransactionMock.AssertCalled(suite.T(), "UpdateOutboxEvent", mock.Anything, event)
+}
+
+func (suite *DispatcherTestSuite) TestStop_CancelsDeliveriesInProgressWithoutRecordingAttempt() {
+	//arrange
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case received <- struct{}{}:
+		default:
+		}
+
+		select {
+		case <-req.Context().Done():
+		case <-release:
+		}
+	}))
+	defer receiver.Close()
+	defer close(release)
+
+	suite.Config.Endpoints = []config.WebhookEndpointConfig{{URL: receiver.URL, Secret: "secret"}}
+	suite.Config.Timeout = 60000
+	suite.Dispatcher = webhook.CreateDispatcher(&suite.TransactionFactoryMock, suite.Config)
+
+	event := models.CreateNewOutboxEvent(models.OutboxEventTypeUserCreated, "{}")
+
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{event}, nil).Once()
+	suite.TransactionMock.On("GetPendingOutboxEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*models.OutboxEvent{}, nil)
+	suite.TransactionMock.On("UpdateOutboxEvent", mock.Anything, mock.Anything).Return(nil)
+	suite.TransactionMock.On("CommitTransaction").Return(nil)
+
+	suite.Dispatcher.Start()
+	<-received
+
+	//act
+	start := time.Now()
+	suite.Dispatcher.Stop()
+
+	//assert
+	suite.Less(int64(time.Since(start)), int64(5*time.Second))
+	suite.TransactionMock.AssertNumberOfCalls(suite.T(), "UpdateOutboxEvent", 1)
+	suite.Zero(event.Attempts)
+}
+
+func TestDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, &DispatcherTestSuite{})
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers sent with every webhook delivery.
+const (
+	// IDHeader is the id of the event. Deliveries may be repeated, so receivers should use it to ignore duplicates.
+	IDHeader = "X-Webhook-ID"
+
+	// EventHeader is the type of the event.
+	EventHeader = "X-Webhook-Event"
+
+	// TimestampHeader is the unix time in seconds the delivery was signed at.
+	TimestampHeader = "X-Webhook-Timestamp"
+
+	// SignatureHeader is the hmac signature of the timestamp and body.
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// signaturePrefix identifies the algorithm used to create the signature.
+const signaturePrefix = "sha256="
+
+// Sign creates the signature of the timestamp and body using the secret.
+// The signature is the hex encoded HMAC-SHA256 of the timestamp, a period and the body, prefixed with "sha256=".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature matches the timestamp and body using the secret.
+// Receivers should also reject timestamps that are too old to prevent replays.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,63 @@
+package webhook_test
+
+import (
+	"authserver/webhook"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SignatureTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SignatureTestSuite) TestSign_CreatesPrefixedHexSignature() {
+	//act
+	signature := webhook.Sign("secret", 1600000000, []byte("body"))
+
+	//assert
+	suite.True(strings.HasPrefix(signature, "sha256="))
+	suite.Len(signature, len("sha256=")+64)
+}
+
+func (suite *SignatureTestSuite) TestVerify_WithMatchingSignature_ReturnsTrue() {
+	//arrange
+	signature := webhook.Sign("secret", 1600000000, []byte("body"))
+
+	//act
+	result := webhook.Verify("secret", 1600000000, []byte("body"), signature)
+
+	//assert
+	suite.True(result)
+}
+
+func (suite *SignatureTestSuite) TestVerify_WithChangedFields_ReturnsFalse() {
+	//arrange
+	signature := webhook.Sign("secret", 1600000000, []byte("body"))
+
+	var tests = []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+	}{
+		{"different secret", "other secret", 1600000000, "body"},
+		{"different timestamp", "secret", 1600000001, "body"},
+		{"different body", "secret", 1600000000, "other body"},
+	}
+
+	for _, test := range tests {
+		suite.Run(test.name, func() {
+			//act
+			result := webhook.Verify(test.secret, test.timestamp, []byte(test.body), signature)
+
+			//assert
+			suite.False(result)
+		})
+	}
+}
+
+func TestSignatureTestSuite(t *testing.T) {
+	suite.Run(t, &SignatureTestSuite{})
+}